@@ -13,6 +13,8 @@ import (
 
 	"github.com/TryCadence/Cadence/internal/ai"
 	"github.com/TryCadence/Cadence/internal/analyzer"
+	"github.com/TryCadence/Cadence/internal/baseline"
+	"github.com/TryCadence/Cadence/internal/cache"
 	"github.com/TryCadence/Cadence/internal/config"
 	"github.com/TryCadence/Cadence/internal/detector"
 	"github.com/TryCadence/Cadence/internal/git"
@@ -29,6 +31,19 @@ var (
 	analyzeMinTimeDelta        int64
 	analyzeBranch              string
 	analyzeExcludeFiles        []string
+	analyzeMergeParent         int
+	analyzeConcurrency         int
+	analyzeNoCache             bool
+	analyzeCacheDir            string
+	analyzeRange               string
+	analyzeSince               string
+	analyzeUntil               string
+	analyzeAuthor              string
+	analyzePaths               []string
+	analyzeBaselineDepth       int
+	analyzeWriteBaseline       string
+	analyzeBaselineFile        string
+	analyzeFailOn              failOn
 )
 
 var analyzeCmd = &cobra.Command{
@@ -38,7 +53,27 @@ var analyzeCmd = &cobra.Command{
 
 The repository argument should be a local directory path to a git repository
 
-Requires threshold configuration via flags or config file`,
+Requires threshold configuration via flags or config file
+
+Use --range, --since, --until, --author and --path to analyze only part of
+the history, e.g. the commits a pull request introduces:
+
+  cadence analyze . --range origin/main..HEAD -o pr.json
+
+Commits outside the selection still form the baseline for statistical
+anomaly detection.
+
+Use --write-baseline to record the current findings, and --baseline in
+later runs to report only findings missing from that file. Such a run
+fails when it finds any:
+
+  cadence analyze . -o report.json --write-baseline cadence-baseline.json
+  cadence analyze . -o report.json --baseline cadence-baseline.json
+
+Use --fail-on-score, --fail-on-count and --fail-on-severity to fail the
+run, after the report is written, when it finds too much. Each gate
+exits with its own code: 2 for score, 3 for count, 4 for severity and 5
+for findings missing from the baseline. Other errors exit with 1.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runAnalyze,
 }
@@ -52,7 +87,24 @@ func init() {
 	analyzeCmd.Flags().Float64Var(&analyzeMaxDeletionsMin, "max-deletions-pm", 0, "max deletions per minute (0 to disable)")
 	analyzeCmd.Flags().Int64Var(&analyzeMinTimeDelta, "min-time-delta", 0, "min seconds between commits (0 to disable)")
 	analyzeCmd.Flags().StringVar(&analyzeBranch, "branch", "", "branch to analyze")
-	analyzeCmd.Flags().StringSliceVar(&analyzeExcludeFiles, "exclude-files", []string{}, "file patterns to exclude (e.g., *.log,*.tmp)")
+	analyzeCmd.Flags().StringSliceVar(&analyzeExcludeFiles, "exclude-files", []string{}, "gitignore-style file patterns to exclude (e.g., *.log,node_modules/,!keep.log)")
+	analyzeCmd.Flags().StringVar(&analyzeRange, "range", "", "revision range to analyze (base..head)")
+	analyzeCmd.Flags().StringVar(&analyzeSince, "since", "", "only analyze commits authored on or after this date (YYYY-MM-DD or RFC3339)")
+	analyzeCmd.Flags().StringVar(&analyzeUntil, "until", "", "only analyze commits authored on or before this date (YYYY-MM-DD or RFC3339)")
+	analyzeCmd.Flags().StringVar(&analyzeAuthor, "author", "", "only analyze commits whose author name or email contains this text")
+	analyzeCmd.Flags().StringSliceVar(&analyzePaths, "path", []string{}, "only analyze commits touching these paths (directories or globs)")
+	analyzeCmd.Flags().IntVar(&analyzeBaselineDepth, "baseline-depth", 0, "max commits of surrounding history used as baseline when filtering (0 for all)")
+	analyzeCmd.Flags().StringVar(&analyzeWriteBaseline, "write-baseline", "", "record the fingerprints of every finding in this file")
+	analyzeCmd.Flags().StringVar(&analyzeBaselineFile, "baseline", "", "report only findings missing from this baseline file, and fail if there are any")
+	analyzeCmd.MarkFlagsMutuallyExclusive("write-baseline", "baseline")
+	analyzeFailOn.addFlags(analyzeCmd,
+		"exit with 2 if a commit scores at or above this (0 to disable)",
+		"exit with 3 if at least this many commits are suspicious (0 to disable)",
+		"exit with 4 if a finding is at least this severe: low, medium or high")
+	analyzeCmd.Flags().IntVar(&analyzeConcurrency, "concurrency", 0, "number of commit pairs diffed in parallel (0 for one per CPU)")
+	analyzeCmd.Flags().BoolVar(&analyzeNoCache, "no-cache", false, "do not read or write the diff cache")
+	analyzeCmd.Flags().StringVar(&analyzeCacheDir, "cache-dir", "", "diff cache directory (defaults to the user cache directory)")
+	analyzeCmd.Flags().IntVar(&analyzeMergeParent, "merge-parent", 0, "diff merge commits against this parent (1 = first parent, 0 to skip merges)")
 }
 
 func runAnalyze(cmd *cobra.Command, args []string) error {
@@ -63,6 +115,9 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if err := analyzeFailOn.validate(); err != nil {
+		return err
+	}
 
 	// Handle remote repositories (GitHub URLs)
 	if isRemoteRepo(repoPath) {
@@ -87,14 +142,7 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 	}
 
 	// Auto-detect cadence.yml in current directory if no config specified
-	cfgPath := configFile
-	if cfgPath == "" {
-		if _, err := os.Stat("cadence.yml"); err == nil {
-			cfgPath = "cadence.yml"
-		}
-	}
-
-	cfg, err := config.Load(cfgPath)
+	cfg, err := config.Load(resolveConfigPath())
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
@@ -117,24 +165,46 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 	if cmd.Flags().Changed("exclude-files") {
 		cfg.ExcludeFiles = analyzeExcludeFiles
 	}
+	if cmd.Flags().Changed("merge-parent") {
+		cfg.MergeParent = analyzeMergeParent
+	}
+	if cmd.Flags().Changed("concurrency") {
+		cfg.Concurrency = analyzeConcurrency
+	}
+	if cmd.Flags().Changed("no-cache") {
+		cfg.Cache.Enabled = !analyzeNoCache
+	}
+	if cmd.Flags().Changed("cache-dir") {
+		cfg.Cache.Dir = analyzeCacheDir
+	}
 
 	if cfg.Thresholds.IsZero() {
 		return fmt.Errorf("no thresholds configured - please set thresholds via config file or flags")
 	}
 
-	repoOpts := &git.RepositoryOptions{
-		ExcludeFiles: cfg.ExcludeFiles,
+	if err := addIgnoreFile(repoPath, cfg); err != nil {
+		return err
 	}
 
-	repo, err := git.OpenRepository(repoPath, repoOpts)
+	repo, store, err := openRepository(repoPath, cfg)
 	if err != nil {
-		return fmt.Errorf("failed to open repository: %w", err)
+		return err
 	}
 	defer func() { _ = repo.Close() }()
 
 	a := analyzer.New(repo)
 	opts := &git.CommitOptions{
-		Branch: analyzeBranch,
+		Branch:        analyzeBranch,
+		Range:         analyzeRange,
+		Author:        analyzeAuthor,
+		Paths:         analyzePaths,
+		BaselineDepth: analyzeBaselineDepth,
+	}
+	if opts.Since, err = parseDate(analyzeSince, false); err != nil {
+		return fmt.Errorf("invalid --since: %w", err)
+	}
+	if opts.Until, err = parseDate(analyzeUntil, true); err != nil {
+		return fmt.Errorf("invalid --until: %w", err)
 	}
 
 	fmt.Fprintln(os.Stderr, "Analyzing repository...")
@@ -152,12 +222,26 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create detector: %w", err)
 	}
 
-	suspicious := det.DetectSuspicious(result.CommitPairs, stats)
+	if len(result.BaselinePairs) > 0 {
+		det.SetBaseline(result.BaselinePairs)
+	}
+
+	if analyzeBaselineFile != "" {
+		known, err := baseline.Load(analyzeBaselineFile)
+		if err != nil {
+			return err
+		}
+		det.SetKnown(known)
+	}
+
+	detection := det.Detect(result.CommitPairs, stats)
+	suspicious := detection.Suspicious
+	warnExpired(detection.ExpiredSuppressions)
 
 	// Perform AI analysis on suspicious commits if enabled
 	if cfg.AI.Enabled && len(suspicious) > 0 {
 		fmt.Fprintf(os.Stderr, "Performing AI analysis on %d suspicious commits...\n", len(suspicious))
-		if err := performAIAnalysis(suspicious, &cfg.AI); err != nil {
+		if err := performAIAnalysis(suspicious, &cfg.AI, store); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: AI analysis failed: %v\n", err)
 		}
 	}
@@ -168,9 +252,14 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 	}
 
 	reportData := &reporter.ReportData{
-		Suspicious: suspicious,
-		Stats:      stats,
-		Thresholds: &cfg.Thresholds,
+		Suspicious:     suspicious,
+		AuthorFindings: detection.Authors,
+		WindowFindings: detection.Windows,
+		Suppressed:     detection.Suppressed,
+		Expired:        detection.ExpiredSuppressions,
+		Known:          detection.Known,
+		Stats:          stats,
+		Thresholds:     &cfg.Thresholds,
 	}
 
 	reportStr, err := rep.Generate(reportData)
@@ -191,7 +280,95 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Fprintf(os.Stderr, "Report written to %s\n", outputPath)
 
-	return nil
+	if analyzeWriteBaseline != "" {
+		b := baseline.New(detection)
+		if err := b.Save(analyzeWriteBaseline); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Baseline of %d findings written to %s\n", len(b.Findings), analyzeWriteBaseline)
+	}
+
+	if analyzeBaselineFile != "" {
+		fmt.Fprintf(os.Stderr, "%d findings already in the baseline\n", detection.Known)
+		if n := countFindings(detection); n > 0 {
+			cmd.SilenceUsage = true
+			return &gateError{exitNewFindings, fmt.Sprintf("%d new findings not in baseline %s", n, analyzeBaselineFile)}
+		}
+	}
+
+	return analyzeFailOn.check(cmd, analyzeMeasures(detection))
+}
+
+// analyzeMeasures summarizes a detection for the --fail-on gates: the
+// highest commit score, the number of suspicious commits and the highest
+// severity of a commit or of an author or window finding.
+func analyzeMeasures(result *detector.Result) gateMeasures {
+	m := gateMeasures{count: len(result.Suspicious)}
+	raise := func(s detector.Severity) {
+		if !detector.SeverityAtLeast(m.severity, s) {
+			m.severity = s
+		}
+	}
+	for _, s := range result.Suspicious {
+		if s.Score > m.score {
+			m.score = s.Score
+		}
+		raise(s.Severity)
+	}
+	for _, f := range result.Authors {
+		raise(f.Severity)
+	}
+	for _, f := range result.Windows {
+		raise(f.Severity)
+	}
+	m.scoreText = fmt.Sprintf("highest commit score %.2f", m.score)
+	m.countText = fmt.Sprintf("%d suspicious commits", m.count)
+	return m
+}
+
+// countFindings counts the reported findings of every scope.
+func countFindings(result *detector.Result) int {
+	n := len(result.Authors) + len(result.Windows)
+	for _, s := range result.Suspicious {
+		n += len(s.Findings)
+	}
+	return n
+}
+
+// openRepository opens the repository at repoPath with the configured
+// excludes, merge handling, concurrency and aliases, backed by the diff
+// cache when it is enabled. The store is nil when caching is off.
+func openRepository(repoPath string, cfg *config.Config) (git.Repository, *cache.Store, error) {
+	repoOpts := &git.RepositoryOptions{
+		ExcludeFiles: cfg.ExcludeFiles,
+		MergeParent:  cfg.MergeParent,
+		Concurrency:  cfg.Concurrency,
+		Aliases:      cfg.Authors.Aliases,
+		AnalyzeGo:    cfg.Thresholds.StrategyEnabled("go_source_analysis"),
+	}
+
+	var store *cache.Store
+	if cfg.Cache.Enabled {
+		var err error
+		store, err = cache.Open(cache.Options{
+			Dir:          cfg.Cache.Dir,
+			ExcludeFiles: cfg.ExcludeFiles,
+			AnalyzeGo:    repoOpts.AnalyzeGo,
+			MaxDiffBytes: cfg.Cache.MaxDiffBytes,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: diff cache disabled: %v\n", err)
+			store = nil
+		} else {
+			repoOpts.Cache = store
+		}
+	}
+
+	repo, err := git.OpenRepository(repoPath, repoOpts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+	return repo, store, nil
 }
 
 func isRemoteRepo(path string) bool {
@@ -252,7 +429,9 @@ func cloneRemoteRepo(repoURL string) (tempDir string, cleanup func() error, err
 	return tempDir, cleanup, nil
 }
 
-func performAIAnalysis(suspicious []*detector.SuspiciousCommit, aiConfig *config.AIConfig) error {
+// performAIAnalysis asks the configured model about each suspicious commit.
+// Verdicts are cached per commit and model when a store is given.
+func performAIAnalysis(suspicious []*detector.SuspiciousCommit, aiConfig *config.AIConfig, store *cache.Store) error {
 	aiAnalyzer, err := ai.NewAnalyzer(&ai.Config{
 		Enabled:   aiConfig.Enabled,
 		Provider:  aiConfig.Provider,
@@ -272,6 +451,13 @@ func performAIAnalysis(suspicious []*detector.SuspiciousCommit, aiConfig *config
 	defer cancel()
 
 	for i, commit := range suspicious {
+		if store != nil {
+			if verdict, ok := store.GetAIVerdict(commit.Pair.Current.Hash, aiConfig.Model); ok {
+				commit.AIAnalysis = verdict
+				continue
+			}
+		}
+
 		additions := getCommitAdditions(commit.Pair)
 		if additions == "" {
 			continue
@@ -286,6 +472,9 @@ func performAIAnalysis(suspicious []*detector.SuspiciousCommit, aiConfig *config
 		}
 
 		commit.AIAnalysis = analysis
+		if store != nil {
+			_ = store.PutAIVerdict(commit.Pair.Current.Hash, aiConfig.Model, analysis)
+		}
 	}
 
 	return nil
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/TryCadence/Cadence/internal/cache"
+	"github.com/TryCadence/Cadence/internal/config"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect and prune the analysis cache",
+	Long: `Inspect and prune the on-disk cache of diff stats, diff content and AI verdicts.
+
+Entries are grouped by a fingerprint of the exclude_files patterns they were
+computed with. Changing the patterns starts a new partition; old partitions can
+be pruned.
+
+Examples:
+  cadence cache info
+  cadence cache prune --older-than 30d
+  cadence cache prune --fingerprint 3f2a9c1d0b7e4a55
+  cadence cache prune --all`,
+}
+
+var cacheInfoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Show cache location and size",
+	Long:  `Show the cache directory and the number, size and age of entries in each partition.`,
+	Args:  cobra.NoArgs,
+	RunE:  runCacheInfo,
+}
+
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove cache entries",
+	Long:  `Remove cache entries by age, by partition fingerprint, or all of them.`,
+	Args:  cobra.NoArgs,
+	RunE:  runCachePrune,
+}
+
+var cacheFlags struct {
+	dir         string
+	olderThan   string
+	fingerprint string
+	all         bool
+}
+
+func init() {
+	cacheCmd.PersistentFlags().StringVar(&cacheFlags.dir, "dir", "", "cache directory (defaults to cache.dir or the user cache directory)")
+	cachePruneCmd.Flags().StringVar(&cacheFlags.olderThan, "older-than", "", "remove entries older than this age (e.g. 72h, 30d)")
+	cachePruneCmd.Flags().StringVar(&cacheFlags.fingerprint, "fingerprint", "", "only remove entries of this partition")
+	cachePruneCmd.Flags().BoolVar(&cacheFlags.all, "all", false, "remove every entry")
+	cacheCmd.AddCommand(cacheInfoCmd, cachePruneCmd)
+}
+
+func runCacheInfo(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load(resolveConfigPath())
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	dir, err := cacheDir(cfg)
+	if err != nil {
+		return err
+	}
+
+	info, err := cache.Inspect(dir)
+	if err != nil {
+		return fmt.Errorf("failed to inspect cache: %w", err)
+	}
+
+	current := cache.Fingerprint(cfg.ExcludeFiles, cfg.Thresholds.StrategyEnabled("go_source_analysis"))
+
+	fmt.Printf("Cache directory: %s\n", info.Dir)
+	fmt.Printf("Total entries:   %d (%s)\n", info.TotalEntries(), formatBytes(info.TotalBytes()))
+	if len(info.Partitions) == 0 {
+		fmt.Println("Cache is empty.")
+		return nil
+	}
+
+	fmt.Println()
+	for _, p := range info.Partitions {
+		marker := ""
+		if p.Fingerprint == current {
+			marker = " (current exclude_files)"
+		}
+		fmt.Printf("%s%s\n", p.Fingerprint, marker)
+		fmt.Printf("  Diff entries:  %d\n", p.DiffEntries)
+		fmt.Printf("  AI verdicts:   %d\n", p.AIEntries)
+		fmt.Printf("  Size:          %s\n", formatBytes(p.Bytes))
+		fmt.Printf("  Oldest:        %s\n", p.Oldest.Format(time.RFC3339))
+		fmt.Printf("  Newest:        %s\n", p.Newest.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func runCachePrune(cmd *cobra.Command, args []string) error {
+	cfg, err := config.Load(resolveConfigPath())
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	dir, err := cacheDir(cfg)
+	if err != nil {
+		return err
+	}
+
+	opts := cache.PruneOptions{
+		Fingerprint: cacheFlags.fingerprint,
+		All:         cacheFlags.all,
+	}
+	if cacheFlags.olderThan != "" {
+		opts.OlderThan, err = parseAge(cacheFlags.olderThan)
+		if err != nil {
+			return err
+		}
+	}
+
+	result, err := cache.Prune(dir, opts)
+	if err != nil {
+		return fmt.Errorf("failed to prune cache: %w", err)
+	}
+
+	fmt.Printf("Removed %d entries (%s) from %s\n", result.Removed, formatBytes(result.Bytes), dir)
+	return nil
+}
+
+func cacheDir(cfg *config.Config) (string, error) {
+	if cacheFlags.dir != "" {
+		return cacheFlags.dir, nil
+	}
+	if cfg.Cache.Dir != "" {
+		return cfg.Cache.Dir, nil
+	}
+	return cache.DefaultDir()
+}
+
+// parseAge parses a Go duration, additionally accepting whole days ("30d").
+func parseAge(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid age: %s", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid age: %s (use e.g. 72h or 30d)", value)
+	}
+	return d, nil
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -54,13 +54,166 @@ thresholds:
   # PRECISION ANALYSIS
   enable_precision_analysis: true
 
-# File patterns to exclude from analysis
+# AI TOOL FINGERPRINTS
+# Commits whose trailers, message or identities name an AI tool are flagged
+# with high confidence. The built-in list covers Claude Code, GitHub Copilot,
+# Aider, Cursor, Devin, Codex, Gemini and "Generated with ..." footers.
+fingerprints:
+  defaults: true
+  # field is trailer, message, author or committer; pattern is a
+  # case-insensitive regular expression; key limits trailer matches
+  custom: []
+  #  - name: Internal codegen bot
+  #    field: trailer
+  #    key: Co-authored-by
+  #    pattern: "codegen-bot@example\\.com"
+
+# LANGUAGE MODEL
+# An n-gram model of this repository's code and commit messages, trained
+# with "cadence model train <repo> --before <date>". Commits after the
+# training cutoff that are far more predictable than the history are
+# flagged by language_model_analysis. Relative paths are resolved from the
+# directory of this file.
+language_model: ""
+
+# SCORING
+# Each strategy that fires reports a strength between 0 and 1. Strengths
+# are multiplied by the strategy's weight and combined with a noisy-OR,
+# score = 1 - (1 - w1*s1) * (1 - w2*s2) * ..., so independent signals
+# reinforce each other. Scores below 0.4 are low severity, below 0.7
+# medium, and high otherwise. Override any built-in weight (0.0 - 1.0):
+scoring:
+  weights: {}
+  #  velocity_analysis: 0.6
+  #  size_analysis: 0.4
+  #  commit_message_analysis: 0.0   # report reasons but ignore in score
+
+# STRATEGIES
+# Switch any strategy on or off by name and override its parameters.
+# size, velocity, timing, dispersion and ratio analysis run when their
+# thresholds above are set.
+# timing_cluster, author_behavior_change, commit_gap_anomaly and
+# author_style_drift look at the whole history at once and attach their
+# findings to single commits; cadence_shift reports authors and
+# uniform_commit_burst windows of time.
+# Parameters and their defaults:
+#   commit_message_analysis: min_ai_phrases 2, min_generic_phrases 1,
+#     verbose_min_words 8
+#   naming_pattern_analysis: min_generic_terms 2, min_indicators 2,
+#     max_camel_case_ratio 0.3, lines_per_handler 20
+#   structural_consistency_analysis: min_lines 100, balance_tolerance 0.1,
+#     ratio_tolerance 0.05
+#   burst_pattern_analysis: window_seconds 300, min_additions 50
+#   error_handling_analysis: min_additions 50, large_commit_lines 300,
+#     lines_per_handler 30, min_sparse_additions 100 (too little handling
+#     is only reported above min_sparse_additions added lines)
+#   template_pattern_analysis: min_additions 50, min_indicators 2,
+#     message_min_additions 100
+#   go_source_analysis: min_code_lines 30, max_comment_ratio 0.5,
+#     min_error_checks 5, min_wrapped_share 0.9, min_functions 4,
+#     max_length_variation 0.2, min_identifiers 20, min_mean_name_length 9,
+#     min_indicators 2 (measured on the changed lines of parsed .go files)
+#   near_clone_analysis: min_tokens 40, min_added_tokens 300,
+#     min_similarity 0.5 (share of added tokens inside near-duplicate
+#     fragments of at least min_tokens tokens)
+#   language_model_analysis: min_code_tokens 100, min_message_words 8,
+#     min_lines 5, max_z 2, min_baseline_commits 10 (fires when perplexity
+#     or burstiness falls max_z standard deviations below the model's
+#     held-out commits; runs when language_model is set)
+#   emoji_pattern_analysis: min_message_emojis 3, max_message_ratio 0.2,
+#     min_code_emojis 5 (code counts comments and string literals of added
+#     lines)
+#   special_character_pattern_analysis: min_message_marks 2,
+#     min_code_marks 3, max_line_density 0.25 (marks are em and en dashes,
+#     ellipses, curly double quotes, arrows and bullets)
+#   file_extension_analysis: min_files 10, min_additions 1000,
+#     min_lines_per_file 50, max_lines_per_file 200, min_consistency 0.8
+#   precision_analysis: min_lines 50, balance_tolerance 0.05,
+#     min_lines_per_file 100, max_lines_per_file 150
+#   StatisticalAnomaly: window_days 90, min_commits 10 (compares each
+#     commit with its author's commits in the window_days before it, then
+#     everyone's, then the whole repository, using the first with
+#     min_commits commits)
+#   TimingAnomaly: short_gap_seconds 60, short_gap_lines 50,
+#     long_gap_hours 24, long_gap_lines 500
+#   uniform_commit_burst: window_minutes 10, min_commits 10,
+#     max_size_variation 0.25
+#   cadence_shift: min_commits 12, min_segment 5, min_ratio 10
+#   author_style_drift: min_commits 10, min_features 4, max_distance 2.5,
+#     min_drift 2 (compares indentation, identifier length, comment
+#     phrasing, message length and casing and punctuation with the
+#     author's earlier commits; features at least min_drift standard
+#     deviations off are named in the report)
+strategies: {}
+#  commit_message_analysis:
+#    enabled: false
+#  burst_pattern_analysis:
+#    window_seconds: 120
+#    min_additions: 200
+#  emoji_pattern_analysis:
+#    enabled: false
+
+# File patterns to exclude from analysis, in .gitignore syntax: names
+# without a slash match at any depth, a trailing / matches directories,
+# a leading / anchors to the repository root, ** spans directories and
+# ! re-includes a file excluded by an earlier pattern. Files marked
+# linguist-generated, linguist-vendored or -diff in .gitattributes are
+# always excluded.
 exclude_files:
   - package-lock.json
   - yarn.lock
   - "*.min.js"
   - "*.min.css"
-  - "node_modules/**"
+  - "node_modules/"
+
+# SUPPRESSIONS
+# Findings that were reviewed and cleared. Each entry sets any of commit
+# (a hash or prefix of at least 7 characters), author (email or name),
+# path (a pattern every file of the finding must match, in the syntax of
+# exclude_files) and strategy; a finding is suppressed when it matches all
+# the fields an entry sets. reason is required, and expires (YYYY-MM-DD)
+# ends the suppression after that day with a warning. Suppressed findings
+# are listed in their own report section. A .cadence-ignore file at the
+# repository root may hold more entries under the same key.
+suppressions: []
+#  - commit: 3f2a9c1
+#    reason: Generated protobuf code, reviewed in #142
+#  - path: docs/**
+#    strategy: emoji_pattern_analysis
+#    reason: Docs use emoji headings
+#    expires: 2026-12-31
+
+# Merge commits are skipped by default. Set to 1 to diff each merge
+# against its first parent (what the merge brought in), 2 for the second.
+merge_parent: 0
+
+# Number of commit pairs diffed in parallel (0 = one per CPU)
+concurrency: 0
+
+# Author identities are resolved through the repository's .mailmap first.
+# Aliases then fold any remaining addresses or names of one person into a
+# single identity, so their commits share statistics and baselines. AI
+# tool fingerprints still match the identities as recorded in each commit.
+authors:
+  aliases: []
+  #  - name: Jane Doe
+  #    email: jane@example.com
+  #    aliases:
+  #      - jane@users.noreply.github.com
+  #      - jdoe@old-company.com
+  #      - Jane D
+
+# DIFF CACHE
+# Diff stats, diff content and AI verdicts are cached per commit so that
+# re-running analysis only pays for new commits.
+cache:
+  enabled: true
+
+  # Cache location (empty = user cache directory, e.g. ~/.cache/cadence)
+  dir: ""
+
+  # Diffs larger than this many bytes keep only their stats (0 = 1 MiB)
+  max_diff_bytes: 0
 
 # WEBHOOK SERVER CONFIGURATION
 webhook:
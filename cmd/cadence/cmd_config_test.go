@@ -2,6 +2,7 @@ package main
 
 import (
 	"testing"
+	"time"
 )
 
 func TestConfigCommandProperties(t *testing.T) {
@@ -122,3 +123,48 @@ func TestConfigDefaultOutput(t *testing.T) {
 		}
 	}
 }
+
+func TestCacheCommandRegistered(t *testing.T) {
+	names := make(map[string]bool)
+	for _, cmd := range cacheCmd.Commands() {
+		names[cmd.Name()] = true
+	}
+	for _, want := range []string{"info", "prune"} {
+		if !names[want] {
+			t.Errorf("expected %q subcommand to be registered with cacheCmd", want)
+		}
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		value       string
+		want        time.Duration
+		shouldError bool
+	}{
+		{value: "30d", want: 30 * 24 * time.Hour},
+		{value: "72h", want: 72 * time.Hour},
+		{value: "90m", want: 90 * time.Minute},
+		{value: "0d", shouldError: true},
+		{value: "-5h", shouldError: true},
+		{value: "soon", shouldError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseAge(tt.value)
+			if tt.shouldError {
+				if err == nil {
+					t.Errorf("parseAge(%q) expected error but got none", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseAge(%q) unexpected error = %v", tt.value, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseAge(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/TryCadence/Cadence/internal/analyzer"
+	"github.com/TryCadence/Cadence/internal/config"
+	"github.com/TryCadence/Cadence/internal/detector"
+	"github.com/TryCadence/Cadence/internal/eval"
+	"github.com/TryCadence/Cadence/internal/git"
+	"github.com/TryCadence/Cadence/internal/metrics"
+)
+
+var evalFlags struct {
+	labels         string
+	branch         string
+	scoreThreshold float64
+	fit            bool
+	fitOutput      string
+}
+
+var evalCmd = &cobra.Command{
+	Use:   "eval <repository>",
+	Short: "Measure detection quality against labeled commits",
+	Long: `Run the detector over a local repository and compare its results with
+commits labeled as AI-generated or human-written.
+
+Labels come from a CSV file with hash and label columns, or a JSONL file of
+{"hash": "...", "label": "ai"} objects. Labels are "ai" or "human"; hashes
+may be abbreviated to at least 7 characters.
+
+Prints precision, recall, F1 and ROC-AUC for each enabled strategy and for
+the combined score. With --fit, also fits the numeric thresholds and the
+strategy weights to the labels and writes them as a cadence.yml snippet.
+
+Examples:
+  cadence eval . --labels labels.csv
+  cadence eval . --labels labels.jsonl --fit --fit-output fitted.yml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEval,
+}
+
+func init() {
+	evalCmd.Flags().StringVar(&evalFlags.labels, "labels", "", "CSV or JSONL file of commit hashes labeled ai or human (required)")
+	_ = evalCmd.MarkFlagRequired("labels")
+	evalCmd.Flags().StringVar(&evalFlags.branch, "branch", "", "branch to analyze")
+	evalCmd.Flags().Float64Var(&evalFlags.scoreThreshold, "score-threshold", detector.MediumScore, "combined score at or above which a commit counts as flagged")
+	evalCmd.Flags().BoolVar(&evalFlags.fit, "fit", false, "fit thresholds and weights to the labels")
+	evalCmd.Flags().StringVar(&evalFlags.fitOutput, "fit-output", "", "write the fitted cadence.yml snippet to this file instead of stdout")
+}
+
+func runEval(cmd *cobra.Command, args []string) error {
+	labels, err := eval.LoadLabels(evalFlags.labels)
+	if err != nil {
+		return err
+	}
+	if len(labels) == 0 {
+		return fmt.Errorf("no labels in %s", evalFlags.labels)
+	}
+
+	cfg, err := config.Load(resolveConfigPath())
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	repo, _, err := openRepository(args[0], cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = repo.Close() }()
+
+	fmt.Fprintln(os.Stderr, "Analyzing repository...")
+	result, err := analyzer.New(repo).AnalyzeRepository(&git.CommitOptions{Branch: evalFlags.branch})
+	if err != nil {
+		return fmt.Errorf("analysis failed: %w", err)
+	}
+	stats := metrics.CalculateStats(result.Commits, result.CommitPairs)
+
+	det, err := detector.New(&cfg.Thresholds)
+	if err != nil {
+		return fmt.Errorf("failed to create detector: %w", err)
+	}
+
+	fmt.Fprintln(os.Stderr, "Evaluating detector...")
+	evaluation := eval.Run(det, result.CommitPairs, stats, labels, evalFlags.scoreThreshold)
+	if evaluation.AI+evaluation.Human == 0 {
+		return fmt.Errorf("none of the %d labeled commits were found in the repository", len(labels))
+	}
+	if err := eval.WriteReport(os.Stdout, evaluation); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	if !evalFlags.fit {
+		return nil
+	}
+
+	fmt.Fprintln(os.Stderr, "Fitting thresholds and weights...")
+	fit, err := eval.FitDetector(&cfg.Thresholds, result.CommitPairs, stats, labels, evalFlags.scoreThreshold)
+	if err != nil {
+		return fmt.Errorf("failed to fit: %w", err)
+	}
+
+	if evalFlags.fitOutput == "" {
+		fmt.Println()
+		return eval.WriteFit(os.Stdout, fit)
+	}
+
+	f, err := os.OpenFile(evalFlags.fitOutput, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create fit output: %w", err)
+	}
+	if err := eval.WriteFit(f, fit); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write fit output: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write fit output: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Fitted configuration written to %s\n", evalFlags.fitOutput)
+	return nil
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/TryCadence/Cadence/internal/analyzer"
+	"github.com/TryCadence/Cadence/internal/config"
+	"github.com/TryCadence/Cadence/internal/detector"
+	"github.com/TryCadence/Cadence/internal/exposure"
+	"github.com/TryCadence/Cadence/internal/git"
+	"github.com/TryCadence/Cadence/internal/metrics"
+)
+
+var exposureFlags struct {
+	branch   string
+	minScore float64
+	top      int
+	output   string
+}
+
+var exposureCmd = &cobra.Command{
+	Use:   "exposure <repository>",
+	Short: "Report how much of the current code comes from flagged commits",
+	Long: `Run the detector over a local repository's history, then git blame every
+file at the head of the branch and map each surviving line to the score of
+the commit that last changed it.
+
+Reports the share of likely-AI lines, those whose commit scored at least
+--min-score, for the whole tree and for each directory, package and file.
+Track the total over time, and start review where the share is highest.
+
+The format follows the --output extension (.txt or .json); --output is
+saved in the reports/ directory, and without it a text report is printed.
+Files that fail to blame are skipped with a warning and counted in the
+report.
+
+Examples:
+  cadence exposure .
+  cadence exposure . --min-score 0.6 -o exposure.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExposure,
+}
+
+func init() {
+	exposureCmd.Flags().StringVar(&exposureFlags.branch, "branch", "", "branch to analyze and blame")
+	exposureCmd.Flags().Float64Var(&exposureFlags.minScore, "min-score", detector.MediumScore, "commit score at or above which its lines count as likely AI")
+	exposureCmd.Flags().IntVar(&exposureFlags.top, "top", 20, "entries listed per section of the text report (0 for all)")
+	exposureCmd.Flags().StringVarP(&exposureFlags.output, "output", "o", "", "write report to file (.txt or .json, saved in reports/ directory)")
+}
+
+func runExposure(cmd *cobra.Command, args []string) error {
+	format, err := detectFormatFromExtension(exposureFlags.output)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(resolveConfigPath())
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if err := addIgnoreFile(args[0], cfg); err != nil {
+		return err
+	}
+
+	repo, _, err := openRepository(args[0], cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = repo.Close() }()
+
+	blamer, ok := repo.(git.BlameProvider)
+	if !ok {
+		return fmt.Errorf("repository does not support blame")
+	}
+
+	fmt.Fprintln(os.Stderr, "Analyzing repository...")
+	result, err := analyzer.New(repo).AnalyzeRepository(&git.CommitOptions{Branch: exposureFlags.branch})
+	if err != nil {
+		return fmt.Errorf("analysis failed: %w", err)
+	}
+	stats := metrics.CalculateStats(result.Commits, result.CommitPairs)
+
+	det, err := detector.New(&cfg.Thresholds)
+	if err != nil {
+		return fmt.Errorf("failed to create detector: %w", err)
+	}
+	detection := det.Detect(result.CommitPairs, stats)
+	warnExpired(detection.ExpiredSuppressions)
+	scores := make(map[string]float64)
+	for _, s := range detection.Suspicious {
+		scores[s.Pair.Current.Hash] = s.Score
+	}
+
+	fmt.Fprintln(os.Stderr, "Blaming files...")
+	blames, skipped, err := blamer.Blame(exposureFlags.branch)
+	if err != nil {
+		return fmt.Errorf("blame failed: %w", err)
+	}
+	for _, s := range skipped {
+		fmt.Fprintf(os.Stderr, "Warning: skipped %s: %v\n", s.Path, s.Err)
+	}
+
+	report := exposure.Compute(blames, scores, exposureFlags.minScore)
+	report.SkippedFiles = len(skipped)
+	report.Revision = "HEAD"
+	if exposureFlags.branch != "" {
+		report.Revision = exposureFlags.branch
+	}
+
+	out := os.Stdout
+	var outputPath string
+	if exposureFlags.output != "" {
+		reportsDir := "reports"
+		if err := os.MkdirAll(reportsDir, 0o750); err != nil {
+			return fmt.Errorf("failed to create reports directory: %w", err)
+		}
+		outputPath = filepath.Join(reportsDir, exposureFlags.output)
+
+		f, err := os.OpenFile(outputPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to create report: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		out = f
+	}
+
+	if format == "json" {
+		err = exposure.WriteJSON(out, report)
+	} else {
+		err = exposure.WriteText(out, report, exposureFlags.top)
+	}
+	if err != nil {
+		return err
+	}
+	if outputPath != "" {
+		fmt.Fprintf(os.Stderr, "Report written to %s\n", outputPath)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/TryCadence/Cadence/internal/detector"
+)
+
+// Exit codes of a run that completed but tripped a gate, one per gate so
+// that CI can tell them apart. Any other error exits with 1.
+const (
+	exitFailOnScore    = 2
+	exitFailOnCount    = 3
+	exitFailOnSeverity = 4
+	exitNewFindings    = 5
+)
+
+// gateError fails a completed run with the exit code of the gate it
+// tripped.
+type gateError struct {
+	code int
+	msg  string
+}
+
+func (e *gateError) Error() string {
+	return e.msg
+}
+
+// failOn holds the --fail-on-* flags of a command. A zero value leaves
+// its gate off.
+type failOn struct {
+	score    float64
+	count    int
+	severity string
+}
+
+// gateMeasures is what a run found, as the gates compare it. The texts
+// name the measures in failure messages.
+type gateMeasures struct {
+	score     float64
+	scoreText string
+	count     int
+	countText string
+	// severity is the most severe finding, empty when there is none.
+	severity detector.Severity
+}
+
+func (f *failOn) addFlags(cmd *cobra.Command, scoreUsage, countUsage, severityUsage string) {
+	cmd.Flags().Float64Var(&f.score, "fail-on-score", 0, scoreUsage)
+	cmd.Flags().IntVar(&f.count, "fail-on-count", 0, countUsage)
+	cmd.Flags().StringVar(&f.severity, "fail-on-severity", "", severityUsage)
+}
+
+// validate rejects flag values before the analysis runs, so a typo does
+// not cost a full run. Scores are between 0 and 1 for every command.
+func (f *failOn) validate() error {
+	if f.score < 0 || f.score > 1 {
+		return fmt.Errorf("--fail-on-score must be between 0 and 1")
+	}
+	if f.count < 0 {
+		return fmt.Errorf("--fail-on-count must not be negative")
+	}
+	if f.severity != "" {
+		if _, err := detector.ParseSeverity(f.severity); err != nil {
+			return fmt.Errorf("invalid --fail-on-severity: %w", err)
+		}
+	}
+	return nil
+}
+
+// check returns the gateError of the first gate m trips, trying score,
+// count and severity in that order. The usage message is not printed for
+// a tripped gate, since the command was used correctly.
+func (f *failOn) check(cmd *cobra.Command, m gateMeasures) error {
+	var err *gateError
+	switch {
+	case f.score > 0 && m.score >= f.score:
+		err = &gateError{exitFailOnScore, fmt.Sprintf("%s, reaching --fail-on-score %g", m.scoreText, f.score)}
+	case f.count > 0 && m.count >= f.count:
+		err = &gateError{exitFailOnCount, fmt.Sprintf("%s, reaching --fail-on-count %d", m.countText, f.count)}
+	case f.severity != "":
+		threshold, _ := detector.ParseSeverity(f.severity)
+		if detector.SeverityAtLeast(m.severity, threshold) {
+			err = &gateError{exitFailOnSeverity, fmt.Sprintf("%s severity findings, reaching --fail-on-severity %s", m.severity, threshold)}
+		}
+	}
+	if err == nil {
+		return nil
+	}
+	cmd.SilenceUsage = true
+	return err
+}
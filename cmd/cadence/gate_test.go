@@ -0,0 +1,122 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/TryCadence/Cadence/internal/detector"
+	"github.com/TryCadence/Cadence/internal/detector/patterns"
+	"github.com/TryCadence/Cadence/internal/git"
+)
+
+func TestFailOnValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		f       failOn
+		wantErr bool
+	}{
+		{"off", failOn{}, false},
+		{"all set", failOn{score: 0.7, count: 3, severity: "High"}, false},
+		{"score above max", failOn{score: 1.5}, true},
+		{"negative count", failOn{count: -1}, true},
+		{"unknown severity", failOn{severity: "critical"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.f.validate(); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFailOnCheck(t *testing.T) {
+	found := gateMeasures{
+		score:     0.75,
+		scoreText: "highest commit score 0.75",
+		count:     2,
+		countText: "2 suspicious commits",
+		severity:  detector.SeverityHigh,
+	}
+
+	tests := []struct {
+		name     string
+		f        failOn
+		m        gateMeasures
+		wantCode int
+	}{
+		{"gates off", failOn{}, found, 0},
+		{"score reached", failOn{score: 0.7}, found, exitFailOnScore},
+		{"score not reached", failOn{score: 0.8}, found, 0},
+		{"count reached", failOn{count: 2}, found, exitFailOnCount},
+		{"count not reached", failOn{count: 3}, found, 0},
+		{"severity reached", failOn{severity: "medium"}, found, exitFailOnSeverity},
+		{"nothing found", failOn{severity: "low"}, gateMeasures{}, 0},
+		{"score checked first", failOn{score: 0.5, count: 1, severity: "low"}, found, exitFailOnScore},
+		{"count before severity", failOn{score: 0.9, count: 1, severity: "low"}, found, exitFailOnCount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			err := tt.f.check(cmd, tt.m)
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Errorf("check() error = %v, want nil", err)
+				}
+				return
+			}
+			var gate *gateError
+			if !errors.As(err, &gate) || gate.code != tt.wantCode {
+				t.Fatalf("check() error = %v, want exit code %d", err, tt.wantCode)
+			}
+			if !cmd.SilenceUsage {
+				t.Error("check() left usage on for a tripped gate")
+			}
+		})
+	}
+}
+
+func TestAnalyzeMeasures(t *testing.T) {
+	pair := &git.CommitPair{Current: &git.Commit{Hash: "abc1234"}}
+	result := &detector.Result{
+		Suspicious: []*detector.SuspiciousCommit{
+			{Pair: pair, Score: 0.3, Severity: detector.SeverityLow},
+			{Pair: pair, Score: 0.55, Severity: detector.SeverityMedium},
+		},
+		Windows: []detector.Finding{{Severity: detector.SeverityHigh}},
+	}
+
+	m := analyzeMeasures(result)
+	if m.score != 0.55 || m.count != 2 || m.severity != detector.SeverityHigh {
+		t.Errorf("analyzeMeasures() = %+v", m)
+	}
+	if m.scoreText != "highest commit score 0.55" || m.countText != "2 suspicious commits" {
+		t.Errorf("texts = %q, %q", m.scoreText, m.countText)
+	}
+
+	if m := analyzeMeasures(&detector.Result{}); m.score != 0 || m.count != 0 || m.severity != "" {
+		t.Errorf("analyzeMeasures() of nothing = %+v", m)
+	}
+}
+
+func TestWebMeasures(t *testing.T) {
+	result := &patterns.TextSlopResult{
+		Patterns: []patterns.Pattern{
+			{Type: "overused_phrases", Severity: 0.5},
+			{Type: "generic_language", Severity: 0.3},
+		},
+		SuspicionRate: 0.4,
+	}
+
+	m := webMeasures(result)
+	if m.score != 0.4 || m.count != 2 || m.severity != detector.SeverityMedium {
+		t.Errorf("webMeasures() = %+v", m)
+	}
+	if m.scoreText != "confidence score 0.40" || m.countText != "2 patterns detected" {
+		t.Errorf("texts = %q, %q", m.scoreText, m.countText)
+	}
+}
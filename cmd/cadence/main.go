@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -14,6 +15,10 @@ var (
 func main() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
+		var gate *gateError
+		if errors.As(err, &gate) {
+			os.Exit(gate.code)
+		}
 		os.Exit(1)
 	}
 }
@@ -34,5 +39,5 @@ Use 'cadence --help' to see all available commands.`,
 func init() {
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "config file path")
-	rootCmd.AddCommand(analyzeCmd, webCmd, configCmd, versionCmd, webhookCmd)
+	rootCmd.AddCommand(analyzeCmd, evalCmd, exposureCmd, modelCmd, webCmd, configCmd, versionCmd, webhookCmd, cacheCmd)
 }
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/TryCadence/Cadence/internal/config"
+	"github.com/TryCadence/Cadence/internal/git"
+	"github.com/TryCadence/Cadence/internal/langmodel"
+)
+
+var modelCmd = &cobra.Command{
+	Use:   "model",
+	Short: "Train and inspect the repository language model",
+	Long: `Train an n-gram language model of a repository's own code and commit
+messages, and inspect trained models.
+
+Point language_model in cadence.yml at the trained file to enable
+language_model_analysis, which flags commits after the training cutoff that
+are far more predictable than the history the model learned from.
+
+Examples:
+  cadence model train . --before 2023-01-01
+  cadence model info cadence.model`,
+}
+
+var modelTrainCmd = &cobra.Command{
+	Use:   "train <repository>",
+	Short: "Train a language model from commit history",
+	Long: `Train a language model from the added lines and messages of every commit
+authored before --before, and write it to --output. Choose a cutoff before
+AI tools came into use in the repository, so the model learns how its
+authors write.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runModelTrain,
+}
+
+var modelInfoCmd = &cobra.Command{
+	Use:   "info <model>",
+	Short: "Show what a language model was trained on",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runModelInfo,
+}
+
+var modelFlags struct {
+	before string
+	branch string
+	output string
+}
+
+func init() {
+	modelTrainCmd.Flags().StringVar(&modelFlags.before, "before", "", "only train on commits authored before this date (YYYY-MM-DD or RFC3339, default now)")
+	modelTrainCmd.Flags().StringVar(&modelFlags.branch, "branch", "", "branch to train on")
+	modelTrainCmd.Flags().StringVarP(&modelFlags.output, "output", "o", "cadence.model", "model file to write")
+	modelCmd.AddCommand(modelTrainCmd, modelInfoCmd)
+}
+
+func runModelTrain(cmd *cobra.Command, args []string) error {
+	cutoff, err := parseDate(modelFlags.before, false)
+	if err != nil {
+		return err
+	}
+	if cutoff.IsZero() {
+		cutoff = time.Now()
+	}
+
+	cfg, err := config.Load(resolveConfigPath())
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	repo, _, err := openRepository(args[0], cfg)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = repo.Close() }()
+
+	pairer, ok := repo.(git.CommitPairProvider)
+	if !ok {
+		return fmt.Errorf("repository does not support commit pair creation")
+	}
+
+	// Only the commits before the cutoff are read and diffed; training
+	// needs no baseline of the rest of the history.
+	fmt.Fprintln(os.Stderr, "Reading history...")
+	commits, err := repo.GetCommits(&git.CommitOptions{Branch: modelFlags.branch, Until: cutoff})
+	if err != nil {
+		return fmt.Errorf("failed to retrieve commits: %w", err)
+	}
+	pairs, err := pairer.GetCommitPairs(commits)
+	if err != nil {
+		return fmt.Errorf("failed to create commit pairs: %w", err)
+	}
+
+	docs := make([]langmodel.Document, 0, len(pairs))
+	for _, pair := range pairs {
+		if !pair.Current.Timestamp.Before(cutoff) {
+			continue
+		}
+		docs = append(docs, langmodel.Document{
+			Code:    langmodel.AddedLines(pair.DiffContent),
+			Message: pair.Current.Message,
+		})
+	}
+	if len(docs) == 0 {
+		return fmt.Errorf("no commits before %s to train on", cutoff.Format("2006-01-02"))
+	}
+
+	fmt.Fprintf(os.Stderr, "Training on %d commits...\n", len(docs))
+	model := langmodel.Train(docs, cutoff)
+	if err := model.Save(modelFlags.output); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Model written to %s\n", modelFlags.output)
+	printModel(model)
+	return nil
+}
+
+func runModelInfo(cmd *cobra.Command, args []string) error {
+	model, err := langmodel.Load(args[0])
+	if err != nil {
+		return err
+	}
+	printModel(model)
+	return nil
+}
+
+func printModel(model *langmodel.Model) {
+	fmt.Printf("Format version: %d\n", model.Version)
+	fmt.Printf("Trained:        %s\n", model.CreatedAt.Format(time.RFC3339))
+	fmt.Printf("Cutoff:         %s\n", model.Cutoff.Format(time.RFC3339))
+	fmt.Printf("Commits:        %d\n", model.Commits)
+	for _, part := range []struct {
+		name  string
+		ngram *langmodel.NGram
+	}{{"Code", model.Code}, {"Text", model.Text}} {
+		b := part.ngram.Baseline
+		fmt.Printf("%s model: %d tokens, %d n-grams; baseline of %d held-out commits",
+			part.name, part.ngram.Tokens, len(part.ngram.Counts), b.Commits)
+		if b.Commits > 0 {
+			fmt.Printf(", perplexity %.1f, burstiness %.2f",
+				math.Exp(b.MeanLogPerplexity), b.MeanBurstiness)
+		}
+		fmt.Println()
+	}
+}
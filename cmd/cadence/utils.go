@@ -2,8 +2,13 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/config"
+	"github.com/TryCadence/Cadence/internal/detector"
 )
 
 func detectFormatFromExtension(filePath string) (string, error) {
@@ -20,3 +25,56 @@ func detectFormatFromExtension(filePath string) (string, error) {
 		return "", fmt.Errorf("unsupported file extension: %s", ext)
 	}
 }
+
+// parseDate parses a YYYY-MM-DD or RFC3339 date. A bare date used as an
+// upper bound covers the whole day. Empty input yields the zero time.
+func parseDate(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unsupported date format: %s (use YYYY-MM-DD or RFC3339)", value)
+	}
+
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return t, nil
+}
+
+// resolveConfigPath returns the --config path, falling back to cadence.yml
+// in the current directory when it exists.
+func resolveConfigPath() string {
+	if configFile != "" {
+		return configFile
+	}
+	if _, err := os.Stat("cadence.yml"); err == nil {
+		return "cadence.yml"
+	}
+	return ""
+}
+
+// addIgnoreFile appends the suppressions of the repository's
+// .cadence-ignore, if it has one, to those of the config.
+func addIgnoreFile(repoPath string, cfg *config.Config) error {
+	suppressions, err := config.LoadIgnoreFile(filepath.Join(repoPath, config.IgnoreFile))
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", config.IgnoreFile, err)
+	}
+	cfg.Thresholds.Suppressions = append(cfg.Thresholds.Suppressions, suppressions...)
+	return nil
+}
+
+// warnExpired prints a warning for each suppression past its expiry date.
+func warnExpired(expired []detector.Suppression) {
+	for _, s := range expired {
+		fmt.Fprintf(os.Stderr, "Warning: suppression of %s in %s expired on %s; its findings are reported again\n",
+			s.Describe(), s.Source, s.Expires)
+	}
+}
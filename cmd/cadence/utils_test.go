@@ -2,6 +2,7 @@ package main
 
 import (
 	"testing"
+	"time"
 )
 
 func TestDetectFormatFromExtension(t *testing.T) {
@@ -219,3 +220,37 @@ func TestParseGitHubURL(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		endOfDay    bool
+		want        time.Time
+		shouldError bool
+	}{
+		{name: "empty", value: "", want: time.Time{}},
+		{name: "date", value: "2024-03-01", want: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "date end of day", value: "2024-03-01", endOfDay: true, want: time.Date(2024, 3, 1, 23, 59, 59, 999999999, time.UTC)},
+		{name: "rfc3339", value: "2024-03-01T12:30:00Z", endOfDay: true, want: time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)},
+		{name: "invalid", value: "March 1st", shouldError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDate(tt.value, tt.endOfDay)
+			if tt.shouldError {
+				if err == nil {
+					t.Errorf("parseDate(%q) expected error but got none", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseDate(%q) unexpected error = %v", tt.value, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseDate(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
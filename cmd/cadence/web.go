@@ -10,6 +10,7 @@ import (
 
 	"github.com/TryCadence/Cadence/internal/ai"
 	"github.com/TryCadence/Cadence/internal/config"
+	"github.com/TryCadence/Cadence/internal/detector"
 	"github.com/TryCadence/Cadence/internal/detector/patterns"
 	"github.com/TryCadence/Cadence/internal/web"
 )
@@ -19,6 +20,7 @@ var (
 	verbose    bool
 	outputFile string
 	jsonFormat bool
+	webFailOn  failOn
 )
 
 var webCmd = &cobra.Command{
@@ -50,7 +52,13 @@ Examples:
   cadence web https://example.com --json --output report.json
 
   # Verbose output with content quality metrics
-  cadence web https://example.com --verbose`,
+  cadence web https://example.com --verbose
+
+  # Fail a CI job when the page looks AI-generated
+  cadence web https://example.com --fail-on-score 0.7
+
+Each --fail-on gate exits with its own code after the report is written:
+2 for score, 3 for count and 4 for severity. Other errors exit with 1.`,
 	Args: cobra.ExactArgs(1),
 	RunE: runWebAnalyze,
 }
@@ -60,11 +68,18 @@ func init() {
 	webCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "show detailed analysis information")
 	webCmd.Flags().StringVarP(&outputFile, "output", "o", "", "write report to file (saved in reports/ directory)")
 	webCmd.Flags().BoolVarP(&jsonFormat, "json", "j", false, "output in JSON format")
+	webFailOn.addFlags(webCmd,
+		"exit with 2 if the confidence score (0-1) is at or above this (0 to disable)",
+		"exit with 3 if at least this many patterns are detected (0 to disable)",
+		"exit with 4 if a pattern is at least this severe: low, medium or high")
 	rootCmd.AddCommand(webCmd)
 }
 
 func runWebAnalyze(cmd *cobra.Command, args []string) error {
 	url := args[0]
+	if err := webFailOn.validate(); err != nil {
+		return err
+	}
 
 	fmt.Fprintf(os.Stderr, "Fetching website content from %s...\n", url)
 
@@ -104,14 +119,7 @@ func runWebAnalyze(cmd *cobra.Command, args []string) error {
 
 	// Perform AI analysis if enabled
 	var aiAnalysis string
-	cfgPath := configFile
-	if cfgPath == "" {
-		if _, err := os.Stat("cadence.yml"); err == nil {
-			cfgPath = "cadence.yml"
-		}
-	}
-
-	cfg, err := config.Load(cfgPath)
+	cfg, err := config.Load(resolveConfigPath())
 	if err == nil && cfg.AI.Enabled {
 		fmt.Fprintf(os.Stderr, "Performing AI analysis...\n")
 		aiAnalysis, err = performWebAIAnalysis(pageContent, result, &cfg.AI)
@@ -159,7 +167,26 @@ func runWebAnalyze(cmd *cobra.Command, args []string) error {
 		fmt.Print(output)
 	}
 
-	return nil
+	return webFailOn.check(cmd, webMeasures(result))
+}
+
+// webMeasures summarizes a text slop result for the --fail-on gates: the
+// confidence score, scaled to 0-1 like commit scores so that both commands
+// take the same --fail-on-score, the number of patterns detected and the
+// highest severity band of a pattern.
+func webMeasures(result *patterns.TextSlopResult) gateMeasures {
+	m := gateMeasures{
+		score: float64(result.GetConfidenceScore()) / 100,
+		count: len(result.Patterns),
+	}
+	for _, p := range result.Patterns {
+		if s := detector.SeverityFor(p.Severity); !detector.SeverityAtLeast(m.severity, s) {
+			m.severity = s
+		}
+	}
+	m.scoreText = fmt.Sprintf("confidence score %.2f", m.score)
+	m.countText = fmt.Sprintf("%d patterns detected", m.count)
+	return m
 }
 
 func performWebAIAnalysis(content *web.PageContent, result *patterns.TextSlopResult, aiCfg *config.AIConfig) (string, error) {
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -74,6 +75,9 @@ func TestWebCommandFlag(t *testing.T) {
 		{"output", "o", "write report to file"},
 		{"verbose", "v", "show detailed analysis"},
 		{"json", "j", "JSON format"},
+		{"fail-on-score", "", "confidence score"},
+		{"fail-on-count", "", "patterns"},
+		{"fail-on-severity", "", "severe"},
 	}
 
 	for _, flag := range flags {
@@ -188,3 +192,48 @@ func TestContentQualityScore(t *testing.T) {
 		})
 	}
 }
+
+func TestWebFailOnScore(t *testing.T) {
+	slop := strings.Repeat("<p>In today's fast-paced digital landscape, it's important to note that we delve into a comprehensive, robust and seamless solution, leveraging cutting-edge synergy to unlock the full potential of your journey. Furthermore, moreover, additionally, in conclusion.</p>\n", 6)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html><head><title>Solutions</title></head><body><h1>Our Solutions</h1>\n" + slop + "</body></html>"))
+	}))
+	defer server.Close()
+
+	saved := webFailOn
+	defer func() { webFailOn = saved }()
+
+	tests := []struct {
+		name     string
+		score    float64
+		wantCode int
+		wantErr  bool
+	}{
+		// The page scores 0.8 or more: the same unit as analyze.
+		{name: "reached", score: 0.7, wantCode: exitFailOnScore},
+		{name: "not reached", score: 0.9},
+		{name: "percent rejected", score: 70, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webFailOn = failOn{score: tt.score}
+			err := runWebAnalyze(webCmd, []string{server.URL})
+
+			var gate *gateError
+			switch {
+			case tt.wantCode != 0:
+				if !errors.As(err, &gate) || gate.code != tt.wantCode {
+					t.Errorf("runWebAnalyze() error = %v, want exit code %d", err, tt.wantCode)
+				}
+			case tt.wantErr:
+				if err == nil || errors.As(err, &gate) {
+					t.Errorf("runWebAnalyze() error = %v, want a flag error", err)
+				}
+			case err != nil:
+				t.Errorf("runWebAnalyze() error = %v, want nil", err)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package analysis
 
 import (
 	"math"
+	"sort"
 
 	"github.com/TryCadence/Cadence/internal/git"
 )
@@ -106,6 +107,7 @@ func CalculateBaseline(pairs []*git.CommitPair) *RepositoryBaseline {
 	}
 
 	if len(commitSizes) > 0 {
+		sort.Slice(commitSizes, func(i, j int) bool { return commitSizes[i] < commitSizes[j] })
 		baseline.MedianCommitSize = calculatePercentileValue(commitSizes, 50)
 		baseline.Q1CommitSize = calculatePercentileValue(commitSizes, 25)
 		baseline.Q3CommitSize = calculatePercentileValue(commitSizes, 75)
@@ -237,6 +239,9 @@ func calculatePercentileValue(sortedValues []int64, percentile float64) int64 {
 	return int64(float64(sortedValues[lower])*(1-weight) + float64(sortedValues[upper])*weight)
 }
 
+// DetectTimingClusters reports runs of at least three consecutive pairs
+// committed less than five minutes apart. Each run is reported once,
+// against its last commit.
 func DetectTimingClusters(pairs []*git.CommitPair) []*StatisticalAnomaly {
 	anomalies := make([]*StatisticalAnomaly, 0)
 
@@ -245,23 +250,23 @@ func DetectTimingClusters(pairs []*git.CommitPair) []*StatisticalAnomaly {
 	}
 
 	rapidCommitWindow := 0
-	for i := 0; i < len(pairs); i++ {
-		if pairs[i].TimeDelta.Minutes() < 5.0 {
+	for i := 0; i <= len(pairs); i++ {
+		if i < len(pairs) && pairs[i].TimeDelta.Minutes() < 5.0 {
 			rapidCommitWindow++
-		} else {
-			if rapidCommitWindow >= 3 {
-				anomalies = append(anomalies, &StatisticalAnomaly{
-					Type:          AnomalyTimingCluster,
-					CommitHash:    pairs[i].Current.Hash,
-					Score:         float64(rapidCommitWindow),
-					BaselineValue: 1.0,
-					ObservedValue: float64(rapidCommitWindow),
-					IsSignificant: rapidCommitWindow >= 5,
-					Description:   "Cluster of rapid-fire commits detected (potential batch processing)",
-				})
-			}
-			rapidCommitWindow = 0
+			continue
+		}
+		if rapidCommitWindow >= 3 {
+			anomalies = append(anomalies, &StatisticalAnomaly{
+				Type:          AnomalyTimingCluster,
+				CommitHash:    pairs[i-1].Current.Hash,
+				Score:         float64(rapidCommitWindow),
+				BaselineValue: 1.0,
+				ObservedValue: float64(rapidCommitWindow),
+				IsSignificant: rapidCommitWindow >= 5,
+				Description:   "Cluster of rapid-fire commits detected (potential batch processing)",
+			})
 		}
+		rapidCommitWindow = 0
 	}
 
 	return anomalies
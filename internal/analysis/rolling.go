@@ -0,0 +1,86 @@
+package analysis
+
+import (
+	"math"
+	"sort"
+
+	"github.com/TryCadence/Cadence/internal/git"
+)
+
+// RollingBaseline holds the statistics of a RepositoryBaseline for a set of
+// pairs that changes one pair at a time, so that a baseline over a sliding
+// window costs an update per pair entering or leaving it rather than a pass
+// over the whole window. Pairs without changes are ignored.
+type RollingBaseline struct {
+	n            int
+	sumAdditions float64
+	sumAddSq     float64
+	sumDeletions float64
+	sumDelSq     float64
+	sumFiles     float64
+	sumRatios    float64
+	sizes        []int64
+}
+
+// Len returns the number of pairs in the set.
+func (b *RollingBaseline) Len() int {
+	return b.n
+}
+
+// Add puts pair into the set.
+func (b *RollingBaseline) Add(pair *git.CommitPair) {
+	b.update(pair, 1)
+}
+
+// Remove takes pair, added before, out of the set.
+func (b *RollingBaseline) Remove(pair *git.CommitPair) {
+	b.update(pair, -1)
+}
+
+func (b *RollingBaseline) update(pair *git.CommitPair, sign float64) {
+	stats := pair.Stats
+	if stats == nil || (stats.Additions == 0 && stats.Deletions == 0) {
+		return
+	}
+
+	additions, deletions := float64(stats.Additions), float64(stats.Deletions)
+	b.n += int(sign)
+	b.sumAdditions += sign * additions
+	b.sumAddSq += sign * additions * additions
+	b.sumDeletions += sign * deletions
+	b.sumDelSq += sign * deletions * deletions
+	b.sumFiles += sign * float64(stats.FilesChanged)
+	b.sumRatios += sign * additions / (additions + deletions)
+
+	size := stats.Additions + stats.Deletions
+	i := sort.Search(len(b.sizes), func(i int) bool { return b.sizes[i] >= size })
+	if sign > 0 {
+		b.sizes = append(b.sizes, 0)
+		copy(b.sizes[i+1:], b.sizes[i:])
+		b.sizes[i] = size
+	} else if i < len(b.sizes) && b.sizes[i] == size {
+		b.sizes = append(b.sizes[:i], b.sizes[i+1:]...)
+	}
+}
+
+// Baseline returns the baseline of the pairs in the set, the same as
+// CalculateBaseline of them.
+func (b *RollingBaseline) Baseline() *RepositoryBaseline {
+	if b.n == 0 {
+		return &RepositoryBaseline{}
+	}
+
+	n := float64(b.n)
+	baseline := &RepositoryBaseline{
+		AvgAdditions:     b.sumAdditions / n,
+		AvgDeletions:     b.sumDeletions / n,
+		AvgFilesChanged:  b.sumFiles / n,
+		AvgAdditionRatio: b.sumRatios / n,
+		MedianCommitSize: calculatePercentileValue(b.sizes, 50),
+		Q1CommitSize:     calculatePercentileValue(b.sizes, 25),
+		Q3CommitSize:     calculatePercentileValue(b.sizes, 75),
+	}
+	baseline.StdDevAdditions = math.Sqrt(math.Max(0, b.sumAddSq/n-baseline.AvgAdditions*baseline.AvgAdditions))
+	baseline.StdDevDeletions = math.Sqrt(math.Max(0, b.sumDelSq/n-baseline.AvgDeletions*baseline.AvgDeletions))
+	return baseline
+}
@@ -0,0 +1,58 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/git"
+)
+
+func TestRollingBaseline(t *testing.T) {
+	pairs := make([]*git.CommitPair, 0, 40)
+	for i := 0; i < 40; i++ {
+		pairs = append(pairs, &git.CommitPair{
+			Current: &git.Commit{Hash: fmt.Sprintf("c%d", i)},
+			Stats:   &git.DiffStats{Additions: int64(10 + i*i%97), Deletions: int64(i % 7), FilesChanged: 1 + i%4},
+		})
+	}
+
+	var rolling RollingBaseline
+	const window = 15
+	for i, pair := range pairs {
+		rolling.Add(pair)
+		if i >= window {
+			rolling.Remove(pairs[i-window])
+		}
+
+		lo := i + 1 - window
+		if lo < 0 {
+			lo = 0
+		}
+		want := CalculateBaseline(pairs[lo : i+1])
+		got := rolling.Baseline()
+		if rolling.Len() != i+1-lo {
+			t.Fatalf("step %d: Len() = %d, want %d", i, rolling.Len(), i+1-lo)
+		}
+		for _, c := range []struct {
+			name      string
+			got, want float64
+		}{
+			{"AvgAdditions", got.AvgAdditions, want.AvgAdditions},
+			{"StdDevAdditions", got.StdDevAdditions, want.StdDevAdditions},
+			{"AvgDeletions", got.AvgDeletions, want.AvgDeletions},
+			{"StdDevDeletions", got.StdDevDeletions, want.StdDevDeletions},
+			{"AvgFilesChanged", got.AvgFilesChanged, want.AvgFilesChanged},
+			{"AvgAdditionRatio", got.AvgAdditionRatio, want.AvgAdditionRatio},
+		} {
+			if math.Abs(c.got-c.want) > 1e-6 {
+				t.Errorf("step %d: %s = %v, want %v", i, c.name, c.got, c.want)
+			}
+		}
+		if got.MedianCommitSize != want.MedianCommitSize || got.Q1CommitSize != want.Q1CommitSize || got.Q3CommitSize != want.Q3CommitSize {
+			t.Errorf("step %d: quartiles = %d, %d, %d, want %d, %d, %d", i,
+				got.Q1CommitSize, got.MedianCommitSize, got.Q3CommitSize,
+				want.Q1CommitSize, want.MedianCommitSize, want.Q3CommitSize)
+		}
+	}
+}
@@ -14,6 +14,9 @@ type AnalysisResult struct {
 	Commits      []*git.Commit
 	CommitPairs  []*git.CommitPair
 	TotalCommits int
+	// BaselinePairs holds pairs from the history outside a filtered
+	// selection. It is empty when the whole history is analyzed.
+	BaselinePairs []*git.CommitPair
 }
 
 func New(repo git.Repository) *Analyzer {
@@ -45,11 +48,53 @@ func (a *Analyzer) AnalyzeRepository(opts *git.CommitOptions) (*AnalysisResult,
 		return nil, fmt.Errorf("failed to create commit pairs: %w", err)
 	}
 
-	return &AnalysisResult{
+	result := &AnalysisResult{
 		Commits:      commits,
 		CommitPairs:  pairs,
 		TotalCommits: len(commits),
-	}, nil
+	}
+
+	if opts.IsFiltered() {
+		result.BaselinePairs, err = a.createBaselinePairs(opts, commits)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create baseline pairs: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// createBaselinePairs pairs the commits of the unfiltered history that are
+// not part of the analyzed selection, so that the selection is compared
+// against the rest of the repository rather than against itself.
+func (a *Analyzer) createBaselinePairs(opts *git.CommitOptions, selected []*git.Commit) ([]*git.CommitPair, error) {
+	baselineOpts, err := opts.BaselineOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := a.repo.GetCommits(baselineOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve baseline commits: %w", err)
+	}
+
+	inSelection := make(map[string]bool, len(selected))
+	for _, c := range selected {
+		inSelection[c.Hash] = true
+	}
+
+	outside := make([]*git.Commit, 0, len(history))
+	for _, c := range history {
+		if !inSelection[c.Hash] {
+			outside = append(outside, c)
+		}
+	}
+
+	if len(outside) == 0 {
+		return []*git.CommitPair{}, nil
+	}
+
+	return a.createCommitPairs(outside)
 }
 
 func (a *Analyzer) createCommitPairs(commits []*git.Commit) ([]*git.CommitPair, error) {
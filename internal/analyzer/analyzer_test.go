@@ -11,16 +11,21 @@ import (
 // mockRepository implements the git.Repository interface for testing
 type mockRepository struct {
 	commits      []*git.Commit
+	history      []*git.Commit
 	commitPairs  []*git.CommitPair
 	commitErr    error
 	pairErr      error
 	supportPairs bool
+	pairInputs   [][]*git.Commit
 }
 
 func (m *mockRepository) GetCommits(opts *git.CommitOptions) ([]*git.Commit, error) {
 	if m.commitErr != nil {
 		return nil, m.commitErr
 	}
+	if m.history != nil && opts != nil && !opts.IsFiltered() {
+		return m.history, nil
+	}
 	return m.commits, nil
 }
 
@@ -31,6 +36,7 @@ func (m *mockRepository) GetCommitPairs(commits []*git.Commit) ([]*git.CommitPai
 	if m.pairErr != nil {
 		return nil, m.pairErr
 	}
+	m.pairInputs = append(m.pairInputs, commits)
 	return m.commitPairs, nil
 }
 
@@ -256,6 +262,62 @@ func TestAnalyzer_AnalyzeRepository(t *testing.T) {
 	})
 }
 
+func TestAnalyzer_AnalyzeRepository_Baseline(t *testing.T) {
+	history := []*git.Commit{{Hash: "c3"}, {Hash: "c2"}, {Hash: "c1"}, {Hash: "c0"}}
+
+	t.Run("filtered analysis pairs history outside selection", func(t *testing.T) {
+		repo := &mockRepository{
+			commits:      history[:2],
+			history:      history,
+			commitPairs:  []*git.CommitPair{{}},
+			supportPairs: true,
+		}
+
+		result, err := New(repo).AnalyzeRepository(&git.CommitOptions{Range: "c1..c3"})
+		if err != nil {
+			t.Fatalf("AnalyzeRepository() unexpected error = %v", err)
+		}
+		if len(result.BaselinePairs) != 1 {
+			t.Errorf("len(BaselinePairs) = %d, want 1", len(result.BaselinePairs))
+		}
+		if len(repo.pairInputs) != 2 {
+			t.Fatalf("GetCommitPairs called %d times, want 2", len(repo.pairInputs))
+		}
+
+		baselineInput := repo.pairInputs[1]
+		if len(baselineInput) != 2 || baselineInput[0].Hash != "c1" || baselineInput[1].Hash != "c0" {
+			t.Errorf("baseline commits = %v, want [c1 c0]", baselineInput)
+		}
+	})
+
+	t.Run("unfiltered analysis has no separate baseline", func(t *testing.T) {
+		repo := &mockRepository{
+			commits:      history,
+			commitPairs:  []*git.CommitPair{{}},
+			supportPairs: true,
+		}
+
+		result, err := New(repo).AnalyzeRepository(&git.CommitOptions{Branch: "main"})
+		if err != nil {
+			t.Fatalf("AnalyzeRepository() unexpected error = %v", err)
+		}
+		if len(result.BaselinePairs) != 0 {
+			t.Errorf("len(BaselinePairs) = %d, want 0", len(result.BaselinePairs))
+		}
+		if len(repo.pairInputs) != 1 {
+			t.Errorf("GetCommitPairs called %d times, want 1", len(repo.pairInputs))
+		}
+	})
+
+	t.Run("invalid range fails", func(t *testing.T) {
+		repo := &mockRepository{commits: history, supportPairs: true, commitPairs: []*git.CommitPair{}}
+
+		if _, err := New(repo).AnalyzeRepository(&git.CommitOptions{Range: "c1"}); err == nil {
+			t.Error("AnalyzeRepository() expected error for invalid range")
+		}
+	})
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) && containsHelper(s, substr))
 }
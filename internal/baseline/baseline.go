@@ -0,0 +1,156 @@
+// Package baseline records the findings of an analysis so that later runs
+// report only the findings added since, the way linters adopt a baseline
+// for existing code.
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/detector"
+)
+
+// FormatVersion is bumped whenever the file layout or the way findings are
+// fingerprinted changes, so a baseline of an older release is rejected
+// rather than matching nothing.
+const FormatVersion = 1
+
+// Fingerprint identifies a finding across runs by the commit it belongs to,
+// the strategy that raised it and the file its evidence starts in. Author
+// findings have no commit and are told apart by Author and the Start of
+// the change they report, so a later change of the same author is new;
+// window findings by every commit of the window, so a window that grows
+// is new.
+type Fingerprint struct {
+	Commit   string   `json:"commit,omitempty"`
+	Author   string   `json:"author,omitempty"`
+	Start    string   `json:"start,omitempty"`
+	Commits  []string `json:"commits,omitempty"`
+	Strategy string   `json:"strategy"`
+	File     string   `json:"file,omitempty"`
+}
+
+// Of returns the fingerprint of f, a finding of the given commit, or of
+// no commit when commit is empty.
+func Of(commit string, f detector.Finding) Fingerprint {
+	fp := Fingerprint{Commit: commit, Strategy: f.Strategy, File: f.File}
+	switch f.Scope {
+	case detector.ScopeAuthor:
+		fp.Author = f.Author
+		if !f.Start.IsZero() {
+			fp.Start = f.Start.UTC().Format(time.RFC3339)
+		}
+	case detector.ScopeWindow:
+		fp.Commits = f.Commits
+	}
+	return fp
+}
+
+// key identifies the fingerprint in a set.
+func (fp Fingerprint) key() string {
+	return strings.Join([]string{fp.Commit, fp.Author, fp.Start, strings.Join(fp.Commits, ","), fp.Strategy, fp.File}, "\x00")
+}
+
+// Baseline is the set of findings accepted when it was written.
+type Baseline struct {
+	Version   int           `json:"version"`
+	CreatedAt time.Time     `json:"created_at"`
+	Findings  []Fingerprint `json:"findings"`
+
+	index map[string]bool
+}
+
+// New records every finding of result. Suppressed findings are left out,
+// since their suppressions already clear them.
+func New(result *detector.Result) *Baseline {
+	b := &Baseline{Version: FormatVersion, CreatedAt: time.Now().UTC()}
+	for _, s := range result.Suspicious {
+		for _, f := range s.Findings {
+			b.add(Of(s.Pair.Current.Hash, f))
+		}
+	}
+	for _, f := range result.Authors {
+		b.add(Of("", f))
+	}
+	for _, f := range result.Windows {
+		b.add(Of("", f))
+	}
+
+	sort.Slice(b.Findings, func(i, j int) bool {
+		a, c := b.Findings[i], b.Findings[j]
+		if a.Commit != c.Commit {
+			return a.Commit < c.Commit
+		}
+		if a.Author != c.Author {
+			return a.Author < c.Author
+		}
+		if a.Start != c.Start {
+			return a.Start < c.Start
+		}
+		if ac, cc := strings.Join(a.Commits, ","), strings.Join(c.Commits, ","); ac != cc {
+			return ac < cc
+		}
+		if a.Strategy != c.Strategy {
+			return a.Strategy < c.Strategy
+		}
+		return a.File < c.File
+	})
+	return b
+}
+
+func (b *Baseline) add(fp Fingerprint) {
+	if b.index == nil {
+		b.index = make(map[string]bool)
+	}
+	if key := fp.key(); !b.index[key] {
+		b.index[key] = true
+		b.Findings = append(b.Findings, fp)
+	}
+}
+
+// Contains reports whether the baseline holds f, implementing
+// detector.KnownFindings.
+func (b *Baseline) Contains(commit string, f detector.Finding) bool {
+	return b.index[Of(commit, f).key()]
+}
+
+// Save writes the baseline as indented JSON, one finding per entry in a
+// stable order, so that it diffs well when checked in.
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+	return nil
+}
+
+// Load reads a baseline written by Save. Baselines of another
+// FormatVersion are rejected.
+func Load(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline %s: %w", path, err)
+	}
+	if b.Version != FormatVersion {
+		return nil, fmt.Errorf("baseline %s has format version %d, expected %d; write it again with --write-baseline", path, b.Version, FormatVersion)
+	}
+
+	findings := b.Findings
+	b.Findings = nil
+	for _, fp := range findings {
+		b.add(fp)
+	}
+	return &b, nil
+}
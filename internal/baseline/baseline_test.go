@@ -0,0 +1,162 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/detector"
+	"github.com/TryCadence/Cadence/internal/git"
+)
+
+func TestOf(t *testing.T) {
+	tests := []struct {
+		name    string
+		commit  string
+		finding detector.Finding
+		want    Fingerprint
+	}{
+		{
+			name:    "commit finding",
+			commit:  "abc1234def",
+			finding: detector.Finding{Strategy: "emoji_pattern_analysis", File: "src/main.go", Message: "Emoji"},
+			want:    Fingerprint{Commit: "abc1234def", Strategy: "emoji_pattern_analysis", File: "src/main.go"},
+		},
+		{
+			name:    "author finding",
+			finding: detector.Finding{Strategy: "cadence_shift", Scope: detector.ScopeAuthor, Author: "dev@example.com", Start: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)},
+			want:    Fingerprint{Author: "dev@example.com", Start: "2024-03-01T09:00:00Z", Strategy: "cadence_shift"},
+		},
+		{
+			name:    "window finding",
+			finding: detector.Finding{Strategy: "uniform_commit_burst", Scope: detector.ScopeWindow, Commits: []string{"aaa", "bbb"}},
+			want:    Fingerprint{Commits: []string{"aaa", "bbb"}, Strategy: "uniform_commit_burst"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Of(tt.commit, tt.finding); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Of() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContains_ChangedFindingsAreNew(t *testing.T) {
+	shift := detector.Finding{Strategy: "cadence_shift", Scope: detector.ScopeAuthor, Author: "dev@example.com", Start: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)}
+	burst := detector.Finding{Strategy: "uniform_commit_burst", Scope: detector.ScopeWindow, Commits: []string{"aaa", "bbb"}}
+	b := New(&detector.Result{Authors: []detector.Finding{shift}, Windows: []detector.Finding{burst}})
+
+	laterShift := shift
+	laterShift.Start = shift.Start.AddDate(0, 2, 0)
+	longerBurst := burst
+	longerBurst.Commits = []string{"aaa", "bbb", "ccc"}
+
+	tests := []struct {
+		name    string
+		finding detector.Finding
+		want    bool
+	}{
+		{"same shift", shift, true},
+		{"new shift of the same author", laterShift, false},
+		{"same window", burst, true},
+		{"window grown by a commit", longerBurst, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.Contains("", tt.finding); got != tt.want {
+				t.Errorf("Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func sizedPair(hash string, additions int64) *git.CommitPair {
+	return &git.CommitPair{
+		Previous:  &git.Commit{Hash: "parent"},
+		Current:   &git.Commit{Hash: hash, Message: "Rework parser"},
+		TimeDelta: 2 * time.Hour,
+		Stats:     &git.DiffStats{Additions: additions, FilesChanged: 1},
+	}
+}
+
+func TestBaseline_NewFindingsOnly(t *testing.T) {
+	detect := func(known detector.KnownFindings, pairs ...*git.CommitPair) *detector.Result {
+		t.Helper()
+		d, err := detector.New(&detector.Thresholds{SuspiciousAdditions: 100, Fingerprints: []detector.Fingerprint{}})
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		if known != nil {
+			d.SetKnown(known)
+		}
+		return d.Detect(pairs, nil)
+	}
+
+	old := sizedPair("aaa1234", 200)
+	first := detect(nil, old)
+	if len(first.Suspicious) != 1 {
+		t.Fatalf("len(Suspicious) = %d, want 1", len(first.Suspicious))
+	}
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := New(first).Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	b, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(b.Findings) != len(first.Suspicious[0].Findings) {
+		t.Errorf("len(Findings) = %d, want %d", len(b.Findings), len(first.Suspicious[0].Findings))
+	}
+
+	added := sizedPair("bbb1234", 300)
+	second := detect(b, old, added)
+	if len(second.Suspicious) != 1 || second.Suspicious[0].Pair != added {
+		t.Fatalf("Suspicious = %+v, want only the new commit", second.Suspicious)
+	}
+	if second.Known != len(b.Findings) {
+		t.Errorf("Known = %d, want %d", second.Known, len(b.Findings))
+	}
+}
+
+func TestSaveIsStable(t *testing.T) {
+	result := &detector.Result{
+		Suspicious: []*detector.SuspiciousCommit{
+			{Pair: sizedPair("bbb", 1), Findings: []detector.Finding{{Strategy: "size_analysis"}}},
+			{Pair: sizedPair("aaa", 1), Findings: []detector.Finding{
+				{Strategy: "size_analysis"},
+				{Strategy: "emoji_pattern_analysis", File: "b.go"},
+				{Strategy: "emoji_pattern_analysis", File: "a.go"},
+			}},
+		},
+		Windows: []detector.Finding{{Strategy: "uniform_commit_burst", Scope: detector.ScopeWindow, Commits: []string{"aaa"}}},
+	}
+
+	want := []Fingerprint{
+		{Commits: []string{"aaa"}, Strategy: "uniform_commit_burst"},
+		{Commit: "aaa", Strategy: "emoji_pattern_analysis", File: "a.go"},
+		{Commit: "aaa", Strategy: "emoji_pattern_analysis", File: "b.go"},
+		{Commit: "aaa", Strategy: "size_analysis"},
+		{Commit: "bbb", Strategy: "size_analysis"},
+	}
+	if got := New(result).Findings; !reflect.DeepEqual(got, want) {
+		t.Errorf("Findings = %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadRejectsOtherVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := os.WriteFile(path, []byte(`{"version": 99, "findings": []}`), 0o600); err != nil {
+		t.Fatalf("failed to write baseline: %v", err)
+	}
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "format version 99") {
+		t.Errorf("Load() error = %v, want a format version error", err)
+	}
+}
@@ -0,0 +1,262 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/git"
+)
+
+// formatVersion is bumped whenever the entry layout or the way diffs are
+// computed changes, so stale entries read as misses instead of wrong data.
+const formatVersion = 5
+
+// DefaultMaxDiffBytes bounds the diff content kept per entry. Pairs with
+// larger diffs keep only their stats, and their content is recomputed on
+// every run.
+const DefaultMaxDiffBytes = 1 << 20
+
+const (
+	diffDir = "diff"
+	aiDir   = "ai"
+)
+
+type Options struct {
+	// Dir is the cache root. Empty uses DefaultDir.
+	Dir string
+	// ExcludeFiles are the patterns the diffs were computed with. They are
+	// fingerprinted so that changing them never serves stale stats.
+	ExcludeFiles []string
+	// AnalyzeGo tells whether the diffs carry Go signals, and is
+	// fingerprinted for the same reason.
+	AnalyzeGo bool
+	// MaxDiffBytes bounds cached diff content. Larger diffs are stored
+	// without it. Zero uses DefaultMaxDiffBytes.
+	MaxDiffBytes int
+}
+
+// Store is an on-disk cache of per-commit diff results and AI verdicts,
+// partitioned by the fingerprint of the exclusion setup. It implements
+// git.DiffCache and is safe for concurrent use.
+type Store struct {
+	dir          string
+	fingerprint  string
+	maxDiffBytes int
+}
+
+type diffEntry struct {
+	Version     int            `json:"version"`
+	FromHash    string         `json:"from_hash"`
+	ToHash      string         `json:"to_hash"`
+	Stats       *git.DiffStats `json:"stats"`
+	DiffContent string         `json:"diff_content"`
+	// ContentOmitted marks an entry whose content was above MaxDiffBytes
+	// and was left out.
+	ContentOmitted bool      `json:"content_omitted,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type aiEntry struct {
+	Version   int       `json:"version"`
+	Hash      string    `json:"hash"`
+	Model     string    `json:"model"`
+	Verdict   string    `json:"verdict"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DefaultDir returns the per-user cache location.
+func DefaultDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate user cache directory: %w", err)
+	}
+	return filepath.Join(base, "cadence"), nil
+}
+
+// Fingerprint identifies an exclusion setup and whether Go signals are
+// computed. Pattern order is significant because later patterns may negate
+// earlier ones.
+func Fingerprint(excludeFiles []string, analyzeGo bool) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "v%d\n", formatVersion)
+	if analyzeGo {
+		h.Write([]byte("go\n"))
+	}
+	for _, pattern := range excludeFiles {
+		h.Write([]byte(pattern))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+func Open(opts Options) (*Store, error) {
+	dir := opts.Dir
+	if dir == "" {
+		var err error
+		dir, err = DefaultDir()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.MaxDiffBytes < 0 {
+		return nil, fmt.Errorf("max diff bytes cannot be negative")
+	}
+
+	maxDiffBytes := opts.MaxDiffBytes
+	if maxDiffBytes == 0 {
+		maxDiffBytes = DefaultMaxDiffBytes
+	}
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	return &Store{
+		dir:          dir,
+		fingerprint:  Fingerprint(opts.ExcludeFiles, opts.AnalyzeGo),
+		maxDiffBytes: maxDiffBytes,
+	}, nil
+}
+
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+func (s *Store) Fingerprint() string {
+	return s.fingerprint
+}
+
+// GetDiff returns the cached diff of a pair. complete is false when the
+// content was too large to keep, leaving content empty.
+func (s *Store) GetDiff(fromHash, toHash string) (stats *git.DiffStats, content string, complete, ok bool) {
+	var entry diffEntry
+	if !readJSON(s.diffPath(fromHash, toHash), &entry) {
+		return nil, "", false, false
+	}
+	if entry.Version != formatVersion || entry.Stats == nil ||
+		entry.FromHash != fromHash || entry.ToHash != toHash {
+		return nil, "", false, false
+	}
+	return entry.Stats, entry.DiffContent, !entry.ContentOmitted, true
+}
+
+// PutDiff stores the diff of a pair. Content above MaxDiffBytes is left
+// out and the entry keeps only the stats.
+func (s *Store) PutDiff(fromHash, toHash string, stats *git.DiffStats, content string) error {
+	if stats == nil {
+		return nil
+	}
+
+	entry := &diffEntry{
+		Version:     formatVersion,
+		FromHash:    fromHash,
+		ToHash:      toHash,
+		Stats:       stats,
+		DiffContent: content,
+		CreatedAt:   time.Now(),
+	}
+	if len(content) > s.maxDiffBytes {
+		entry.DiffContent = ""
+		entry.ContentOmitted = true
+	}
+	return writeJSON(s.diffPath(fromHash, toHash), entry)
+}
+
+// GetAIVerdict returns the cached AI analysis of a commit by a model.
+func (s *Store) GetAIVerdict(hash, model string) (string, bool) {
+	var entry aiEntry
+	if !readJSON(s.aiPath(hash, model), &entry) {
+		return "", false
+	}
+	if entry.Version != formatVersion || entry.Hash != hash || entry.Model != model {
+		return "", false
+	}
+	return entry.Verdict, true
+}
+
+func (s *Store) PutAIVerdict(hash, model, verdict string) error {
+	return writeJSON(s.aiPath(hash, model), &aiEntry{
+		Version:   formatVersion,
+		Hash:      hash,
+		Model:     model,
+		Verdict:   verdict,
+		CreatedAt: time.Now(),
+	})
+}
+
+func (s *Store) diffPath(fromHash, toHash string) string {
+	return filepath.Join(s.dir, s.fingerprint, diffDir, shard(toHash), safeName(toHash)+"-"+safeName(fromHash)+".json")
+}
+
+func (s *Store) aiPath(hash, model string) string {
+	return filepath.Join(s.dir, s.fingerprint, aiDir, safeName(model), shard(hash), safeName(hash)+".json")
+}
+
+func shard(hash string) string {
+	if len(hash) < 2 {
+		return "_"
+	}
+	return safeName(hash[:2])
+}
+
+// safeName keeps a key usable as a single path element.
+func safeName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
+
+func readJSON(path string, v interface{}) bool {
+	data, err := os.ReadFile(path) // #nosec G304 -- path is built from the cache root
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, v) == nil
+}
+
+// writeJSON writes atomically so concurrent readers never see a partial
+// entry.
+func writeJSON(path string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create cache entry: %w", err)
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to store cache entry: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/git"
+)
+
+func TestFingerprint(t *testing.T) {
+	a := Fingerprint([]string{"*.log", "!keep.log"}, false)
+	b := Fingerprint([]string{"!keep.log", "*.log"}, false)
+	c := Fingerprint([]string{"*.log", "!keep.log"}, false)
+
+	if a != c {
+		t.Error("Fingerprint() should be stable for identical patterns")
+	}
+	if a == b {
+		t.Error("Fingerprint() should depend on pattern order")
+	}
+	if Fingerprint(nil, false) == a {
+		t.Error("Fingerprint() should differ between empty and non-empty patterns")
+	}
+	if Fingerprint([]string{"*.log", "!keep.log"}, true) == a {
+		t.Error("Fingerprint() should depend on whether Go signals are computed")
+	}
+}
+
+func TestStore_Diff(t *testing.T) {
+	dir := t.TempDir()
+	stats := &git.DiffStats{
+		Additions: 10, Deletions: 2, FilesChanged: 1, TotalAdditions: 12, FilesChangedTotal: 2,
+		Files: []git.FileStats{{Path: "main.go", Additions: 10, Deletions: 2}},
+	}
+
+	store, err := Open(Options{Dir: dir, ExcludeFiles: []string{"*.lock"}, MaxDiffBytes: 64})
+	if err != nil {
+		t.Fatalf("Open() unexpected error = %v", err)
+	}
+
+	t.Run("miss before put", func(t *testing.T) {
+		if _, _, _, ok := store.GetDiff("aaa", "bbb"); ok {
+			t.Error("GetDiff() hit on empty cache")
+		}
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		if err := store.PutDiff("aaa", "bbb", stats, "+hello"); err != nil {
+			t.Fatalf("PutDiff() unexpected error = %v", err)
+		}
+		got, content, complete, ok := store.GetDiff("aaa", "bbb")
+		if !ok {
+			t.Fatal("GetDiff() missed after PutDiff()")
+		}
+		if !complete {
+			t.Error("GetDiff() complete = false for a diff below MaxDiffBytes")
+		}
+		if !reflect.DeepEqual(got, stats) {
+			t.Errorf("GetDiff() stats = %+v, want %+v", *got, *stats)
+		}
+		if content != "+hello" {
+			t.Errorf("GetDiff() content = %q, want %q", content, "+hello")
+		}
+	})
+
+	t.Run("parent is part of the key", func(t *testing.T) {
+		if _, _, _, ok := store.GetDiff("ccc", "bbb"); ok {
+			t.Error("GetDiff() hit for a different parent")
+		}
+	})
+
+	t.Run("oversized diffs keep only their stats", func(t *testing.T) {
+		big := string(make([]byte, 65))
+		if err := store.PutDiff("aaa", "ddd", stats, big); err != nil {
+			t.Fatalf("PutDiff() unexpected error = %v", err)
+		}
+		got, content, complete, ok := store.GetDiff("aaa", "ddd")
+		if !ok {
+			t.Fatal("GetDiff() missed for a diff above MaxDiffBytes")
+		}
+		if !reflect.DeepEqual(got, stats) {
+			t.Errorf("GetDiff() stats = %+v, want %+v", *got, *stats)
+		}
+		if complete || content != "" {
+			t.Errorf("GetDiff() content = %q, complete = %v, want empty and incomplete", content, complete)
+		}
+	})
+
+	t.Run("other exclusion setups do not share entries", func(t *testing.T) {
+		other, err := Open(Options{Dir: dir, ExcludeFiles: []string{"*.min.js"}})
+		if err != nil {
+			t.Fatalf("Open() unexpected error = %v", err)
+		}
+		if _, _, _, ok := other.GetDiff("aaa", "bbb"); ok {
+			t.Error("GetDiff() hit across fingerprints")
+		}
+	})
+
+	t.Run("corrupt entries are misses", func(t *testing.T) {
+		path := store.diffPath("eee", "fff")
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatalf("MkdirAll() error = %v", err)
+		}
+		if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+		if _, _, _, ok := store.GetDiff("eee", "fff"); ok {
+			t.Error("GetDiff() hit on corrupt entry")
+		}
+	})
+}
+
+func TestStore_AIVerdict(t *testing.T) {
+	store, err := Open(Options{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatalf("Open() unexpected error = %v", err)
+	}
+
+	if err := store.PutAIVerdict("abc123", "gpt-4o-mini", "likely AI"); err != nil {
+		t.Fatalf("PutAIVerdict() unexpected error = %v", err)
+	}
+
+	got, ok := store.GetAIVerdict("abc123", "gpt-4o-mini")
+	if !ok || got != "likely AI" {
+		t.Errorf("GetAIVerdict() = %q, %v, want %q, true", got, ok, "likely AI")
+	}
+	if _, ok := store.GetAIVerdict("abc123", "other-model"); ok {
+		t.Error("GetAIVerdict() hit for a different model")
+	}
+}
+
+func TestInspectAndPrune(t *testing.T) {
+	dir := t.TempDir()
+	stats := &git.DiffStats{Additions: 1}
+
+	current, _ := Open(Options{Dir: dir, ExcludeFiles: []string{"*.lock"}})
+	stale, _ := Open(Options{Dir: dir})
+
+	_ = current.PutDiff("a", "b", stats, "")
+	_ = current.PutDiff("b", "c", stats, "")
+	_ = current.PutAIVerdict("c", "m", "verdict")
+	_ = stale.PutDiff("a", "b", stats, "")
+
+	old := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(stale.diffPath("a", "b"), old, old); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+
+	info, err := Inspect(dir)
+	if err != nil {
+		t.Fatalf("Inspect() unexpected error = %v", err)
+	}
+	if len(info.Partitions) != 2 {
+		t.Fatalf("len(Partitions) = %d, want 2", len(info.Partitions))
+	}
+	if info.TotalEntries() != 4 {
+		t.Errorf("TotalEntries() = %d, want 4", info.TotalEntries())
+	}
+	for _, p := range info.Partitions {
+		if p.Fingerprint == current.Fingerprint() && (p.DiffEntries != 2 || p.AIEntries != 1) {
+			t.Errorf("current partition = %+v, want 2 diff and 1 AI entries", p)
+		}
+	}
+
+	t.Run("prune requires a selector", func(t *testing.T) {
+		if _, err := Prune(dir, PruneOptions{}); err == nil {
+			t.Error("Prune() expected error without options")
+		}
+	})
+
+	t.Run("prune by age", func(t *testing.T) {
+		result, err := Prune(dir, PruneOptions{OlderThan: 24 * time.Hour})
+		if err != nil {
+			t.Fatalf("Prune() unexpected error = %v", err)
+		}
+		if result.Removed != 1 {
+			t.Errorf("Removed = %d, want 1", result.Removed)
+		}
+		if _, err := os.Stat(filepath.Join(dir, stale.Fingerprint())); !os.IsNotExist(err) {
+			t.Error("empty partition directory should be removed")
+		}
+	})
+
+	t.Run("prune all", func(t *testing.T) {
+		if _, err := Prune(dir, PruneOptions{All: true}); err != nil {
+			t.Fatalf("Prune() unexpected error = %v", err)
+		}
+		info, _ := Inspect(dir)
+		if info.TotalEntries() != 0 {
+			t.Errorf("TotalEntries() = %d after prune all, want 0", info.TotalEntries())
+		}
+	})
+
+	t.Run("missing directory is empty", func(t *testing.T) {
+		info, err := Inspect(filepath.Join(dir, "missing"))
+		if err != nil {
+			t.Fatalf("Inspect() unexpected error = %v", err)
+		}
+		if info.TotalEntries() != 0 {
+			t.Errorf("TotalEntries() = %d, want 0", info.TotalEntries())
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package cache
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Partition summarizes the entries stored for one exclusion fingerprint.
+type Partition struct {
+	Fingerprint string
+	DiffEntries int
+	AIEntries   int
+	Bytes       int64
+	Oldest      time.Time
+	Newest      time.Time
+}
+
+type Info struct {
+	Dir        string
+	Partitions []*Partition
+}
+
+func (i *Info) TotalEntries() int {
+	total := 0
+	for _, p := range i.Partitions {
+		total += p.DiffEntries + p.AIEntries
+	}
+	return total
+}
+
+func (i *Info) TotalBytes() int64 {
+	var total int64
+	for _, p := range i.Partitions {
+		total += p.Bytes
+	}
+	return total
+}
+
+type PruneOptions struct {
+	// OlderThan removes entries last written before now minus OlderThan.
+	OlderThan time.Duration
+	// Fingerprint restricts pruning to a single partition.
+	Fingerprint string
+	// All removes every entry regardless of age.
+	All bool
+}
+
+type PruneResult struct {
+	Removed int
+	Bytes   int64
+}
+
+// Inspect walks the cache directory and summarizes each partition.
+// A missing directory is reported as an empty cache.
+func Inspect(dir string) (*Info, error) {
+	info := &Info{Dir: dir, Partitions: make([]*Partition, 0)}
+
+	partitions := make(map[string]*Partition)
+	err := walkEntries(dir, func(fingerprint, kind, path string, fi fs.FileInfo) error {
+		p, ok := partitions[fingerprint]
+		if !ok {
+			p = &Partition{Fingerprint: fingerprint}
+			partitions[fingerprint] = p
+			info.Partitions = append(info.Partitions, p)
+		}
+
+		switch kind {
+		case diffDir:
+			p.DiffEntries++
+		case aiDir:
+			p.AIEntries++
+		}
+		p.Bytes += fi.Size()
+
+		if p.Oldest.IsZero() || fi.ModTime().Before(p.Oldest) {
+			p.Oldest = fi.ModTime()
+		}
+		if fi.ModTime().After(p.Newest) {
+			p.Newest = fi.ModTime()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(info.Partitions, func(i, j int) bool {
+		return info.Partitions[i].Fingerprint < info.Partitions[j].Fingerprint
+	})
+
+	return info, nil
+}
+
+// Prune removes entries selected by opts and any directories left empty.
+func Prune(dir string, opts PruneOptions) (*PruneResult, error) {
+	if !opts.All && opts.OlderThan <= 0 && opts.Fingerprint == "" {
+		return nil, fmt.Errorf("nothing to prune: set an age, a fingerprint or all")
+	}
+
+	cutoff := time.Now().Add(-opts.OlderThan)
+	result := &PruneResult{}
+
+	err := walkEntries(dir, func(fingerprint, kind, path string, fi fs.FileInfo) error {
+		if opts.Fingerprint != "" && fingerprint != opts.Fingerprint {
+			return nil
+		}
+		if !opts.All && opts.OlderThan > 0 && !fi.ModTime().Before(cutoff) {
+			return nil
+		}
+
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to remove cache entry: %w", err)
+		}
+		result.Removed++
+		result.Bytes += fi.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	removeEmptyDirs(dir)
+
+	return result, nil
+}
+
+// walkEntries calls fn for every entry file under dir, laid out as
+// <fingerprint>/<kind>/.../<entry>.json.
+func walkEntries(dir string, fn func(fingerprint, kind, path string, fi fs.FileInfo) error) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("failed to read cache directory: %w", err)
+		}
+		if d.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return nil
+		}
+		parts := strings.Split(filepath.ToSlash(rel), "/")
+		if len(parts) < 3 {
+			return nil
+		}
+
+		fi, err := d.Info()
+		if err != nil {
+			return fmt.Errorf("failed to stat cache entry: %w", err)
+		}
+
+		return fn(parts[0], parts[1], path, fi)
+	})
+}
+
+func removeEmptyDirs(root string) {
+	dirs := make([]string, 0)
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && d.IsDir() && path != root {
+			dirs = append(dirs, path)
+		}
+		return nil
+	})
+
+	// Deepest first so parents become empty before they are visited.
+	sort.Slice(dirs, func(i, j int) bool { return len(dirs[i]) > len(dirs[j]) })
+	for _, d := range dirs {
+		_ = os.Remove(d) // fails harmlessly on non-empty directories
+	}
+}
@@ -4,6 +4,11 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/detector"
+	"github.com/TryCadence/Cadence/internal/git"
+	"github.com/TryCadence/Cadence/internal/langmodel"
 )
 
 func TestLoad(t *testing.T) {
@@ -73,6 +78,152 @@ exclude_files:
 		}
 	})
 
+	t.Run("load author aliases", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		yamlContent := `authors:
+  aliases:
+    - name: Jane Doe
+      email: jane@example.com
+      aliases:
+        - jane@users.noreply.github.com
+        - Jane D
+`
+		if err := os.WriteFile(configFile, []byte(yamlContent), 0o600); err != nil {
+			t.Fatalf("Failed to write test config file: %v", err)
+		}
+
+		config, err := Load(configFile)
+		if err != nil {
+			t.Fatalf("Load() unexpected error = %v", err)
+		}
+		if len(config.Authors.Aliases) != 1 {
+			t.Fatalf("len(Authors.Aliases) = %d, want 1", len(config.Authors.Aliases))
+		}
+		alias := config.Authors.Aliases[0]
+		if alias.Name != "Jane Doe" || alias.Email != "jane@example.com" || len(alias.Aliases) != 2 {
+			t.Errorf("Authors.Aliases[0] = %+v", alias)
+		}
+	})
+
+	t.Run("author alias without email", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		yamlContent := `authors:
+  aliases:
+    - name: Jane Doe
+      aliases: [jane@old.example.com]
+`
+		if err := os.WriteFile(configFile, []byte(yamlContent), 0o600); err != nil {
+			t.Fatalf("Failed to write test config file: %v", err)
+		}
+
+		if _, err := Load(configFile); err == nil {
+			t.Error("Load() expected error for alias without email")
+		}
+	})
+
+	t.Run("fingerprints", func(t *testing.T) {
+		config, err := Load("")
+		if err != nil {
+			t.Fatalf("Load(\"\") unexpected error = %v", err)
+		}
+		if len(config.Thresholds.Fingerprints) != len(detector.DefaultFingerprints()) {
+			t.Errorf("len(Fingerprints) = %d, want the %d defaults", len(config.Thresholds.Fingerprints), len(detector.DefaultFingerprints()))
+		}
+
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		yamlContent := `fingerprints:
+  defaults: false
+  custom:
+    - name: Codegen
+      field: trailer
+      key: X-Generator
+      pattern: "^codegen"
+`
+		if err := os.WriteFile(configFile, []byte(yamlContent), 0o600); err != nil {
+			t.Fatalf("Failed to write test config file: %v", err)
+		}
+
+		config, err = Load(configFile)
+		if err != nil {
+			t.Fatalf("Load() unexpected error = %v", err)
+		}
+		want := []detector.Fingerprint{{Name: "Codegen", Field: "trailer", Key: "X-Generator", Pattern: "^codegen"}}
+		if len(config.Thresholds.Fingerprints) != 1 || config.Thresholds.Fingerprints[0] != want[0] {
+			t.Errorf("Fingerprints = %+v, want %+v", config.Thresholds.Fingerprints, want)
+		}
+	})
+
+	t.Run("scoring weights", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		yamlContent := `scoring:
+  weights:
+    velocity_analysis: 0.9
+    StatisticalAnomaly: 0.1
+`
+		if err := os.WriteFile(configFile, []byte(yamlContent), 0o600); err != nil {
+			t.Fatalf("Failed to write test config file: %v", err)
+		}
+
+		config, err := Load(configFile)
+		if err != nil {
+			t.Fatalf("Load() unexpected error = %v", err)
+		}
+		if w := config.Thresholds.Weights["velocity_analysis"]; w != 0.9 {
+			t.Errorf("Weights[velocity_analysis] = %v, want 0.9", w)
+		}
+		if w, ok := config.Thresholds.Weights["StatisticalAnomaly"]; !ok || w != 0.1 {
+			t.Errorf("Weights[StatisticalAnomaly] = %v (present %v), want 0.1", w, ok)
+		}
+		if err := config.Thresholds.Validate(); err != nil {
+			t.Errorf("Validate() unexpected error = %v", err)
+		}
+	})
+
+	t.Run("strategies", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		yamlContent := `strategies:
+  commit_message_analysis:
+    enabled: false
+  TimingAnomaly:
+    long_gap_hours: 48
+    short_gap_seconds: 30.5
+`
+		if err := os.WriteFile(configFile, []byte(yamlContent), 0o600); err != nil {
+			t.Fatalf("Failed to write test config file: %v", err)
+		}
+
+		config, err := Load(configFile)
+		if err != nil {
+			t.Fatalf("Load() unexpected error = %v", err)
+		}
+		msg := config.Thresholds.Strategies["commit_message_analysis"]
+		if msg.Enabled == nil || *msg.Enabled {
+			t.Errorf("commit_message_analysis.Enabled = %v, want false", msg.Enabled)
+		}
+		timing := config.Thresholds.Strategies["TimingAnomaly"]
+		if timing.Params["long_gap_hours"] != 48 || timing.Params["short_gap_seconds"] != 30.5 {
+			t.Errorf("TimingAnomaly.Params = %v", timing.Params)
+		}
+		if err := config.Thresholds.Validate(); err != nil {
+			t.Errorf("Validate() unexpected error = %v", err)
+		}
+	})
+
+	t.Run("strategies with non-numeric parameter", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		yamlContent := `strategies:
+  burst_pattern_analysis:
+    window_seconds: soon
+`
+		if err := os.WriteFile(configFile, []byte(yamlContent), 0o600); err != nil {
+			t.Fatalf("Failed to write test config file: %v", err)
+		}
+
+		if _, err := Load(configFile); err == nil {
+			t.Error("Load() expected error for non-numeric parameter")
+		}
+	})
+
 	t.Run("load from json file", func(t *testing.T) {
 		tmpDir := t.TempDir()
 		configFile := filepath.Join(tmpDir, "config.json")
@@ -116,6 +267,69 @@ exclude_files:
 		}
 	})
 
+	t.Run("load language model relative to config", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		model := langmodel.Train([]langmodel.Document{{Code: []string{"x := 1"}, Message: "Add x"}}, time.Now())
+		if err := model.Save(filepath.Join(tmpDir, "repo.model")); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		configFile := filepath.Join(tmpDir, "config.yaml")
+		if err := os.WriteFile(configFile, []byte("language_model: repo.model\n"), 0o600); err != nil {
+			t.Fatalf("Failed to write test config file: %v", err)
+		}
+
+		config, err := Load(configFile)
+		if err != nil {
+			t.Fatalf("Load() unexpected error = %v", err)
+		}
+		if config.Thresholds.LanguageModel == nil || config.Thresholds.LanguageModel.Commits != 1 {
+			t.Errorf("LanguageModel = %+v, want the saved model", config.Thresholds.LanguageModel)
+		}
+	})
+
+	t.Run("error on missing language model", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(configFile, []byte("language_model: missing.model\n"), 0o600); err != nil {
+			t.Fatalf("Failed to write test config file: %v", err)
+		}
+		if _, err := Load(configFile); err == nil {
+			t.Error("Load() expected error for missing language model")
+		}
+	})
+
+	t.Run("load suppressions", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		yamlContent := `suppressions:
+  - commit: abc1234
+    reason: Generated protobuf code, reviewed in #12
+  - path: docs/**
+    strategy: emoji_pattern_analysis
+    reason: Docs use emoji headings
+    expires: 2026-12-31
+`
+		if err := os.WriteFile(configFile, []byte(yamlContent), 0o600); err != nil {
+			t.Fatalf("Failed to write test config file: %v", err)
+		}
+
+		config, err := Load(configFile)
+		if err != nil {
+			t.Fatalf("Load() unexpected error = %v", err)
+		}
+		suppressions := config.Thresholds.Suppressions
+		if len(suppressions) != 2 {
+			t.Fatalf("len(Suppressions) = %d, want 2", len(suppressions))
+		}
+		if suppressions[0].Commit != "abc1234" || suppressions[0].Source != configFile {
+			t.Errorf("Suppressions[0] = %+v", suppressions[0])
+		}
+		if suppressions[1].Expires != "2026-12-31" || suppressions[1].Strategy != "emoji_pattern_analysis" {
+			t.Errorf("Suppressions[1] = %+v", suppressions[1])
+		}
+		if err := config.Thresholds.Validate(); err != nil {
+			t.Errorf("Validate() error = %v", err)
+		}
+	})
+
 	t.Run("error on non-existent file", func(t *testing.T) {
 		config, err := Load("/non/existent/config.yaml")
 		if err == nil {
@@ -248,6 +462,49 @@ func TestGenerateSampleConfig(t *testing.T) {
 	})
 }
 
+func TestSampleConfigExcludeFiles(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), ".cadence.yaml")
+	if err := GenerateSampleConfig(configFile); err != nil {
+		t.Fatalf("GenerateSampleConfig() unexpected error = %v", err)
+	}
+
+	config, err := Load(configFile)
+	if err != nil {
+		t.Fatalf("Load() unexpected error = %v", err)
+	}
+
+	matcher, err := git.NewExcludeMatcher(config.ExcludeFiles)
+	if err != nil {
+		t.Fatalf("NewExcludeMatcher() unexpected error = %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "package-lock.json", want: true},
+		{path: "frontend/package-lock.json", want: true},
+		{path: "yarn.lock", want: true},
+		{path: "dist/app.min.js", want: true},
+		{path: "static/css/site.min.css", want: true},
+		{path: "node_modules/react/index.js", want: true},
+		{path: "node_modules/@scope/pkg/lib/deep/file.js", want: true},
+		{path: "packages/web/node_modules/lodash/lodash.js", want: true},
+		{path: "src/app.js", want: false},
+		{path: "src/app.min.ts", want: false},
+		{path: "docs/node_modules.md", want: false},
+		{path: "package.json", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := matcher.Match(tt.path); got != tt.want {
+				t.Errorf("sample exclude_files match %q = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) && containsHelper(s, substr))
 }
@@ -260,3 +517,46 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func TestLoadIgnoreFile(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		suppressions, err := LoadIgnoreFile(filepath.Join(t.TempDir(), IgnoreFile))
+		if err != nil || suppressions != nil {
+			t.Errorf("LoadIgnoreFile() = %v, %v, want nil, nil", suppressions, err)
+		}
+	})
+
+	t.Run("suppressions", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), IgnoreFile)
+		content := `suppressions:
+  - author: bot@example.com
+    reason: Release bot
+    expires: 2027-01-15
+`
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write ignore file: %v", err)
+		}
+
+		suppressions, err := LoadIgnoreFile(path)
+		if err != nil {
+			t.Fatalf("LoadIgnoreFile() error = %v", err)
+		}
+		if len(suppressions) != 1 {
+			t.Fatalf("len(suppressions) = %d, want 1", len(suppressions))
+		}
+		s := suppressions[0]
+		if s.Author != "bot@example.com" || s.Reason != "Release bot" || s.Expires != "2027-01-15" || s.Source != path {
+			t.Errorf("suppression = %+v", s)
+		}
+	})
+
+	t.Run("invalid yaml", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), IgnoreFile)
+		if err := os.WriteFile(path, []byte("suppressions: [\n"), 0o600); err != nil {
+			t.Fatalf("Failed to write ignore file: %v", err)
+		}
+		if _, err := LoadIgnoreFile(path); err == nil {
+			t.Error("LoadIgnoreFile() expected error for invalid yaml")
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package detector
+
+import (
+	"sort"
+
+	"github.com/TryCadence/Cadence/internal/detector/patterns"
+	"github.com/TryCadence/Cadence/internal/git"
+)
+
+// FileContribution is the part of a suspicious commit's score one of its
+// files accounts for, on the same scale as the score.
+type FileContribution struct {
+	Path  string
+	Score float64
+}
+
+// spreadCategories are the findings about a commit's changed lines as a
+// whole. Without a location of their own they are shared among the files
+// by how many lines each changed. Message, timing, statistical and
+// provenance findings concern no file and are left out.
+var spreadCategories = map[string]bool{
+	patterns.CategoryVelocity:  true,
+	patterns.CategorySize:      true,
+	patterns.CategoryStructure: true,
+	patterns.CategoryCode:      true,
+}
+
+// rankFiles splits each finding's weighted contribution among the files it
+// points at and combines each file's parts like the commit score, highest
+// first. A finding with Locations is split by the lines located in each
+// file, one with only File goes wholly to it, and the rest follow
+// spreadCategories.
+func rankFiles(stats *git.DiffStats, findings []Finding, weighted []float64) []FileContribution {
+	parts := make(map[string][]float64)
+	for i, finding := range findings {
+		for path, share := range fileShares(stats, finding) {
+			parts[path] = append(parts[path], weighted[i]*share)
+		}
+	}
+
+	files := make([]FileContribution, 0, len(parts))
+	for path, p := range parts {
+		if score := CombineScores(p); score > 0 {
+			files = append(files, FileContribution{Path: path, Score: score})
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].Score != files[j].Score {
+			return files[i].Score > files[j].Score
+		}
+		return files[i].Path < files[j].Path
+	})
+	return files
+}
+
+// fileShares returns the share of a finding each file accounts for.
+func fileShares(stats *git.DiffStats, finding Finding) map[string]float64 {
+	lines := make(map[string]float64)
+	switch {
+	case len(finding.Locations) > 0:
+		for _, loc := range finding.Locations {
+			lines[loc.File] += float64(loc.EndLine - loc.StartLine + 1)
+		}
+	case finding.File != "":
+		lines[finding.File] = 1
+	case spreadCategories[finding.Category] && stats != nil:
+		for _, file := range stats.Files {
+			lines[file.Path] += float64(file.Additions + file.Deletions)
+		}
+	}
+
+	total := 0.0
+	for _, n := range lines {
+		total += n
+	}
+	if total == 0 {
+		return nil
+	}
+	for path, n := range lines {
+		lines[path] = n / total
+	}
+	return lines
+}
@@ -0,0 +1,74 @@
+package detector
+
+import (
+	"math"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/detector/patterns"
+	"github.com/TryCadence/Cadence/internal/git"
+)
+
+func TestRankFiles(t *testing.T) {
+	stats := &git.DiffStats{Files: []git.FileStats{
+		{Path: "big.go", Additions: 300},
+		{Path: "small.go", Additions: 60, Deletions: 40},
+	}}
+
+	tests := []struct {
+		name     string
+		findings []Finding
+		weighted []float64
+		want     []FileContribution
+	}{
+		{
+			name: "locations split by located lines",
+			findings: []Finding{{Category: patterns.CategoryCode, Locations: []Location{
+				{File: "small.go", StartLine: 1, EndLine: 30},
+				{File: "big.go", StartLine: 5, EndLine: 14},
+			}}},
+			weighted: []float64{0.4},
+			want:     []FileContribution{{Path: "small.go", Score: 0.3}, {Path: "big.go", Score: 0.1}},
+		},
+		{
+			name:     "file without locations",
+			findings: []Finding{{Category: patterns.CategoryCode, File: "small.go", StartLine: 3, EndLine: 3}},
+			weighted: []float64{0.5},
+			want:     []FileContribution{{Path: "small.go", Score: 0.5}},
+		},
+		{
+			name:     "unlocated size finding spread by changed lines",
+			findings: []Finding{{Category: patterns.CategorySize}},
+			weighted: []float64{0.4},
+			want:     []FileContribution{{Path: "big.go", Score: 0.3}, {Path: "small.go", Score: 0.1}},
+		},
+		{
+			name:     "message and timing findings concern no file",
+			findings: []Finding{{Category: patterns.CategoryMessage}, {Category: patterns.CategoryTiming}},
+			weighted: []float64{0.5, 0.5},
+			want:     []FileContribution{},
+		},
+		{
+			name: "parts combine like the commit score",
+			findings: []Finding{
+				{Category: patterns.CategoryCode, File: "small.go"},
+				{Category: patterns.CategoryCode, File: "small.go"},
+			},
+			weighted: []float64{0.5, 0.5},
+			want:     []FileContribution{{Path: "small.go", Score: 0.75}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rankFiles(stats, tt.findings, tt.weighted)
+			if len(got) != len(tt.want) {
+				t.Fatalf("rankFiles() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i].Path != tt.want[i].Path || math.Abs(got[i].Score-tt.want[i].Score) > 1e-9 {
+					t.Errorf("rankFiles()[%d] = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -2,9 +2,9 @@ package detector
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
-	"github.com/TryCadence/Cadence/internal/detector/patterns"
 	"github.com/TryCadence/Cadence/internal/git"
 	"github.com/TryCadence/Cadence/internal/metrics"
 )
@@ -13,14 +13,54 @@ type SuspiciousCommit struct {
 	Pair             *git.CommitPair
 	AdditionVelocity *metrics.VelocityMetrics
 	DeletionVelocity *metrics.VelocityMetrics
-	Reasons          []string
-	Score            float64
-	AIAnalysis       string
+	Findings         []Finding
+	// Reasons holds each finding's Message, in the same order.
+	Reasons  []string
+	Score    float64
+	Severity Severity
+	// Files ranks the commit's files by their part in Score, highest
+	// first. Files no finding points at are left out.
+	Files      []FileContribution
+	AIAnalysis string
+}
+
+// Result is everything the detector found: the suspicious commits, and the
+// author and time window findings of repository strategies, which belong
+// to no single commit. Suppressed holds the findings suppressions cleared,
+// which count toward no score, and ExpiredSuppressions the suppressions
+// that no longer apply. Known counts the findings left out as already
+// known, see SetKnown.
+type Result struct {
+	Suspicious          []*SuspiciousCommit
+	Authors             []Finding
+	Windows             []Finding
+	Suppressed          []SuppressedFinding
+	ExpiredSuppressions []Suppression
+	Known               int
+}
+
+// KnownFindings holds findings accepted before, such as those recorded in
+// a baseline file. commit is the hash of the commit a finding belongs to,
+// or empty for author and window findings.
+type KnownFindings interface {
+	Contains(commit string, f Finding) bool
 }
 
 type Detector struct {
-	thresholds *Thresholds
-	strategies []DetectionStrategy
+	thresholds     *Thresholds
+	strategies     []DetectionStrategy
+	repoStrategies []RepositoryStrategy
+	weights        map[string]float64
+	baselinePairs  []*git.CommitPair
+	suppressions   []Suppression
+	expired        []Suppression
+	known          KnownFindings
+}
+
+// baselineStrategy is implemented by strategies that compare each pair
+// against statistics of the baseline pairs.
+type baselineStrategy interface {
+	SetBaseline(pairs []*git.CommitPair)
 }
 
 func New(thresholds *Thresholds) (*Detector, error) {
@@ -28,85 +68,141 @@ func New(thresholds *Thresholds) (*Detector, error) {
 		return nil, fmt.Errorf("invalid thresholds: %w", err)
 	}
 
-	strategies := make([]DetectionStrategy, 0)
-
-	if thresholds.SuspiciousAdditions > 0 || thresholds.SuspiciousDeletions > 0 {
-		strategies = append(strategies, NewSizeStrategy(thresholds.SuspiciousAdditions, thresholds.SuspiciousDeletions))
+	strategies, repoStrategies, err := buildStrategies(thresholds)
+	if err != nil {
+		return nil, fmt.Errorf("invalid strategies: %w", err)
 	}
 
-	if thresholds.MaxAdditionsPerMin > 0 || thresholds.MaxDeletionsPerMin > 0 {
-		strategies = append(strategies, NewVelocityStrategy(thresholds.MaxAdditionsPerMin, thresholds.MaxDeletionsPerMin))
+	d := &Detector{
+		thresholds:     thresholds,
+		strategies:     strategies,
+		repoStrategies: repoStrategies,
+		weights:        mergeWeights(thresholds.Weights),
 	}
-
-	if thresholds.MinTimeDeltaSeconds > 0 {
-		strategies = append(strategies, NewTimingStrategy(thresholds.MinTimeDeltaSeconds))
-	}
-
-	if thresholds.MaxFilesPerCommit > 0 {
-		strategies = append(strategies, NewDispersionStrategy(thresholds.MaxFilesPerCommit))
+	now := time.Now()
+	for _, s := range thresholds.Suppressions {
+		if s.Expired(now) {
+			d.expired = append(d.expired, s)
+		} else {
+			d.suppressions = append(d.suppressions, s)
+		}
 	}
+	return d, nil
+}
 
-	if thresholds.MaxAdditionRatio > 0 || thresholds.MinDeletionRatio > 0 {
-		strategies = append(strategies, NewRatioStrategy(thresholds.MaxAdditionRatio, thresholds.MinDeletionRatio, thresholds.MinCommitSizeRatio))
+// Strategies returns the names of the enabled strategies, per-pair
+// strategies first, in the order they run.
+func (d *Detector) Strategies() []string {
+	names := make([]string, 0, len(d.strategies)+len(d.repoStrategies))
+	for _, s := range d.strategies {
+		names = append(names, s.Name())
 	}
-
-	if thresholds.EnablePrecisionAnalysis {
-		strategies = append(strategies, NewPrecisionStrategy(0.85))
+	for _, s := range d.repoStrategies {
+		names = append(names, s.Name())
 	}
+	return names
+}
 
-	strategies = append(strategies,
-		NewCommitMessageStrategy(),
-		NewNamingPatternStrategy(),
-		NewStructuralConsistencyStrategy(),
-		NewBurstPatternStrategy(10),
-		NewErrorHandlingPatternStrategy(),
-		NewTemplatePatternStrategy(),
-		NewFileExtensionPatternStrategy(),
-		NewStatisticalAnomalyStrategy(),
-		NewTimingAnomalyStrategy(),
-	)
+// SetBaseline sets the pairs that baseline-driven strategies compare
+// against. Without it, the analyzed pairs serve as their own baseline.
+func (d *Detector) SetBaseline(pairs []*git.CommitPair) {
+	d.baselinePairs = pairs
+}
 
-	return &Detector{
-		thresholds: thresholds,
-		strategies: strategies,
-	}, nil
+// SetKnown sets the findings to leave out of the result. Only findings
+// missing from known are reported and scored; the others are counted in
+// Result.Known.
+func (d *Detector) SetKnown(known KnownFindings) {
+	d.known = known
 }
 
 func (d *Detector) DetectSuspicious(pairs []*git.CommitPair, repoStats *metrics.RepositoryStats) []*SuspiciousCommit {
+	return d.Detect(pairs, repoStats).Suspicious
+}
+
+// Detect runs every strategy over pairs. Commit findings of repository
+// strategies join the per-pair findings of the commit they name. Findings
+// an active suppression matches are moved to Suppressed, and known findings
+// are left out.
+func (d *Detector) Detect(pairs []*git.CommitPair, repoStats *metrics.RepositoryStats) *Result {
+	result := &Result{
+		Suspicious:          []*SuspiciousCommit{},
+		Authors:             []Finding{},
+		Windows:             []Finding{},
+		Suppressed:          []SuppressedFinding{},
+		ExpiredSuppressions: d.expired,
+	}
 	if pairs == nil {
-		return []*SuspiciousCommit{}
+		return result
+	}
+
+	baselinePairs := pairs
+	if len(d.baselinePairs) > 0 {
+		baselinePairs = d.baselinePairs
 	}
 
 	for _, strategy := range d.strategies {
-		if statStrategy, ok := strategy.(*patterns.StatisticalAnomalyStrategy); ok {
-			statStrategy.SetBaseline(pairs)
-			break
+		if b, ok := strategy.(baselineStrategy); ok {
+			b.SetBaseline(baselinePairs)
+		}
+	}
+	for _, strategy := range d.repoStrategies {
+		if b, ok := strategy.(baselineStrategy); ok {
+			b.SetBaseline(baselinePairs)
 		}
 	}
 
-	suspicious := make([]*SuspiciousCommit, 0)
+	repoFindings := d.detectRepository(pairs, repoStats)
+	result.Authors = d.reportable(result, repoFindings[ScopeAuthor])
+	result.Windows = d.reportable(result, repoFindings[ScopeWindow])
+	byCommit := make(map[string][]Finding)
+	for _, finding := range repoFindings[ScopeCommit] {
+		byCommit[finding.Commit] = append(byCommit[finding.Commit], finding)
+	}
 
 	for _, pair := range pairs {
 		if pair.Stats.Additions == 0 && pair.Stats.Deletions == 0 {
 			continue
 		}
 
-		if len(pair.Current.Parents) > 1 {
-			continue
-		}
-
+		findings := make([]Finding, 0)
 		reasons := make([]string, 0)
-		detectionCount := 0
+		weighted := make([]float64, 0)
 
 		for _, strategy := range d.strategies {
-			detected, reason := strategy.Detect(pair, repoStats)
-			if detected {
-				reasons = append(reasons, reason)
-				detectionCount++
+			finding := strategy.Detect(pair, repoStats)
+			if finding == nil {
+				continue
+			}
+			contribution := d.weightFor(strategy.Name()) * finding.Confidence
+			finding.Strategy = strategy.Name()
+			finding.Severity = SeverityFor(contribution)
+			if s := suppress(d.suppressions, pair, *finding); s != nil {
+				result.Suppressed = append(result.Suppressed, SuppressedFinding{Pair: pair, Finding: *finding, Suppression: *s})
+				continue
+			}
+			if d.isKnown(result, pair.Current.Hash, *finding) {
+				continue
+			}
+			findings = append(findings, *finding)
+			reasons = append(reasons, finding.Message)
+			weighted = append(weighted, contribution)
+		}
+
+		for _, finding := range byCommit[pair.Current.Hash] {
+			if s := suppress(d.suppressions, pair, finding); s != nil {
+				result.Suppressed = append(result.Suppressed, SuppressedFinding{Pair: pair, Finding: finding, Suppression: *s})
+				continue
+			}
+			if d.isKnown(result, pair.Current.Hash, finding) {
+				continue
 			}
+			findings = append(findings, finding)
+			reasons = append(reasons, finding.Message)
+			weighted = append(weighted, d.weightFor(finding.Strategy)*finding.Confidence)
 		}
 
-		if len(reasons) > 0 {
+		if len(findings) > 0 {
 			var additionVelocity, deletionVelocity *metrics.VelocityMetrics
 			if pair.TimeDelta > 0 {
 				var err error
@@ -120,19 +216,76 @@ func (d *Detector) DetectSuspicious(pairs []*git.CommitPair, repoStats *metrics.
 				}
 			}
 
-			score := float64(detectionCount) / float64(len(d.strategies))
+			score := CombineScores(weighted)
 
-			suspicious = append(suspicious, &SuspiciousCommit{
+			result.Suspicious = append(result.Suspicious, &SuspiciousCommit{
 				Pair:             pair,
 				AdditionVelocity: additionVelocity,
 				DeletionVelocity: deletionVelocity,
+				Findings:         findings,
 				Reasons:          reasons,
 				Score:            score,
+				Severity:         SeverityFor(score),
+				Files:            rankFiles(pair.Stats, findings, weighted),
 			})
 		}
 	}
 
-	return suspicious
+	return result
+}
+
+// reportable returns the author or window findings that are neither
+// suppressed nor known, moving suppressed ones to result.Suppressed.
+func (d *Detector) reportable(result *Result, findings []Finding) []Finding {
+	kept := make([]Finding, 0, len(findings))
+	for _, f := range findings {
+		if s := suppress(d.suppressions, nil, f); s != nil {
+			result.Suppressed = append(result.Suppressed, SuppressedFinding{Finding: f, Suppression: *s})
+			continue
+		}
+		if d.isKnown(result, "", f) {
+			continue
+		}
+		kept = append(kept, f)
+	}
+	return kept
+}
+
+// isKnown reports whether f is a known finding, counting it if so.
+func (d *Detector) isKnown(result *Result, commit string, f Finding) bool {
+	if d.known == nil || !d.known.Contains(commit, f) {
+		return false
+	}
+	result.Known++
+	return true
+}
+
+// detectRepository runs the repository strategies over the pairs, oldest
+// commit first, and groups their findings by scope. Findings without a
+// scope are commit findings.
+func (d *Detector) detectRepository(pairs []*git.CommitPair, repoStats *metrics.RepositoryStats) map[Scope][]Finding {
+	byScope := make(map[Scope][]Finding)
+	if len(d.repoStrategies) == 0 {
+		return byScope
+	}
+
+	ordered := make([]*git.CommitPair, len(pairs))
+	copy(ordered, pairs)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Current.Timestamp.Before(ordered[j].Current.Timestamp)
+	})
+
+	for _, strategy := range d.repoStrategies {
+		for _, finding := range strategy.DetectRepository(ordered, repoStats) {
+			if finding.Scope == "" {
+				finding.Scope = ScopeCommit
+			}
+			finding.Strategy = strategy.Name()
+			finding.Severity = SeverityFor(d.weightFor(finding.Strategy) * finding.Confidence)
+			byScope[finding.Scope] = append(byScope[finding.Scope], finding)
+		}
+	}
+	return byScope
 }
 
 func FormatTimeDelta(d time.Duration) string {
@@ -1,6 +1,8 @@
 package detector
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -268,6 +270,151 @@ func TestDetector_DetectSuspicious(t *testing.T) {
 	})
 }
 
+func TestDetector_SetBaseline(t *testing.T) {
+	now := time.Now()
+
+	pair := func(hash string, additions int64) *git.CommitPair {
+		return &git.CommitPair{
+			Previous:  &git.Commit{Hash: hash + "-parent"},
+			Current:   &git.Commit{Hash: hash, Message: "fix parser", Timestamp: now},
+			TimeDelta: 2 * time.Hour,
+			Stats:     &git.DiffStats{Additions: additions, Deletions: additions / 2, FilesChanged: 1},
+		}
+	}
+
+	hasStatisticalReason := func(result []*SuspiciousCommit) bool {
+		for _, s := range result {
+			for _, reason := range s.Reasons {
+				if strings.Contains(reason, "tatistical") {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	analyzed := []*git.CommitPair{pair("big", 900)}
+
+	t.Run("analyzed pairs are their own baseline by default", func(t *testing.T) {
+		d, _ := New(&Thresholds{SuspiciousAdditions: 100000})
+		if hasStatisticalReason(d.DetectSuspicious(analyzed, nil)) {
+			t.Error("single pair should not be anomalous against itself")
+		}
+	})
+
+	t.Run("external baseline is used when set", func(t *testing.T) {
+		baseline := make([]*git.CommitPair, 0, 20)
+		for i := 0; i < 20; i++ {
+			baseline = append(baseline, pair(fmt.Sprintf("b%d", i), int64(10+i%5)))
+		}
+
+		d, _ := New(&Thresholds{SuspiciousAdditions: 100000})
+		d.SetBaseline(baseline)
+		if !hasStatisticalReason(d.DetectSuspicious(analyzed, nil)) {
+			t.Error("expected statistical anomaly against external baseline")
+		}
+	})
+}
+
+func TestDetector_Fingerprints(t *testing.T) {
+	pair := &git.CommitPair{
+		Previous: &git.Commit{Hash: "parent"},
+		Current: &git.Commit{
+			Hash:     "abc1234",
+			Message:  "Add parser\n\nCo-authored-by: Claude <noreply@anthropic.com>",
+			Trailers: []git.Trailer{{Key: "Co-authored-by", Value: "Claude <noreply@anthropic.com>"}},
+		},
+		TimeDelta: 2 * time.Hour,
+		Stats:     &git.DiffStats{Additions: 10, Deletions: 2, FilesChanged: 1},
+	}
+
+	t.Run("defaults flag explicit trailers with high confidence", func(t *testing.T) {
+		d, _ := New(&Thresholds{SuspiciousAdditions: 100000})
+		result := d.DetectSuspicious([]*git.CommitPair{pair}, nil)
+		if len(result) != 1 {
+			t.Fatalf("len(result) = %d, want 1", len(result))
+		}
+		if result[0].Score < 0.9 {
+			t.Errorf("Score = %f, want at least 0.9 for an explicit fingerprint", result[0].Score)
+		}
+		if result[0].Severity != SeverityHigh {
+			t.Errorf("Severity = %q, want %q", result[0].Severity, SeverityHigh)
+		}
+		if !strings.Contains(strings.Join(result[0].Reasons, "\n"), "Explicit AI tool fingerprint") {
+			t.Errorf("Reasons = %v, want explicit fingerprint reason", result[0].Reasons)
+		}
+	})
+
+	t.Run("empty list disables the check", func(t *testing.T) {
+		d, _ := New(&Thresholds{SuspiciousAdditions: 100000, Fingerprints: []Fingerprint{}})
+		if result := d.DetectSuspicious([]*git.CommitPair{pair}, nil); len(result) != 0 {
+			t.Errorf("len(result) = %d, want 0 without fingerprints", len(result))
+		}
+	})
+}
+
+func TestDetector_Findings(t *testing.T) {
+	pair := &git.CommitPair{
+		Previous:  &git.Commit{Hash: "parent"},
+		Current:   &git.Commit{Hash: "abc1234", Message: "Rework parser"},
+		TimeDelta: 2 * time.Hour,
+		Stats:     &git.DiffStats{Additions: 200, Deletions: 0, FilesChanged: 1},
+	}
+
+	d, err := New(&Thresholds{SuspiciousAdditions: 100, Fingerprints: []Fingerprint{}})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	result := d.DetectSuspicious([]*git.CommitPair{pair}, nil)
+	if len(result) != 1 {
+		t.Fatalf("len(result) = %d, want 1", len(result))
+	}
+
+	s := result[0]
+	if len(s.Findings) != len(s.Reasons) {
+		t.Fatalf("len(Findings) = %d, len(Reasons) = %d, want equal", len(s.Findings), len(s.Reasons))
+	}
+	for i, f := range s.Findings {
+		if f.Message != s.Reasons[i] {
+			t.Errorf("Findings[%d].Message = %q, want %q", i, f.Message, s.Reasons[i])
+		}
+	}
+
+	f := s.Findings[0]
+	if f.Strategy != "size_analysis" || f.Category != "size" {
+		t.Errorf("Strategy, Category = %q, %q, want size_analysis, size", f.Strategy, f.Category)
+	}
+	if f.Observed != 200 || f.Threshold != 100 || f.Confidence != 0.75 {
+		t.Errorf("Observed, Threshold, Confidence = %v, %v, %v, want 200, 100, 0.75", f.Observed, f.Threshold, f.Confidence)
+	}
+	if f.Severity != SeverityFor(DefaultWeights()["size_analysis"]*0.75) {
+		t.Errorf("Severity = %q", f.Severity)
+	}
+}
+
+func TestDetector_ScoresMergePairs(t *testing.T) {
+	// The repository only pairs a merge with a parent when merge_parent
+	// asks for it, so such a pair is scored like any other.
+	pair := &git.CommitPair{
+		Previous:  &git.Commit{Hash: "main"},
+		Current:   &git.Commit{Hash: "merge12", Message: "Merge branch 'feature'", Parents: []string{"main", "feature"}},
+		TimeDelta: 2 * time.Hour,
+		Stats:     &git.DiffStats{Additions: 200, FilesChanged: 1},
+	}
+
+	d, err := New(&Thresholds{SuspiciousAdditions: 100, Fingerprints: []Fingerprint{}})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	result := d.DetectSuspicious([]*git.CommitPair{pair}, nil)
+	if len(result) != 1 || result[0].Pair != pair {
+		t.Fatalf("DetectSuspicious() = %v, want the merge pair", result)
+	}
+	if result[0].Score == 0 {
+		t.Error("merge pair was not scored")
+	}
+}
+
 func TestFormatTimeDelta(t *testing.T) {
 	tests := []struct {
 		name     string
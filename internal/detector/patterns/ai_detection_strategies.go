@@ -2,6 +2,7 @@ package patterns
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/TryCadence/Cadence/internal/git"
 	"github.com/TryCadence/Cadence/internal/metrics"
@@ -21,14 +22,20 @@ func (s *DispersionStrategy) Name() string {
 	return "file_dispersion_analysis"
 }
 
-func (s *DispersionStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+func (s *DispersionStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding {
 	if s.maxFilesThreshold > 0 && pair.Stats.FilesChanged > s.maxFilesThreshold {
-		return true, fmt.Sprintf(
-			"Commit touches too many files: %d files (threshold: %d files) - possible batch automation",
-			pair.Stats.FilesChanged, s.maxFilesThreshold,
-		)
+		return &Finding{
+			Category:   CategoryStructure,
+			Confidence: overThreshold(float64(pair.Stats.FilesChanged), float64(s.maxFilesThreshold)),
+			Observed:   float64(pair.Stats.FilesChanged),
+			Threshold:  float64(s.maxFilesThreshold),
+			Message: fmt.Sprintf(
+				"Commit touches too many files: %d files (threshold: %d files) - possible batch automation",
+				pair.Stats.FilesChanged, s.maxFilesThreshold,
+			),
+		}
 	}
-	return false, ""
+	return nil
 }
 
 type RatioStrategy struct {
@@ -49,43 +56,61 @@ func (s *RatioStrategy) Name() string {
 	return "ratio_analysis"
 }
 
-func (s *RatioStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+func (s *RatioStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding {
 	total := pair.Stats.Additions + pair.Stats.Deletions
 	if total < s.minCommitSize {
-		return false, ""
+		return nil
 	}
 
 	if total == 0 {
-		return false, ""
+		return nil
 	}
 
 	addRatio := float64(pair.Stats.Additions) / float64(total)
 	delRatio := float64(pair.Stats.Deletions) / float64(total)
 
 	if s.maxAdditionRatio > 0 && addRatio > s.maxAdditionRatio && total > s.minCommitSize {
-		return true, fmt.Sprintf(
-			"Skewed addition ratio: %.1f%% additions (threshold: %.1f%%) - possible generated code",
-			addRatio*100, s.maxAdditionRatio*100,
-		)
+		return &Finding{
+			Category:   CategoryStructure,
+			Confidence: overThreshold(addRatio, s.maxAdditionRatio),
+			Observed:   addRatio,
+			Threshold:  s.maxAdditionRatio,
+			Message: fmt.Sprintf(
+				"Skewed addition ratio: %.1f%% additions (threshold: %.1f%%) - possible generated code",
+				addRatio*100, s.maxAdditionRatio*100,
+			),
+		}
 	}
 
 	if s.minDeletionRatio > 0 && delRatio > s.minDeletionRatio && total > s.minCommitSize {
-		return true, fmt.Sprintf(
-			"Skewed deletion ratio: %.1f%% deletions (threshold: %.1f%%) - possible generated code",
-			delRatio*100, s.minDeletionRatio*100,
-		)
+		return &Finding{
+			Category:   CategoryStructure,
+			Confidence: overThreshold(delRatio, s.minDeletionRatio),
+			Observed:   delRatio,
+			Threshold:  s.minDeletionRatio,
+			Message: fmt.Sprintf(
+				"Skewed deletion ratio: %.1f%% deletions (threshold: %.1f%%) - possible generated code",
+				delRatio*100, s.minDeletionRatio*100,
+			),
+		}
 	}
 
-	return false, ""
+	return nil
 }
 
 type PrecisionStrategy struct {
-	minConsistencyScore float64
+	minLines         float64
+	balanceTolerance float64
+	minLinesPerFile  float64
+	maxLinesPerFile  float64
 }
 
-func NewPrecisionStrategy(consistency float64) *PrecisionStrategy {
+func NewPrecisionStrategy() *PrecisionStrategy {
 	return &PrecisionStrategy{
-		minConsistencyScore: consistency,
+		minLines:         50,
+		balanceTolerance: 0.05,
+		minLinesPerFile:  100,
+		maxLinesPerFile:  150,
 	}
 }
 
@@ -93,29 +118,55 @@ func (s *PrecisionStrategy) Name() string {
 	return "precision_analysis"
 }
 
-func (s *PrecisionStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
-	if pair.Stats.Additions > 50 && pair.Stats.Deletions > 50 {
+func (s *PrecisionStrategy) params() []param {
+	return []param{
+		{name: "min_lines", value: &s.minLines},
+		{name: "balance_tolerance", value: &s.balanceTolerance, max: 1},
+		{name: "min_lines_per_file", value: &s.minLinesPerFile},
+		{name: "max_lines_per_file", value: &s.maxLinesPerFile},
+	}
+}
+
+func (s *PrecisionStrategy) Params() map[string]float64 { return paramValues(s.params()) }
+
+func (s *PrecisionStrategy) SetParam(name string, value float64) error {
+	return setParam(s.Name(), s.params(), name, value)
+}
+
+func (s *PrecisionStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding {
+	if float64(pair.Stats.Additions) > s.minLines && float64(pair.Stats.Deletions) > s.minLines {
 		diff := float64(pair.Stats.Additions - pair.Stats.Deletions)
 		total := float64(pair.Stats.Additions + pair.Stats.Deletions)
 		ratio := diff / total
 
-		if ratio < 0.05 {
-			return true, fmt.Sprintf(
-				"Suspiciously balanced change ratio: %.1f%% difference - possible generated refactoring",
-				ratio*100,
-			)
+		if ratio < s.balanceTolerance {
+			return &Finding{
+				Category:   CategoryStructure,
+				Confidence: underThreshold(math.Abs(ratio), s.balanceTolerance),
+				Observed:   ratio,
+				Threshold:  s.balanceTolerance,
+				Message: fmt.Sprintf(
+					"Suspiciously balanced change ratio: %.1f%% difference - possible generated refactoring",
+					ratio*100,
+				),
+			}
 		}
 	}
 
 	if pair.Stats.FilesChanged > 0 && pair.Stats.Additions+pair.Stats.Deletions > 100 {
 		avgPerFile := float64(pair.Stats.Additions+pair.Stats.Deletions) / float64(pair.Stats.FilesChanged)
-		if avgPerFile > 100 && avgPerFile < 150 {
-			return true, fmt.Sprintf(
-				"Suspiciously consistent file change sizes: ~%.0f LOC per file - possible generated code",
-				avgPerFile,
-			)
+		if avgPerFile > s.minLinesPerFile && avgPerFile < s.maxLinesPerFile {
+			return &Finding{
+				Category:   CategoryStructure,
+				Confidence: 0.5,
+				Observed:   avgPerFile,
+				Message: fmt.Sprintf(
+					"Suspiciously consistent file change sizes: ~%.0f LOC per file - possible generated code",
+					avgPerFile,
+				),
+			}
 		}
 	}
 
-	return false, ""
+	return nil
 }
@@ -2,6 +2,7 @@ package patterns
 
 import (
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/TryCadence/Cadence/internal/git"
@@ -9,20 +10,42 @@ import (
 )
 
 type CommitMessageStrategy struct {
-	enabled bool
+	enabled           bool
+	minAIPhrases      float64
+	minGenericPhrases float64
+	verboseMinWords   float64
 }
 
 func NewCommitMessageStrategy() *CommitMessageStrategy {
-	return &CommitMessageStrategy{enabled: true}
+	return &CommitMessageStrategy{
+		enabled:           true,
+		minAIPhrases:      2,
+		minGenericPhrases: 1,
+		verboseMinWords:   8,
+	}
 }
 
 func (s *CommitMessageStrategy) Name() string {
 	return "commit_message_analysis"
 }
 
-func (s *CommitMessageStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+func (s *CommitMessageStrategy) params() []param {
+	return []param{
+		{name: "min_ai_phrases", value: &s.minAIPhrases},
+		{name: "min_generic_phrases", value: &s.minGenericPhrases},
+		{name: "verbose_min_words", value: &s.verboseMinWords},
+	}
+}
+
+func (s *CommitMessageStrategy) Params() map[string]float64 { return paramValues(s.params()) }
+
+func (s *CommitMessageStrategy) SetParam(name string, value float64) error {
+	return setParam(s.Name(), s.params(), name, value)
+}
+
+func (s *CommitMessageStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding {
 	if !s.enabled {
-		return false, ""
+		return nil
 	}
 
 	msg := strings.ToLower(pair.Current.Message)
@@ -70,40 +93,106 @@ func (s *CommitMessageStrategy) Detect(pair *git.CommitPair, repoStats *metrics.
 		}
 	}
 
-	if aiScore >= 2 || genericScore >= 1 {
-		return true, fmt.Sprintf(
-			"Suspicious commit message patterns - generic/AI-like phrasing (AI patterns: %d, generic: %d)",
-			aiScore, genericScore,
-		)
+	aiHit := s.minAIPhrases > 0 && aiScore >= int(s.minAIPhrases)
+	genericHit := s.minGenericPhrases > 0 && genericScore >= int(s.minGenericPhrases)
+	if aiHit || genericHit {
+		strength := 0.0
+		if aiHit {
+			strength = indicatorStrength(aiScore, int(s.minAIPhrases))
+		}
+		if genericHit {
+			strength = math.Max(strength, indicatorStrength(genericScore, int(s.minGenericPhrases)))
+		}
+		return &Finding{
+			Category:   CategoryMessage,
+			Confidence: strength,
+			Observed:   float64(aiScore + genericScore),
+			Message: fmt.Sprintf(
+				"Suspicious commit message patterns - generic/AI-like phrasing (AI patterns: %d, generic: %d)",
+				aiScore, genericScore,
+			),
+		}
 	}
 
 	words := strings.Fields(msg)
-	if len(words) > 8 && (strings.Contains(msg, "implement") || strings.Contains(msg, "functionality")) {
-		return true, "Overly verbose yet generic commit message - typical of AI generation"
+	if float64(len(words)) > s.verboseMinWords && (strings.Contains(msg, "implement") || strings.Contains(msg, "functionality")) {
+		return &Finding{
+			Category:   CategoryMessage,
+			Confidence: 0.5,
+			Observed:   float64(len(words)),
+			Threshold:  s.verboseMinWords,
+			Message:    "Overly verbose yet generic commit message - typical of AI generation",
+		}
 	}
 
-	return false, ""
+	return nil
+}
+
+// addedCodeLines returns the lines the pair's diff adds, without the +
+// prefix, and where each lands. With skipGo, set when go_source_analysis
+// runs, the lines of parsed Go files are left out: GoSourceStrategy
+// measures them from the syntax tree instead.
+func addedCodeLines(pair *git.CommitPair, skipGo bool) []textLine {
+	skipGo = skipGo && pair.Stats != nil && pair.Stats.Go != nil && pair.Stats.Go.Files > 0
+
+	addedLines := make([]textLine, 0)
+	forEachAddedLine(pair.DiffContent, func(file string, line int, text string) {
+		if skipGo && strings.HasSuffix(file, ".go") {
+			return
+		}
+		addedLines = append(addedLines, textLine{file: file, line: line, text: text})
+	})
+	return addedLines
 }
 
 type NamingPatternStrategy struct {
-	enabled bool
+	enabled         bool
+	skipGo          bool
+	minGenericTerms float64
+	minIndicators   float64
+	maxCamelRatio   float64
+	linesPerHandler float64
 }
 
-func NewNamingPatternStrategy() *NamingPatternStrategy {
-	return &NamingPatternStrategy{enabled: true}
+// NewNamingPatternStrategy returns the strategy; skipGo leaves Go code to
+// go_source_analysis.
+func NewNamingPatternStrategy(skipGo bool) *NamingPatternStrategy {
+	return &NamingPatternStrategy{
+		enabled:         true,
+		skipGo:          skipGo,
+		minGenericTerms: 2,
+		minIndicators:   2,
+		maxCamelRatio:   0.3,
+		linesPerHandler: 20,
+	}
 }
 
 func (s *NamingPatternStrategy) Name() string {
 	return "naming_pattern_analysis"
 }
 
-func (s *NamingPatternStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+func (s *NamingPatternStrategy) params() []param {
+	return []param{
+		{name: "min_generic_terms", value: &s.minGenericTerms},
+		{name: "min_indicators", value: &s.minIndicators},
+		{name: "max_camel_case_ratio", value: &s.maxCamelRatio, max: 1},
+		{name: "lines_per_handler", value: &s.linesPerHandler},
+	}
+}
+
+func (s *NamingPatternStrategy) Params() map[string]float64 { return paramValues(s.params()) }
+
+func (s *NamingPatternStrategy) SetParam(name string, value float64) error {
+	return setParam(s.Name(), s.params(), name, value)
+}
+
+func (s *NamingPatternStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding {
 	if !s.enabled {
-		return false, ""
+		return nil
 	}
 
 	if pair.DiffContent != "" {
-		return s.analyzeCodeContent(pair.DiffContent)
+		return s.analyzeCodeContent(addedCodeLines(pair, s.skipGo))
 	}
 
 	msg := strings.ToLower(pair.Current.Message)
@@ -120,34 +209,32 @@ func (s *NamingPatternStrategy) Detect(pair *git.CommitPair, repoStats *metrics.
 		}
 	}
 
-	if nameCount >= 2 {
-		return true, fmt.Sprintf(
-			"Commit message contains multiple generic naming terms (%d) - may indicate AI-generated variable names",
-			nameCount,
-		)
+	if nameCount >= int(s.minGenericTerms) {
+		return &Finding{
+			Category:   CategoryMessage,
+			Confidence: indicatorStrength(nameCount, int(s.minGenericTerms)),
+			Observed:   float64(nameCount),
+			Threshold:  s.minGenericTerms,
+			Message: fmt.Sprintf(
+				"Commit message contains multiple generic naming terms (%d) - may indicate AI-generated variable names",
+				nameCount,
+			),
+		}
 	}
 
-	return false, ""
+	return nil
 }
 
-func (s *NamingPatternStrategy) analyzeCodeContent(diffContent string) (isSuspicious bool, reason string) {
-	lines := strings.Split(diffContent, "\n")
-	addedLines := make([]string, 0)
-
-	// Extract only added lines (starting with +)
-	for _, line := range lines {
-		if strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++") {
-			addedLines = append(addedLines, strings.TrimPrefix(line, "+"))
-		}
-	}
-
-	if len(addedLines) == 0 {
-		return false, ""
+func (s *NamingPatternStrategy) analyzeCodeContent(lines []textLine) *Finding {
+	if len(lines) == 0 {
+		return nil
 	}
 
 	suspiciousPatterns := 0
 	totalPatterns := 0
 
+	addedLines := texts(lines)
+	found := newEvidence(lines)
 	codeContent := strings.Join(addedLines, "\n")
 
 	genericVarPatterns := []string{
@@ -157,6 +244,7 @@ func (s *NamingPatternStrategy) analyzeCodeContent(diffContent string) (isSuspic
 	for _, pattern := range genericVarPatterns {
 		if strings.Contains(strings.ToLower(codeContent), pattern) {
 			suspiciousPatterns++
+			found.containing(pattern)
 		}
 		totalPatterns++
 	}
@@ -165,6 +253,7 @@ func (s *NamingPatternStrategy) analyzeCodeContent(diffContent string) (isSuspic
 	fixmeCount := strings.Count(strings.ToLower(codeContent), "fixme")
 	if todoCount > 2 || fixmeCount > 1 {
 		suspiciousPatterns++
+		found.containing("todo", "fixme")
 	}
 
 	words := strings.Fields(codeContent)
@@ -174,25 +263,45 @@ func (s *NamingPatternStrategy) analyzeCodeContent(diffContent string) (isSuspic
 			perfectCamelCaseCount++
 		}
 	}
-	if len(words) > 10 && float64(perfectCamelCaseCount)/float64(len(words)) > 0.3 {
+	if len(words) > 10 && float64(perfectCamelCaseCount)/float64(len(words)) > s.maxCamelRatio {
 		suspiciousPatterns++
+		for i, line := range addedLines {
+			for _, word := range strings.Fields(line) {
+				if len(word) > 4 && isPerfectCamelCase(word) {
+					found.marked[i] = true
+				}
+			}
+		}
 	}
 
 	if strings.Contains(codeContent, "catch") || strings.Contains(codeContent, "except") {
 		errorHandlingCount := strings.Count(codeContent, "catch") + strings.Count(codeContent, "except") + strings.Count(codeContent, "try")
-		if errorHandlingCount > len(addedLines)/20 {
+		maxHandlers := 0
+		if s.linesPerHandler > 0 {
+			maxHandlers = int(float64(len(addedLines)) / s.linesPerHandler)
+		}
+		if errorHandlingCount > maxHandlers {
 			suspiciousPatterns++
+			found.containing("catch", "except", "try")
 		}
 	}
 
-	if suspiciousPatterns >= 2 {
-		return true, fmt.Sprintf(
-			"Code contains multiple AI-slop patterns (%d detected) - generic names, TODO comments, perfect patterns",
-			suspiciousPatterns,
-		)
+	if suspiciousPatterns >= int(s.minIndicators) {
+		finding := &Finding{
+			Category:   CategoryCode,
+			Confidence: indicatorStrength(suspiciousPatterns, int(s.minIndicators)),
+			Observed:   float64(suspiciousPatterns),
+			Threshold:  s.minIndicators,
+			Message: fmt.Sprintf(
+				"Code contains multiple AI-slop patterns (%d detected) - generic names, TODO comments, perfect patterns",
+				suspiciousPatterns,
+			),
+		}
+		locate(finding, found.found())
+		return finding
 	}
 
-	return false, ""
+	return nil
 }
 
 func isPerfectCamelCase(word string) bool {
@@ -215,53 +324,87 @@ func isPerfectCamelCase(word string) bool {
 }
 
 type StructuralConsistencyStrategy struct {
-	enabled bool
+	enabled          bool
+	minLines         float64
+	balanceTolerance float64
+	ratioTolerance   float64
 }
 
 func NewStructuralConsistencyStrategy() *StructuralConsistencyStrategy {
-	return &StructuralConsistencyStrategy{enabled: true}
+	return &StructuralConsistencyStrategy{
+		enabled:          true,
+		minLines:         100,
+		balanceTolerance: 0.1,
+		ratioTolerance:   0.05,
+	}
 }
 
 func (s *StructuralConsistencyStrategy) Name() string {
 	return "structural_consistency_analysis"
 }
 
-func (s *StructuralConsistencyStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+func (s *StructuralConsistencyStrategy) params() []param {
+	return []param{
+		{name: "min_lines", value: &s.minLines},
+		{name: "balance_tolerance", value: &s.balanceTolerance, max: 1},
+		{name: "ratio_tolerance", value: &s.ratioTolerance, max: 0.5},
+	}
+}
+
+func (s *StructuralConsistencyStrategy) Params() map[string]float64 { return paramValues(s.params()) }
+
+func (s *StructuralConsistencyStrategy) SetParam(name string, value float64) error {
+	return setParam(s.Name(), s.params(), name, value)
+}
+
+func (s *StructuralConsistencyStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding {
 	if !s.enabled {
-		return false, ""
+		return nil
 	}
 
-	if pair.Stats.Additions > 100 && pair.Stats.Deletions > 100 {
+	if float64(pair.Stats.Additions) > s.minLines && float64(pair.Stats.Deletions) > s.minLines {
 		ratio := float64(pair.Stats.Additions) / float64(pair.Stats.Deletions)
 
-		if ratio >= 0.9 && ratio <= 1.1 {
-			return true, fmt.Sprintf(
-				"Suspiciously balanced addition/deletion ratio: %.2f - may indicate automated refactoring",
-				ratio,
-			)
+		if math.Abs(ratio-1) <= s.balanceTolerance {
+			return &Finding{
+				Category:   CategoryStructure,
+				Confidence: underThreshold(math.Abs(ratio-1), s.balanceTolerance),
+				Observed:   ratio,
+				Message: fmt.Sprintf(
+					"Suspiciously balanced addition/deletion ratio: %.2f - may indicate automated refactoring",
+					ratio,
+				),
+			}
 		}
 
 		// Or very consistent ratios (e.g., exactly 2:1, 3:1)
-		if isNearInteger(ratio, 0.05) || isNearInteger(1.0/ratio, 0.05) {
-			return true, fmt.Sprintf(
-				"Suspiciously consistent addition/deletion ratio: %.2f - may indicate template-based generation",
-				ratio,
-			)
+		if isNearInteger(ratio, s.ratioTolerance) || isNearInteger(1.0/ratio, s.ratioTolerance) {
+			return &Finding{
+				Category:   CategoryStructure,
+				Confidence: 0.5,
+				Observed:   ratio,
+				Message: fmt.Sprintf(
+					"Suspiciously consistent addition/deletion ratio: %.2f - may indicate template-based generation",
+					ratio,
+				),
+			}
 		}
 	}
 
-	return false, ""
+	return nil
 }
 
 type BurstPatternStrategy struct {
-	maxCommitsPerHour int
-	enabled           bool
+	enabled       bool
+	windowSeconds float64
+	minAdditions  float64
 }
 
-func NewBurstPatternStrategy(maxPerHour int) *BurstPatternStrategy {
+func NewBurstPatternStrategy() *BurstPatternStrategy {
 	return &BurstPatternStrategy{
-		maxCommitsPerHour: maxPerHour,
-		enabled:           true,
+		enabled:       true,
+		windowSeconds: 300,
+		minAdditions:  50,
 	}
 }
 
@@ -269,44 +412,92 @@ func (s *BurstPatternStrategy) Name() string {
 	return "burst_pattern_analysis"
 }
 
-func (s *BurstPatternStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
-	if !s.enabled || s.maxCommitsPerHour <= 0 {
-		return false, ""
+func (s *BurstPatternStrategy) params() []param {
+	return []param{
+		{name: "window_seconds", value: &s.windowSeconds},
+		{name: "min_additions", value: &s.minAdditions},
 	}
+}
+
+func (s *BurstPatternStrategy) Params() map[string]float64 { return paramValues(s.params()) }
+
+func (s *BurstPatternStrategy) SetParam(name string, value float64) error {
+	return setParam(s.Name(), s.params(), name, value)
+}
 
-	if pair.TimeDelta.Seconds() < 300 && pair.Stats.Additions > 50 {
-		return true, fmt.Sprintf(
-			"Rapid commit pattern: %.1f seconds between substantial commits - may indicate batch processing",
-			pair.TimeDelta.Seconds(),
-		)
+func (s *BurstPatternStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding {
+	if !s.enabled {
+		return nil
+	}
+
+	if pair.TimeDelta.Seconds() < s.windowSeconds && float64(pair.Stats.Additions) > s.minAdditions {
+		return &Finding{
+			Category:   CategoryTiming,
+			Confidence: underThreshold(pair.TimeDelta.Seconds(), s.windowSeconds),
+			Observed:   pair.TimeDelta.Seconds(),
+			Threshold:  s.windowSeconds,
+			Message: fmt.Sprintf(
+				"Rapid commit pattern: %.1f seconds between substantial commits - may indicate batch processing",
+				pair.TimeDelta.Seconds(),
+			),
+		}
 	}
 
-	return false, ""
+	return nil
 }
 
 type ErrorHandlingPatternStrategy struct {
-	enabled bool
+	enabled          bool
+	skipGo           bool
+	minAdditions     float64
+	largeCommitLines float64
+	linesPerHandler  float64
+	minSparseLines   float64
 }
 
-func NewErrorHandlingPatternStrategy() *ErrorHandlingPatternStrategy {
-	return &ErrorHandlingPatternStrategy{enabled: true}
+// NewErrorHandlingPatternStrategy returns the strategy; skipGo leaves Go
+// code to go_source_analysis.
+func NewErrorHandlingPatternStrategy(skipGo bool) *ErrorHandlingPatternStrategy {
+	return &ErrorHandlingPatternStrategy{
+		enabled:          true,
+		skipGo:           skipGo,
+		minAdditions:     50,
+		largeCommitLines: 300,
+		linesPerHandler:  30,
+		minSparseLines:   100,
+	}
 }
 
 func (s *ErrorHandlingPatternStrategy) Name() string {
 	return "error_handling_analysis"
 }
 
-func (s *ErrorHandlingPatternStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+func (s *ErrorHandlingPatternStrategy) params() []param {
+	return []param{
+		{name: "min_additions", value: &s.minAdditions},
+		{name: "large_commit_lines", value: &s.largeCommitLines},
+		{name: "lines_per_handler", value: &s.linesPerHandler},
+		{name: "min_sparse_additions", value: &s.minSparseLines},
+	}
+}
+
+func (s *ErrorHandlingPatternStrategy) Params() map[string]float64 { return paramValues(s.params()) }
+
+func (s *ErrorHandlingPatternStrategy) SetParam(name string, value float64) error {
+	return setParam(s.Name(), s.params(), name, value)
+}
+
+func (s *ErrorHandlingPatternStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding {
 	if !s.enabled {
-		return false, ""
+		return nil
 	}
 
 	// Analyze actual code content if available
-	if pair.DiffContent != "" && pair.Stats.Additions > 50 {
-		return s.analyzeErrorHandling(pair.DiffContent, pair.Stats.Additions)
+	if pair.DiffContent != "" && float64(pair.Stats.Additions) > s.minAdditions {
+		return s.analyzeErrorHandling(addedCodeLines(pair, s.skipGo), pair.Stats.Additions)
 	}
 
-	if pair.Stats.Additions > 200 {
+	if float64(pair.Stats.Additions) > s.largeCommitLines {
 		msg := strings.ToLower(pair.Current.Message)
 		hasErrorPatterns := strings.Contains(msg, "error") ||
 			strings.Contains(msg, "exception") ||
@@ -314,32 +505,29 @@ func (s *ErrorHandlingPatternStrategy) Detect(pair *git.CommitPair, repoStats *m
 			strings.Contains(msg, "catch") ||
 			strings.Contains(msg, "handle")
 
-		if !hasErrorPatterns && pair.Stats.Additions > 300 {
-			return true, fmt.Sprintf(
-				"Large code addition (%d lines) with no error handling mentions - AI often omits error handling",
-				pair.Stats.Additions,
-			)
+		if !hasErrorPatterns {
+			return &Finding{
+				Category:   CategoryCode,
+				Confidence: 0.5,
+				Observed:   float64(pair.Stats.Additions),
+				Threshold:  s.largeCommitLines,
+				Message: fmt.Sprintf(
+					"Large code addition (%d lines) with no error handling mentions - AI often omits error handling",
+					pair.Stats.Additions,
+				),
+			}
 		}
 	}
 
-	return false, ""
+	return nil
 }
 
-func (s *ErrorHandlingPatternStrategy) analyzeErrorHandling(diffContent string, additions int64) (isSuspicious bool, reason string) {
-	lines := strings.Split(diffContent, "\n")
-	addedLines := make([]string, 0)
-
-	// Extract only added lines
-	for _, line := range lines {
-		if strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++") {
-			addedLines = append(addedLines, strings.TrimPrefix(line, "+"))
-		}
-	}
-
-	if len(addedLines) < 20 {
-		return false, ""
+func (s *ErrorHandlingPatternStrategy) analyzeErrorHandling(lines []textLine, additions int64) *Finding {
+	if len(lines) < 20 {
+		return nil
 	}
 
+	addedLines := texts(lines)
 	codeContent := strings.ToLower(strings.Join(addedLines, "\n"))
 
 	errorHandlingPatterns := 0
@@ -359,47 +547,95 @@ func (s *ErrorHandlingPatternStrategy) analyzeErrorHandling(diffContent string,
 	errorHandlingPatterns += strings.Count(codeContent, "rescue")  // Ruby
 
 	// Calculate expected error handling density
-	expectedErrorHandling := len(addedLines) / 30
-
-	if additions > 100 && errorHandlingPatterns < expectedErrorHandling {
-		return true, fmt.Sprintf(
-			"Large code addition (%d lines) with insufficient error handling (%d patterns, expected ~%d) - typical AI omission",
-			additions, errorHandlingPatterns, expectedErrorHandling,
-		)
+	expectedErrorHandling := 0
+	if s.linesPerHandler > 0 {
+		expectedErrorHandling = int(float64(len(addedLines)) / s.linesPerHandler)
+	}
+
+	// Too little handling is a trait of the whole addition; too much is
+	// found on the lines holding it.
+	if float64(additions) > s.minSparseLines && errorHandlingPatterns < expectedErrorHandling {
+		finding := &Finding{
+			Category:   CategoryCode,
+			Confidence: underThreshold(float64(errorHandlingPatterns), float64(expectedErrorHandling)),
+			Observed:   float64(errorHandlingPatterns),
+			Threshold:  float64(expectedErrorHandling),
+			Message: fmt.Sprintf(
+				"Large code addition (%d lines) with insufficient error handling (%d patterns, expected ~%d) - typical AI omission",
+				additions, errorHandlingPatterns, expectedErrorHandling,
+			),
+		}
+		locate(finding, lines)
+		return finding
 	}
 
 	if errorHandlingPatterns > len(addedLines)/5 {
-		return true, fmt.Sprintf(
-			"Excessive error handling patterns (%d in %d lines) - may indicate AI over-compensation",
-			errorHandlingPatterns, len(addedLines),
-		)
+		found := newEvidence(lines)
+		found.containing("try", "catch", "except", "throw", "error", "exception", "handle", "if err != nil", "rescue")
+		finding := &Finding{
+			Category:   CategoryCode,
+			Confidence: overThreshold(float64(errorHandlingPatterns), float64(len(addedLines)/5)),
+			Observed:   float64(errorHandlingPatterns),
+			Threshold:  float64(len(addedLines) / 5),
+			Message: fmt.Sprintf(
+				"Excessive error handling patterns (%d in %d lines) - may indicate AI over-compensation",
+				errorHandlingPatterns, len(addedLines),
+			),
+		}
+		locate(finding, found.found())
+		return finding
 	}
 
-	return false, ""
+	return nil
 }
 
 type TemplatePatternStrategy struct {
-	enabled bool
+	enabled             bool
+	skipGo              bool
+	minAdditions        float64
+	minIndicators       float64
+	messageMinAdditions float64
 }
 
-func NewTemplatePatternStrategy() *TemplatePatternStrategy {
-	return &TemplatePatternStrategy{enabled: true}
+// NewTemplatePatternStrategy returns the strategy; skipGo leaves Go code
+// to go_source_analysis.
+func NewTemplatePatternStrategy(skipGo bool) *TemplatePatternStrategy {
+	return &TemplatePatternStrategy{
+		enabled:             true,
+		skipGo:              skipGo,
+		minAdditions:        50,
+		minIndicators:       2,
+		messageMinAdditions: 100,
+	}
 }
 
 func (s *TemplatePatternStrategy) Name() string {
 	return "template_pattern_analysis"
 }
 
-func (s *TemplatePatternStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+func (s *TemplatePatternStrategy) params() []param {
+	return []param{
+		{name: "min_additions", value: &s.minAdditions},
+		{name: "min_indicators", value: &s.minIndicators},
+		{name: "message_min_additions", value: &s.messageMinAdditions},
+	}
+}
+
+func (s *TemplatePatternStrategy) Params() map[string]float64 { return paramValues(s.params()) }
+
+func (s *TemplatePatternStrategy) SetParam(name string, value float64) error {
+	return setParam(s.Name(), s.params(), name, value)
+}
+
+func (s *TemplatePatternStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding {
 	if !s.enabled {
-		return false, ""
+		return nil
 	}
 
 	// Analyze actual code content if available
-	if pair.DiffContent != "" && pair.Stats.Additions > 50 {
-		detected, reason := s.analyzeTemplatePatterns(pair.DiffContent)
-		if detected {
-			return detected, reason
+	if pair.DiffContent != "" && float64(pair.Stats.Additions) > s.minAdditions {
+		if finding := s.analyzeTemplatePatterns(addedCodeLines(pair, s.skipGo)); finding != nil {
+			return finding
 		}
 	}
 
@@ -415,31 +651,29 @@ func (s *TemplatePatternStrategy) Detect(pair *git.CommitPair, repoStats *metric
 		}
 	}
 
-	if patternCount > 0 && pair.Stats.Additions > 100 {
-		return true, fmt.Sprintf(
-			"Template/boilerplate patterns detected in large commit (%d lines) - may be AI-generated scaffold",
-			pair.Stats.Additions,
-		)
+	if patternCount > 0 && float64(pair.Stats.Additions) > s.messageMinAdditions {
+		return &Finding{
+			Category:   CategoryCode,
+			Confidence: indicatorStrength(patternCount, 1),
+			Observed:   float64(patternCount),
+			Threshold:  1,
+			Message: fmt.Sprintf(
+				"Template/boilerplate patterns detected in large commit (%d lines) - may be AI-generated scaffold",
+				pair.Stats.Additions,
+			),
+		}
 	}
 
-	return false, ""
+	return nil
 }
 
-func (s *TemplatePatternStrategy) analyzeTemplatePatterns(diffContent string) (isSuspicious bool, reason string) {
-	lines := strings.Split(diffContent, "\n")
-	addedLines := make([]string, 0)
-
-	// Extract only added lines
-	for _, line := range lines {
-		if strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++") {
-			addedLines = append(addedLines, strings.TrimPrefix(line, "+"))
-		}
-	}
-
-	if len(addedLines) < 10 {
-		return false, ""
+func (s *TemplatePatternStrategy) analyzeTemplatePatterns(lines []textLine) *Finding {
+	if len(lines) < 10 {
+		return nil
 	}
 
+	addedLines := texts(lines)
+	found := newEvidence(lines)
 	codeContent := strings.Join(addedLines, "\n")
 	lowerContent := strings.ToLower(codeContent)
 
@@ -452,10 +686,12 @@ func (s *TemplatePatternStrategy) analyzeTemplatePatterns(diffContent string) (i
 	for _, pattern := range templateComments {
 		if strings.Contains(lowerContent, pattern) {
 			suspiciousPatterns++
+			found.containing(pattern)
 		}
 	}
 
 	repetitiveCount := 0
+	repetitive := make([]bool, len(addedLines))
 	for i := 0; i < len(addedLines)-1; i++ {
 		line1 := strings.TrimSpace(addedLines[i])
 		line2 := strings.TrimSpace(addedLines[i+1])
@@ -473,6 +709,7 @@ func (s *TemplatePatternStrategy) analyzeTemplatePatterns(diffContent string) (i
 				}
 				if differences <= 2 {
 					repetitiveCount++
+					repetitive[i], repetitive[i+1] = true, true
 				}
 			}
 		}
@@ -480,6 +717,9 @@ func (s *TemplatePatternStrategy) analyzeTemplatePatterns(diffContent string) (i
 
 	if repetitiveCount > len(addedLines)/8 {
 		suspiciousPatterns++
+		for i, r := range repetitive {
+			found.marked[i] = found.marked[i] || r
+		}
 	}
 
 	indentationLevels := make(map[int]int)
@@ -524,17 +764,26 @@ func (s *TemplatePatternStrategy) analyzeTemplatePatterns(diffContent string) (i
 
 		if len(importLines) > 5 && len(addedLines) < len(importLines)*10 {
 			suspiciousPatterns++
+			found.containing("import")
 		}
 	}
 
-	if suspiciousPatterns >= 2 {
-		return true, fmt.Sprintf(
-			"Template/generated code patterns detected (%d indicators) - repetitive structure, perfect formatting, template comments",
-			suspiciousPatterns,
-		)
+	if suspiciousPatterns >= int(s.minIndicators) {
+		finding := &Finding{
+			Category:   CategoryCode,
+			Confidence: indicatorStrength(suspiciousPatterns, int(s.minIndicators)),
+			Observed:   float64(suspiciousPatterns),
+			Threshold:  s.minIndicators,
+			Message: fmt.Sprintf(
+				"Template/generated code patterns detected (%d indicators) - repetitive structure, perfect formatting, template comments",
+				suspiciousPatterns,
+			),
+		}
+		locate(finding, found.found())
+		return finding
 	}
 
-	return false, ""
+	return nil
 }
 
 func isNearInteger(value, tolerance float64) bool {
@@ -543,35 +792,68 @@ func isNearInteger(value, tolerance float64) bool {
 }
 
 type FileExtensionPatternStrategy struct {
-	enabled bool
+	enabled         bool
+	minFiles        float64
+	minAdditions    float64
+	minLinesPerFile float64
+	maxLinesPerFile float64
+	minConsistency  float64
 }
 
 func NewFileExtensionPatternStrategy() *FileExtensionPatternStrategy {
-	return &FileExtensionPatternStrategy{enabled: true}
+	return &FileExtensionPatternStrategy{
+		enabled:         true,
+		minFiles:        10,
+		minAdditions:    1000,
+		minLinesPerFile: 50,
+		maxLinesPerFile: 200,
+		minConsistency:  0.8,
+	}
 }
 
 func (s *FileExtensionPatternStrategy) Name() string {
 	return "file_extension_analysis"
 }
 
-func (s *FileExtensionPatternStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+func (s *FileExtensionPatternStrategy) params() []param {
+	return []param{
+		{name: "min_files", value: &s.minFiles},
+		{name: "min_additions", value: &s.minAdditions},
+		{name: "min_lines_per_file", value: &s.minLinesPerFile},
+		{name: "max_lines_per_file", value: &s.maxLinesPerFile},
+		{name: "min_consistency", value: &s.minConsistency, max: 1},
+	}
+}
+
+func (s *FileExtensionPatternStrategy) Params() map[string]float64 { return paramValues(s.params()) }
+
+func (s *FileExtensionPatternStrategy) SetParam(name string, value float64) error {
+	return setParam(s.Name(), s.params(), name, value)
+}
+
+func (s *FileExtensionPatternStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding {
 	if !s.enabled {
-		return false, ""
+		return nil
 	}
 
-	if pair.Stats.FilesChanged > 10 && pair.Stats.Additions > 1000 {
+	if float64(pair.Stats.FilesChanged) > s.minFiles && float64(pair.Stats.Additions) > s.minAdditions {
 		avgLinesPerFile := float64(pair.Stats.Additions) / float64(pair.Stats.FilesChanged)
 
-		if avgLinesPerFile > 50 && avgLinesPerFile < 200 {
+		if avgLinesPerFile > s.minLinesPerFile && avgLinesPerFile < s.maxLinesPerFile {
 			consistency := 1.0 - (avgLinesPerFile - float64(int(avgLinesPerFile)))
-			if consistency > 0.8 {
-				return true, fmt.Sprintf(
-					"Suspicious file creation pattern: %d files with consistent size (~%.0f lines each) - may be generated",
-					pair.Stats.FilesChanged, avgLinesPerFile,
-				)
+			if consistency > s.minConsistency {
+				return &Finding{
+					Category:   CategoryStructure,
+					Confidence: 0.5,
+					Observed:   avgLinesPerFile,
+					Message: fmt.Sprintf(
+						"Suspicious file creation pattern: %d files with consistent size (~%.0f lines each) - may be generated",
+						pair.Stats.FilesChanged, avgLinesPerFile,
+					),
+				}
 			}
 		}
 	}
 
-	return false, ""
+	return nil
 }
@@ -2,22 +2,44 @@ package patterns
 
 import (
 	"fmt"
+	"math"
+	"sort"
+	"time"
 
 	"github.com/TryCadence/Cadence/internal/analysis"
 	"github.com/TryCadence/Cadence/internal/git"
 	"github.com/TryCadence/Cadence/internal/metrics"
 )
 
+// Baselines a StatisticalAnomalyStrategy finding can be measured against,
+// from most to least specific.
+const (
+	BaselineAuthor     = "author"
+	BaselineWindow     = "window"
+	BaselineRepository = "repository"
+)
+
+// StatisticalAnomalyStrategy compares each commit's size with a baseline of
+// the history before it. It prefers the author's own commits within the
+// window_days before the commit, then everyone's commits in that window,
+// and falls back to the whole baseline when neither holds min_commits
+// commits. Only earlier commits count, so a later run of unusual commits
+// cannot shift the baseline the first of them is judged against.
 type StatisticalAnomalyStrategy struct {
-	baseline        *analysis.RepositoryBaseline
-	baselinePairs   []*git.CommitPair
-	baselineUpdated bool
-	enabled         bool
+	baseline      *analysis.RepositoryBaseline
+	baselinePairs []*git.CommitPair
+	byAuthor      map[string]*trailingBaselines
+	window        *trailingBaselines
+	enabled       bool
+	windowDays    float64
+	minCommits    float64
 }
 
 func NewStatisticalAnomalyStrategy() *StatisticalAnomalyStrategy {
 	return &StatisticalAnomalyStrategy{
-		enabled: true,
+		enabled:    true,
+		windowDays: 90,
+		minCommits: 10,
 	}
 }
 
@@ -25,19 +47,39 @@ func (s *StatisticalAnomalyStrategy) Name() string {
 	return "StatisticalAnomaly"
 }
 
-func (s *StatisticalAnomalyStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+func (s *StatisticalAnomalyStrategy) params() []param {
+	return []param{
+		{name: "window_days", value: &s.windowDays},
+		{name: "min_commits", value: &s.minCommits},
+	}
+}
+
+func (s *StatisticalAnomalyStrategy) Params() map[string]float64 { return paramValues(s.params()) }
+
+func (s *StatisticalAnomalyStrategy) SetParam(name string, value float64) error {
+	return setParam(s.Name(), s.params(), name, value)
+}
+
+func (s *StatisticalAnomalyStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding {
 	if !s.enabled || pair == nil || pair.Stats == nil {
-		return false, ""
+		return nil
 	}
 
 	if s.baseline == nil {
-		return false, ""
+		return nil
 	}
 
-	anomalies := analysis.DetectStatisticalAnomalies(pair, s.baseline)
+	baseline, kind, size := s.baselineFor(pair)
+	anomalies := analysis.DetectStatisticalAnomalies(pair, baseline)
+
+	if len(anomalies) == 0 {
+		return nil
+	}
 
+	// File dispersion anomalies are reported by FileDispersionAnomalyStrategy.
+	anomalies = withoutType(anomalies, analysis.AnomalyFileDispersion)
 	if len(anomalies) == 0 {
-		return false, ""
+		return nil
 	}
 
 	var significantAnomalies []*analysis.StatisticalAnomaly
@@ -48,39 +90,260 @@ func (s *StatisticalAnomalyStrategy) Detect(pair *git.CommitPair, repoStats *met
 	}
 
 	if len(significantAnomalies) > 0 {
-		return true, fmt.Sprintf("Statistical anomalies detected: %s (z-score: %.2f, baseline: %.0f, observed: %.0f)",
-			significantAnomalies[0].Description,
-			significantAnomalies[0].Score,
-			significantAnomalies[0].BaselineValue,
-			significantAnomalies[0].ObservedValue,
-		)
+		return &Finding{
+			Category:   CategoryStatistical,
+			Baseline:   kind,
+			Confidence: zScoreStrength(significantAnomalies[0].Score),
+			Observed:   significantAnomalies[0].ObservedValue,
+			Threshold:  significantAnomalies[0].BaselineValue,
+			Message: fmt.Sprintf("Statistical anomalies detected: %s (z-score: %.2f, %s baseline of %d commits: %.0f, observed: %.0f)",
+				significantAnomalies[0].Description,
+				significantAnomalies[0].Score,
+				kind,
+				size,
+				significantAnomalies[0].BaselineValue,
+				significantAnomalies[0].ObservedValue,
+			),
+		}
 	}
 
 	if len(anomalies) > 0 {
-		return true, fmt.Sprintf("Moderate statistical deviation: %s (z-score: %.2f)",
-			anomalies[0].Description,
-			anomalies[0].Score,
-		)
+		return &Finding{
+			Category:   CategoryStatistical,
+			Baseline:   kind,
+			Confidence: 0.3,
+			Observed:   anomalies[0].ObservedValue,
+			Threshold:  anomalies[0].BaselineValue,
+			Message: fmt.Sprintf("Moderate statistical deviation: %s (z-score: %.2f, %s baseline of %d commits)",
+				anomalies[0].Description,
+				anomalies[0].Score,
+				kind,
+				size,
+			),
+		}
 	}
 
-	return false, ""
+	return nil
+}
+
+// zScoreStrength maps the score of a significant anomaly to a strength:
+// about 0.67 at three standard deviations and 0.9 at ten. Anomalies that
+// are significant for other reasons never score below 0.5.
+func zScoreStrength(z float64) float64 {
+	if math.Abs(z) <= 2 {
+		return 0.5
+	}
+	return clampStrength(1 - 1/math.Abs(z))
 }
 
 func (s *StatisticalAnomalyStrategy) SetBaseline(pairs []*git.CommitPair) {
-	s.baselinePairs = pairs
+	s.baselinePairs = make([]*git.CommitPair, 0, len(pairs))
+	for _, pair := range pairs {
+		if pair.Stats == nil || (pair.Stats.Additions == 0 && pair.Stats.Deletions == 0) {
+			continue
+		}
+		s.baselinePairs = append(s.baselinePairs, pair)
+	}
+	sort.SliceStable(s.baselinePairs, func(i, j int) bool {
+		return s.baselinePairs[i].Current.Timestamp.Before(s.baselinePairs[j].Current.Timestamp)
+	})
+
+	byAuthor := make(map[string][]*git.CommitPair)
+	for _, pair := range s.baselinePairs {
+		email := pair.Current.Email
+		byAuthor[email] = append(byAuthor[email], pair)
+	}
+
+	// A zero window_days leaves the window baseline off, but the author
+	// baseline then spans all of the author's earlier commits.
+	window := time.Duration(s.windowDays * 24 * float64(time.Hour))
+	s.byAuthor = make(map[string]*trailingBaselines, len(byAuthor))
+	for email, authored := range byAuthor {
+		s.byAuthor[email] = newTrailingBaselines(authored, window)
+	}
+	s.window = nil
+	if s.windowDays > 0 {
+		s.window = newTrailingBaselines(s.baselinePairs, window)
+	}
+
+	s.baseline = nil
+	if len(s.baselinePairs) > 0 {
+		s.baseline = analysis.CalculateBaseline(s.baselinePairs)
+	}
+}
+
+// baselineFor picks the most specific baseline with enough commits for
+// pair and returns it with its kind and the number of commits behind it.
+// The pair itself is never part of its author or window baseline.
+func (s *StatisticalAnomalyStrategy) baselineFor(pair *git.CommitPair) (*analysis.RepositoryBaseline, string, int) {
+	if author, ok := s.byAuthor[pair.Current.Email]; ok {
+		if baseline, size := author.before(pair.Current.Timestamp); s.enough(size) {
+			return baseline, BaselineAuthor, size
+		}
+	}
+	if s.window != nil {
+		if baseline, size := s.window.before(pair.Current.Timestamp); s.enough(size) {
+			return baseline, BaselineWindow, size
+		}
+	}
+	return s.baseline, BaselineRepository, len(s.baselinePairs)
+}
+
+func (s *StatisticalAnomalyStrategy) enough(size int) bool {
+	return size > 0 && float64(size) >= s.minCommits
+}
+
+// trailingBaselines holds the baselines of a time-sorted list of pairs over
+// a trailing window: for a moment t, the pairs committed in the window
+// before t. The window's content only changes just after a pair is
+// committed, when it enters, and just after it falls out of the window,
+// so one sweep over those changes computes every baseline a moment can
+// have. A zero window reaches back to the first pair.
+type trailingBaselines struct {
+	// changes[i] is the moment after which baselines[i] and sizes[i] hold,
+	// until changes[i+1].
+	changes   []time.Time
+	baselines []*analysis.RepositoryBaseline
+	sizes     []int
+}
+
+func newTrailingBaselines(sorted []*git.CommitPair, window time.Duration) *trailingBaselines {
+	t := &trailingBaselines{}
+	var rolling analysis.RollingBaseline
+	enter, leave := 0, 0
+	for enter < len(sorted) || (window > 0 && leave < enter) {
+		next := time.Time{}
+		if enter < len(sorted) {
+			next = sorted[enter].Current.Timestamp
+		}
+		if window > 0 && leave < enter {
+			if expiry := sorted[leave].Current.Timestamp.Add(window); next.IsZero() || expiry.Before(next) {
+				next = expiry
+			}
+		}
+
+		for enter < len(sorted) && sorted[enter].Current.Timestamp.Equal(next) {
+			rolling.Add(sorted[enter])
+			enter++
+		}
+		for window > 0 && leave < enter && !sorted[leave].Current.Timestamp.Add(window).After(next) {
+			rolling.Remove(sorted[leave])
+			leave++
+		}
+
+		t.changes = append(t.changes, next)
+		t.baselines = append(t.baselines, rolling.Baseline())
+		t.sizes = append(t.sizes, rolling.Len())
+	}
+	return t
+}
+
+// before returns the baseline of the pairs committed in the window before
+// moment and how many pairs it holds.
+func (t *trailingBaselines) before(moment time.Time) (*analysis.RepositoryBaseline, int) {
+	i := sort.Search(len(t.changes), func(i int) bool { return !t.changes[i].Before(moment) }) - 1
+	if i < 0 {
+		return nil, 0
+	}
+	return t.baselines[i], t.sizes[i]
+}
+
+func withoutType(anomalies []*analysis.StatisticalAnomaly, t analysis.AnomalyType) []*analysis.StatisticalAnomaly {
+	kept := make([]*analysis.StatisticalAnomaly, 0, len(anomalies))
+	for _, anomaly := range anomalies {
+		if anomaly.Type != t {
+			kept = append(kept, anomaly)
+		}
+	}
+	return kept
+}
+
+// FileDispersionAnomalyStrategy flags commits touching far more files than
+// the baseline average, when the commit is also larger than the median.
+type FileDispersionAnomalyStrategy struct {
+	baseline *analysis.RepositoryBaseline
+}
+
+func NewFileDispersionAnomalyStrategy() *FileDispersionAnomalyStrategy {
+	return &FileDispersionAnomalyStrategy{}
+}
+
+func (s *FileDispersionAnomalyStrategy) Name() string {
+	return string(analysis.AnomalyFileDispersion)
+}
+
+func (s *FileDispersionAnomalyStrategy) SetBaseline(pairs []*git.CommitPair) {
 	if len(pairs) > 0 {
 		s.baseline = analysis.CalculateBaseline(pairs)
-		s.baselineUpdated = true
+	}
+}
+
+func (s *FileDispersionAnomalyStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding {
+	if pair == nil || pair.Stats == nil || s.baseline == nil {
+		return nil
+	}
+
+	for _, anomaly := range analysis.DetectStatisticalAnomalies(pair, s.baseline) {
+		if anomaly.Type != analysis.AnomalyFileDispersion {
+			continue
+		}
+		return &Finding{
+			Category:   CategoryStructure,
+			Confidence: overThreshold(anomaly.Score, 3),
+			Observed:   anomaly.ObservedValue,
+			Threshold:  anomaly.BaselineValue,
+			Message: fmt.Sprintf("%s (%.0f files, %.1fx the average of %.1f)",
+				anomaly.Description, anomaly.ObservedValue, anomaly.Score, anomaly.BaselineValue),
+		}
+	}
+	return nil
+}
+
+// EntropyAnomalyStrategy flags large commits whose changes are almost all
+// additions or almost all deletions.
+type EntropyAnomalyStrategy struct{}
+
+func NewEntropyAnomalyStrategy() *EntropyAnomalyStrategy {
+	return &EntropyAnomalyStrategy{}
+}
+
+func (s *EntropyAnomalyStrategy) Name() string {
+	return string(analysis.AnomalyEntropy)
+}
+
+func (s *EntropyAnomalyStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding {
+	if pair == nil || pair.Stats == nil {
+		return nil
+	}
+
+	anomaly := analysis.DetectEntropyAnomalies(pair)
+	if anomaly == nil {
+		return nil
+	}
+	return &Finding{
+		Category:   CategoryStatistical,
+		Confidence: underThreshold(anomaly.ObservedValue, 0.5),
+		Observed:   anomaly.ObservedValue,
+		Threshold:  0.5,
+		Message:    fmt.Sprintf("%s (entropy: %.2f bits)", anomaly.Description, anomaly.ObservedValue),
 	}
 }
 
 type TimingAnomalyStrategy struct {
-	enabled bool
+	enabled         bool
+	shortGapSeconds float64
+	shortGapLines   float64
+	longGapHours    float64
+	longGapLines    float64
 }
 
 func NewTimingAnomalyStrategy() *TimingAnomalyStrategy {
 	return &TimingAnomalyStrategy{
-		enabled: true,
+		enabled:         true,
+		shortGapSeconds: 60,
+		shortGapLines:   50,
+		longGapHours:    24,
+		longGapLines:    500,
 	}
 }
 
@@ -88,22 +351,50 @@ func (s *TimingAnomalyStrategy) Name() string {
 	return "TimingAnomaly"
 }
 
-func (s *TimingAnomalyStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+func (s *TimingAnomalyStrategy) params() []param {
+	return []param{
+		{name: "short_gap_seconds", value: &s.shortGapSeconds},
+		{name: "short_gap_lines", value: &s.shortGapLines},
+		{name: "long_gap_hours", value: &s.longGapHours},
+		{name: "long_gap_lines", value: &s.longGapLines},
+	}
+}
+
+func (s *TimingAnomalyStrategy) Params() map[string]float64 { return paramValues(s.params()) }
+
+func (s *TimingAnomalyStrategy) SetParam(name string, value float64) error {
+	return setParam(s.Name(), s.params(), name, value)
+}
+
+func (s *TimingAnomalyStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding {
 	if !s.enabled || pair == nil {
-		return false, ""
+		return nil
 	}
 
-	timeDeltaMinutes := pair.TimeDelta.Minutes()
+	additions := float64(pair.Stats.Additions)
+	deletions := float64(pair.Stats.Deletions)
 
-	if timeDeltaMinutes < 1.0 && (pair.Stats.Additions > 50 || pair.Stats.Deletions > 50) {
-		return true, fmt.Sprintf("Unusually short time since last commit (%.1f seconds) with significant changes",
-			pair.TimeDelta.Seconds())
+	if pair.TimeDelta.Seconds() < s.shortGapSeconds && (additions > s.shortGapLines || deletions > s.shortGapLines) {
+		return &Finding{
+			Category:   CategoryTiming,
+			Confidence: 0.6,
+			Observed:   pair.TimeDelta.Seconds(),
+			Threshold:  s.shortGapSeconds,
+			Message: fmt.Sprintf("Unusually short time since last commit (%.1f seconds) with significant changes",
+				pair.TimeDelta.Seconds()),
+		}
 	}
 
-	if timeDeltaMinutes > 1440 && (pair.Stats.Additions > 500 || pair.Stats.Deletions > 500) {
-		return true, fmt.Sprintf("Very long gap since last commit (%.1f hours) followed by large changes",
-			pair.TimeDelta.Hours())
+	if s.longGapHours > 0 && pair.TimeDelta.Hours() > s.longGapHours && (additions > s.longGapLines || deletions > s.longGapLines) {
+		return &Finding{
+			Category:   CategoryTiming,
+			Confidence: 0.4,
+			Observed:   pair.TimeDelta.Hours(),
+			Threshold:  s.longGapHours,
+			Message: fmt.Sprintf("Very long gap since last commit (%.1f hours) followed by large changes",
+				pair.TimeDelta.Hours()),
+		}
 	}
 
-	return false, ""
+	return nil
 }
@@ -0,0 +1,246 @@
+package patterns
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/git"
+)
+
+func anomalyPair(hash, email string, additions, deletions int64, files int, delta time.Duration) *git.CommitPair {
+	return &git.CommitPair{
+		Previous:  &git.Commit{Hash: hash + "-parent"},
+		Current:   &git.Commit{Hash: hash, Email: email},
+		TimeDelta: delta,
+		Stats:     &git.DiffStats{Additions: additions, Deletions: deletions, FilesChanged: files},
+	}
+}
+
+func TestEntropyAnomalyStrategy(t *testing.T) {
+	tests := []struct {
+		name      string
+		additions int64
+		deletions int64
+		want      bool
+	}{
+		{"large additions only", 800, 0, true},
+		{"large and balanced", 400, 400, false},
+		{"small additions only", 100, 0, false},
+	}
+
+	s := NewEntropyAnomalyStrategy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			finding := s.Detect(anomalyPair("abc", "a@example.com", tt.additions, tt.deletions, 1, time.Hour), nil)
+			if (finding != nil) != tt.want {
+				t.Fatalf("Detect() = %v, want detection %v", finding, tt.want)
+			}
+			if finding != nil && (finding.Confidence != 1 || finding.Category != CategoryStatistical) {
+				t.Errorf("Confidence, Category = %v, %q, want 1, statistical", finding.Confidence, finding.Category)
+			}
+		})
+	}
+}
+
+func TestFileDispersionAnomalyStrategy(t *testing.T) {
+	baseline := make([]*git.CommitPair, 0, 20)
+	for i := 0; i < 20; i++ {
+		baseline = append(baseline, anomalyPair(fmt.Sprintf("b%d", i), "a@example.com", int64(20+i%5), 5, 2, time.Hour))
+	}
+
+	s := NewFileDispersionAnomalyStrategy()
+	if finding := s.Detect(anomalyPair("wide", "a@example.com", 60, 10, 12, time.Hour), nil); finding != nil {
+		t.Fatalf("Detect() without baseline = %v, want nil", finding)
+	}
+
+	s.SetBaseline(baseline)
+	tests := []struct {
+		name  string
+		files int
+		want  bool
+	}{
+		{"twelve files", 12, true},
+		{"typical file count", 2, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			finding := s.Detect(anomalyPair("wide", "a@example.com", 60, 10, tt.files, time.Hour), nil)
+			if (finding != nil) != tt.want {
+				t.Fatalf("Detect() = %v, want detection %v", finding, tt.want)
+			}
+			if finding != nil && (finding.Observed != 12 || finding.Threshold != 2) {
+				t.Errorf("Observed, Threshold = %v, %v, want 12, 2", finding.Observed, finding.Threshold)
+			}
+		})
+	}
+}
+
+func TestRepositoryStrategies(t *testing.T) {
+	rapid := make([]*git.CommitPair, 0, 8)
+	for i := 0; i < 4; i++ {
+		rapid = append(rapid, anomalyPair(fmt.Sprintf("r%d", i), "a@example.com", 10, 2, 1, time.Minute))
+	}
+	for i := 0; i < 4; i++ {
+		rapid = append(rapid, anomalyPair(fmt.Sprintf("s%d", i), "a@example.com", 10, 2, 1, 3*time.Hour))
+	}
+
+	behavior := make([]*git.CommitPair, 0, 12)
+	for i := 0; i < 11; i++ {
+		behavior = append(behavior, anomalyPair(fmt.Sprintf("n%d", i), "a@example.com", 20, 5, 1, 3*time.Hour))
+	}
+	behavior = append(behavior, anomalyPair("huge", "a@example.com", 2000, 100, 1, 3*time.Hour))
+
+	tests := []struct {
+		name     string
+		strategy RepositoryStrategy
+		pairs    []*git.CommitPair
+		want     []string
+	}{
+		{"cluster reported against its last commit", NewTimingClusterStrategy(), rapid, []string{"r3"}},
+		{"no cluster among spaced commits", NewTimingClusterStrategy(), behavior, nil},
+		{"commit far above author average", NewAuthorBehaviorStrategy(), behavior, []string{"huge"}},
+		{"uniform author", NewAuthorBehaviorStrategy(), rapid, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := tt.strategy.DetectRepository(tt.pairs, nil)
+			if len(findings) != len(tt.want) {
+				t.Fatalf("DetectRepository() = %v, want commits %v", findings, tt.want)
+			}
+			for i, f := range findings {
+				if f.Commit != tt.want[i] {
+					t.Errorf("findings[%d].Commit = %q, want %q", i, f.Commit, tt.want[i])
+				}
+				if f.Confidence <= 0 || f.Confidence > 1 {
+					t.Errorf("findings[%d].Confidence = %v, want in (0, 1]", i, f.Confidence)
+				}
+			}
+		})
+	}
+}
+
+func TestStatisticalAnomalyStrategy_Baselines(t *testing.T) {
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	at := func(day int) time.Time { return start.Add(time.Duration(day) * 24 * time.Hour) }
+	commit := func(hash, email string, day int, additions int64) *git.CommitPair {
+		pair := anomalyPair(hash, email, additions, additions/4, 2, time.Hour)
+		pair.Current.Timestamp = at(day)
+		return pair
+	}
+
+	// A prolific author of large commits among many small ones.
+	history := make([]*git.CommitPair, 0, 80)
+	for i := 0; i < 12; i++ {
+		history = append(history, commit(fmt.Sprintf("senior%d", i), "senior@example.com", 2*i, int64(700+20*(i%10))))
+	}
+	for i := 0; i < 60; i++ {
+		history = append(history, commit(fmt.Sprintf("team%d", i), fmt.Sprintf("dev%d@example.com", i%6), i/2, int64(15+i%10)))
+	}
+	for i := 0; i < 3; i++ {
+		history = append(history, commit(fmt.Sprintf("occasional%d", i), "occasional@example.com", 10*i, 20))
+	}
+
+	// A run of huge commits by the prolific author after day 30.
+	burst := make([]*git.CommitPair, 0, 12)
+	for i := 0; i < 12; i++ {
+		burst = append(burst, commit(fmt.Sprintf("burst%d", i), "senior@example.com", 31+i, 9000+int64(100*i)))
+	}
+
+	tests := []struct {
+		name         string
+		pair         *git.CommitPair
+		later        []*git.CommitPair
+		windowDays   float64
+		minCommits   float64
+		wantFinding  bool
+		wantBaseline string
+	}{
+		{"large commit is routine for a prolific author", commit("senior-new", "senior@example.com", 30, 820), nil, 90, 10, false, ""},
+		{"same commit stands out against the repository", commit("senior-new", "senior@example.com", 30, 820), nil, 0, 100, true, BaselineRepository},
+		{"occasional contributor falls back to the window", commit("occasional-new", "occasional@example.com", 30, 900), nil, 90, 10, true, BaselineWindow},
+		{"no window falls back to the repository", commit("occasional-new", "occasional@example.com", 30, 900), nil, 0, 10, true, BaselineRepository},
+		{"outlier against the author's own history", commit("senior-huge", "senior@example.com", 30, 9000), nil, 90, 10, true, BaselineAuthor},
+		{"later burst does not hide the first outlier", commit("senior-huge", "senior@example.com", 30, 9000), burst, 90, 10, true, BaselineAuthor},
+		{"only earlier commits form the baseline", commit("occasional-new", "occasional@example.com", 3, 900), nil, 90, 10, true, BaselineRepository},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewStatisticalAnomalyStrategy()
+			if err := s.SetParam("window_days", tt.windowDays); err != nil {
+				t.Fatalf("SetParam() error = %v", err)
+			}
+			if err := s.SetParam("min_commits", tt.minCommits); err != nil {
+				t.Fatalf("SetParam() error = %v", err)
+			}
+			s.SetBaseline(append(append([]*git.CommitPair{}, history...), tt.later...))
+
+			finding := s.Detect(tt.pair, nil)
+			if (finding != nil) != tt.wantFinding {
+				t.Fatalf("Detect() = %+v, want finding %v", finding, tt.wantFinding)
+			}
+			if finding != nil && finding.Baseline != tt.wantBaseline {
+				t.Errorf("Baseline = %q, want %q (%s)", finding.Baseline, tt.wantBaseline, finding.Message)
+			}
+		})
+	}
+}
+
+func TestStatisticalAnomalyStrategy_RepositoryBaselineSkipsEmptyPairs(t *testing.T) {
+	changed := make([]*git.CommitPair, 0, 10)
+	for i := 0; i < 10; i++ {
+		changed = append(changed, anomalyPair(fmt.Sprintf("c%d", i), "dev@example.com", int64(100+i), 10, 1, time.Hour))
+	}
+	pairs := append([]*git.CommitPair{}, changed...)
+	for i := 0; i < 30; i++ {
+		pairs = append(pairs, anomalyPair(fmt.Sprintf("empty%d", i), "dev@example.com", 0, 0, 0, time.Hour))
+	}
+
+	s := NewStatisticalAnomalyStrategy()
+	s.SetBaseline(pairs)
+	if want := analysis.CalculateBaseline(changed); !reflect.DeepEqual(s.baseline, want) {
+		t.Errorf("repository baseline = %+v, want %+v", s.baseline, want)
+	}
+	if _, kind, size := s.baselineFor(anomalyPair("new", "new@example.com", 500, 10, 1, time.Hour)); kind != BaselineRepository || size != len(changed) {
+		t.Errorf("baselineFor() = %s of %d commits, want %s of %d", kind, size, BaselineRepository, len(changed))
+	}
+}
+
+func TestTrailingBaselines(t *testing.T) {
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	sorted := make([]*git.CommitPair, 0, 30)
+	for i := 0; i < 30; i++ {
+		pair := anomalyPair(fmt.Sprintf("c%d", i), "dev@example.com", int64(10+i*7%23), int64(i%5), 1, time.Hour)
+		// Several commits share a moment, and the gaps vary.
+		pair.Current.Timestamp = start.Add(time.Duration(i/2*(1+i%3)) * time.Hour)
+		sorted = append(sorted, pair)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Current.Timestamp.Before(sorted[j].Current.Timestamp) })
+
+	for _, window := range []time.Duration{0, 6 * time.Hour} {
+		trailing := newTrailingBaselines(sorted, window)
+		for h := -1; h < 60; h++ {
+			moment := start.Add(time.Duration(h) * time.Hour)
+			var want []*git.CommitPair
+			for _, p := range sorted {
+				ts := p.Current.Timestamp
+				if ts.Before(moment) && (window == 0 || !ts.Before(moment.Add(-window))) {
+					want = append(want, p)
+				}
+			}
+
+			baseline, size := trailing.before(moment)
+			if size != len(want) {
+				t.Fatalf("window %v, hour %d: size = %d, want %d", window, h, size, len(want))
+			}
+			if size > 0 && baseline.AvgAdditions != analysis.CalculateBaseline(want).AvgAdditions {
+				t.Errorf("window %v, hour %d: AvgAdditions = %v, want %v", window, h, baseline.AvgAdditions, analysis.CalculateBaseline(want).AvgAdditions)
+			}
+		}
+	}
+}
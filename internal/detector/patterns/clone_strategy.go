@@ -0,0 +1,437 @@
+package patterns
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/TryCadence/Cadence/internal/git"
+	"github.com/TryCadence/Cadence/internal/langmodel"
+	"github.com/TryCadence/Cadence/internal/metrics"
+)
+
+const (
+	// winnowWindow is how many consecutive k-gram hashes each fingerprint
+	// is chosen from. Clones of at least min_tokens+winnowWindow-1 tokens
+	// are always found.
+	winnowWindow = 4
+	// maxCloneGroups caps the groups a finding lists.
+	maxCloneGroups = 10
+)
+
+// NearCloneStrategy flags commits whose added code is largely copies of
+// itself: handlers, test cases or methods repeated with little more than
+// renamed identifiers. Added lines are tokenized with identifiers and
+// literals normalized, fingerprinted by winnowing k-grams of min_tokens
+// tokens, and matching fingerprints are extended into maximal clones.
+type NearCloneStrategy struct {
+	enabled        bool
+	minTokens      float64
+	minAddedTokens float64
+	minSimilarity  float64
+}
+
+func NewNearCloneStrategy() *NearCloneStrategy {
+	return &NearCloneStrategy{
+		enabled:        true,
+		minTokens:      40,
+		minAddedTokens: 300,
+		minSimilarity:  0.5,
+	}
+}
+
+func (s *NearCloneStrategy) Name() string {
+	return "near_clone_analysis"
+}
+
+func (s *NearCloneStrategy) params() []param {
+	return []param{
+		{name: "min_tokens", value: &s.minTokens},
+		{name: "min_added_tokens", value: &s.minAddedTokens},
+		{name: "min_similarity", value: &s.minSimilarity, max: 1},
+	}
+}
+
+func (s *NearCloneStrategy) Params() map[string]float64 { return paramValues(s.params()) }
+
+func (s *NearCloneStrategy) SetParam(name string, value float64) error {
+	return setParam(s.Name(), s.params(), name, value)
+}
+
+func (s *NearCloneStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding {
+	if !s.enabled || pair.DiffContent == "" {
+		return nil
+	}
+
+	runs := tokenizeAddedRuns(pair.DiffContent)
+	total := 0
+	for _, run := range runs {
+		total += len(run.tokens)
+	}
+	k := int(s.minTokens)
+	if k < 1 || total == 0 || float64(total) < s.minAddedTokens {
+		return nil
+	}
+
+	matches := findClones(runs, k)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	covered := make([][]bool, len(runs))
+	for i, run := range runs {
+		covered[i] = make([]bool, len(run.tokens))
+	}
+	for _, m := range matches {
+		for _, r := range []tokenRange{m.a, m.b} {
+			for i := r.start; i < r.end; i++ {
+				covered[r.run][i] = true
+			}
+		}
+	}
+	cloned := 0
+	for _, c := range covered {
+		for _, ok := range c {
+			if ok {
+				cloned++
+			}
+		}
+	}
+
+	similarity := float64(cloned) / float64(total)
+	if similarity < s.minSimilarity {
+		return nil
+	}
+
+	groups := cloneGroups(runs, matches)
+	var locations []Location
+	for i, group := range groups {
+		if i == maxCloneGroups {
+			break
+		}
+		for _, loc := range group {
+			loc.Group = i + 1
+			locations = append(locations, loc)
+		}
+	}
+
+	largest := groups[0]
+	shown := make([]string, 0, 3)
+	for _, loc := range largest {
+		if len(shown) == cap(shown) {
+			shown = append(shown, "...")
+			break
+		}
+		shown = append(shown, fmt.Sprintf("%s:%d-%d", loc.File, loc.StartLine, loc.EndLine))
+	}
+
+	return &Finding{
+		Category:   CategoryCode,
+		Confidence: overThreshold(similarity, s.minSimilarity),
+		Observed:   similarity,
+		Threshold:  s.minSimilarity,
+		File:       largest[0].File,
+		StartLine:  largest[0].StartLine,
+		EndLine:    largest[0].EndLine,
+		Locations:  locations,
+		Message: fmt.Sprintf(
+			"%.0f%% of added code is near-duplicated across %d clone groups; the largest has %d copies (%s)",
+			similarity*100, len(groups), len(largest), strings.Join(shown, ", "),
+		),
+	}
+}
+
+// cloneToken is one normalized token of an added line.
+type cloneToken struct {
+	text string
+	line int
+}
+
+// addedRun is a run of consecutive added lines in one file. Clones never
+// span runs, since the lines between them were not written by the commit.
+type addedRun struct {
+	file   string
+	tokens []cloneToken
+}
+
+// tokenizeAddedRuns splits a unified diff's added lines into runs, with
+// line numbers taken from the post-image side of each hunk header.
+func tokenizeAddedRuns(diffContent string) []addedRun {
+	var runs []addedRun
+	lastFile, lastAdded := "", -1
+	forEachAddedLine(diffContent, func(file string, line int, text string) {
+		if len(runs) == 0 || file != lastFile || lastAdded != line-1 {
+			runs = append(runs, addedRun{file: file})
+		}
+		current := &runs[len(runs)-1]
+		for _, tok := range cloneTokens(text) {
+			current.tokens = append(current.tokens, cloneToken{text: tok, line: line})
+		}
+		lastFile, lastAdded = file, line
+	})
+
+	kept := runs[:0]
+	for _, run := range runs {
+		if len(run.tokens) > 0 {
+			kept = append(kept, run)
+		}
+	}
+	return kept
+}
+
+// cloneKeywords are kept as written; every other identifier becomes "$",
+// so clones that only rename things still match.
+var cloneKeywords = map[string]bool{
+	"break": true, "case": true, "catch": true, "class": true, "const": true,
+	"continue": true, "def": true, "default": true, "defer": true, "do": true,
+	"else": true, "false": true, "fn": true, "for": true, "func": true,
+	"function": true, "go": true, "if": true, "import": true, "in": true,
+	"interface": true, "let": true, "map": true, "new": true, "nil": true,
+	"null": true, "package": true, "pub": true, "range": true, "return": true,
+	"self": true, "struct": true, "switch": true, "this": true, "throw": true,
+	"true": true, "try": true, "type": true, "var": true, "while": true,
+}
+
+// cloneTokens tokenizes a line with langmodel.CodeTokens, which already
+// replaces number and string literals with placeholders, and turns every
+// identifier but a keyword into "$".
+func cloneTokens(line string) []string {
+	tokens := langmodel.CodeTokens(line)
+	for i, tok := range tokens {
+		if r, _ := utf8.DecodeRuneInString(tok); (r == '_' || unicode.IsLetter(r)) && !cloneKeywords[tok] {
+			tokens[i] = "$"
+		}
+	}
+	return tokens
+}
+
+// tokenRange is tokens [start, end) of a run.
+type tokenRange struct {
+	run, start, end int
+}
+
+type cloneMatch struct {
+	a, b tokenRange
+}
+
+// findClones winnows the k-grams of each run and extends every pair of
+// matching fingerprints into a maximal clone of at least k tokens.
+func findClones(runs []addedRun, k int) []cloneMatch {
+	type position struct{ run, index int }
+	index := make(map[uint64][]position)
+	for r, run := range runs {
+		hashes := kgramHashes(run.tokens, k)
+		for _, i := range winnow(hashes) {
+			index[hashes[i]] = append(index[hashes[i]], position{r, i})
+		}
+	}
+
+	// Seeds on the diagonal of a clone already found add nothing.
+	type diagonal struct{ runA, runB, offset int }
+	found := make(map[diagonal][]tokenRange)
+	seen := func(d diagonal, i int) bool {
+		for _, r := range found[d] {
+			if i >= r.start && i < r.end {
+				return true
+			}
+		}
+		return false
+	}
+
+	hashes := make([]uint64, 0, len(index))
+	for h := range index {
+		hashes = append(hashes, h)
+	}
+	sort.Slice(hashes, func(i, j int) bool { return hashes[i] < hashes[j] })
+
+	// Only consecutive occurrences of a fingerprint are paired: the groups
+	// still join every copy, and a match does not reach across the copies
+	// in between.
+	var matches []cloneMatch
+	for _, h := range hashes {
+		positions := index[h]
+		for x := 0; x+1 < len(positions); x++ {
+			a, b := positions[x], positions[x+1]
+			d := diagonal{a.run, b.run, b.index - a.index}
+			if seen(d, a.index) {
+				continue
+			}
+			ta, tb := runs[a.run].tokens, runs[b.run].tokens
+
+			start := 0
+			for a.index-start > 0 && b.index-start > 0 && ta[a.index-start-1].text == tb[b.index-start-1].text {
+				start++
+			}
+			end := 0
+			for a.index+end < len(ta) && b.index+end < len(tb) && ta[a.index+end].text == tb[b.index+end].text {
+				end++
+			}
+			extent := tokenRange{a.run, a.index - start, a.index + end}
+			found[d] = append(found[d], extent)
+			length := extent.end - extent.start
+			if a.run != b.run || d.offset >= length {
+				if length >= k {
+					matches = append(matches, cloneMatch{
+						a: extent,
+						b: tokenRange{b.run, b.index - start, b.index + end},
+					})
+				}
+				continue
+			}
+
+			// Copies that follow one another overlap when extended:
+			// split the repeat into copies one period long.
+			period := d.offset
+			if period < k {
+				continue
+			}
+			for c := extent.start; c+2*period <= extent.end+period; c += period {
+				matches = append(matches, cloneMatch{
+					a: tokenRange{a.run, c, c + period},
+					b: tokenRange{a.run, c + period, c + 2*period},
+				})
+			}
+		}
+	}
+	return matches
+}
+
+func kgramHash(tokens []cloneToken) uint64 {
+	h := fnv.New64a()
+	for _, t := range tokens {
+		h.Write([]byte(t.text))
+		h.Write([]byte{0})
+	}
+	return h.Sum64()
+}
+
+func kgramHashes(tokens []cloneToken, k int) []uint64 {
+	if len(tokens) < k {
+		return nil
+	}
+	hashes := make([]uint64, len(tokens)-k+1)
+	for i := range hashes {
+		hashes[i] = kgramHash(tokens[i : i+k])
+	}
+	return hashes
+}
+
+// winnow selects the positions of the minimum hash in every window of
+// winnowWindow consecutive hashes, preferring the rightmost on ties.
+func winnow(hashes []uint64) []int {
+	if len(hashes) == 0 {
+		return nil
+	}
+	w := winnowWindow
+	if w > len(hashes) {
+		w = len(hashes)
+	}
+
+	var selected []int
+	last := -1
+	for start := 0; start+w <= len(hashes); start++ {
+		min := start
+		for i := start; i < start+w; i++ {
+			if hashes[i] <= hashes[min] {
+				min = i
+			}
+		}
+		if min != last {
+			selected = append(selected, min)
+			last = min
+		}
+	}
+	return selected
+}
+
+// cloneGroups joins clones that share a copy into groups of line ranges,
+// largest group first. Overlapping copies in a group are merged.
+func cloneGroups(runs []addedRun, matches []cloneMatch) [][]Location {
+	ranges := make([]tokenRange, 0, 2*len(matches))
+	for _, m := range matches {
+		ranges = append(ranges, m.a, m.b)
+	}
+
+	parent := make([]int, len(ranges))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	union := func(i, j int) { parent[find(i)] = find(j) }
+
+	for i := range matches {
+		union(2*i, 2*i+1)
+	}
+	// Copies that overlap are the same fragment.
+	order := make([]int, len(ranges))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := ranges[order[i]], ranges[order[j]]
+		if a.run != b.run {
+			return a.run < b.run
+		}
+		return a.start < b.start
+	})
+	for i := 1; i < len(order); i++ {
+		prev, cur := ranges[order[i-1]], ranges[order[i]]
+		if prev.run == cur.run && cur.start < prev.end {
+			union(order[i-1], order[i])
+		}
+	}
+
+	members := make(map[int][]tokenRange)
+	for i, r := range ranges {
+		members[find(i)] = append(members[find(i)], r)
+	}
+
+	groups := make([][]Location, 0, len(members))
+	for _, rs := range members {
+		sort.Slice(rs, func(i, j int) bool {
+			if rs[i].run != rs[j].run {
+				return rs[i].run < rs[j].run
+			}
+			return rs[i].start < rs[j].start
+		})
+		var group []Location
+		var last tokenRange
+		for i, r := range rs {
+			if i > 0 && r.run == last.run && r.start < last.end {
+				if r.end > last.end {
+					last.end = r.end
+					group[len(group)-1].EndLine = runs[r.run].tokens[r.end-1].line
+				}
+				continue
+			}
+			tokens := runs[r.run].tokens
+			group = append(group, Location{
+				File:      runs[r.run].file,
+				StartLine: tokens[r.start].line,
+				EndLine:   tokens[r.end-1].line,
+			})
+			last = r
+		}
+		groups = append(groups, group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if len(groups[i]) != len(groups[j]) {
+			return len(groups[i]) > len(groups[j])
+		}
+		if groups[i][0].File != groups[j][0].File {
+			return groups[i][0].File < groups[j][0].File
+		}
+		return groups[i][0].StartLine < groups[j][0].StartLine
+	})
+	return groups
+}
@@ -0,0 +1,198 @@
+package patterns
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/git"
+)
+
+// cloneHandler is a 13-line HTTP handler followed by a blank line; copies
+// differ only in names and literals.
+func cloneHandler(name string) []string {
+	return []string{
+		fmt.Sprintf("func handle%s(w http.ResponseWriter, r *http.Request) {", name),
+		fmt.Sprintf("\tid := r.URL.Query().Get(%q)", strings.ToLower(name)+"_id"),
+		"\tif id == \"\" {",
+		"\t\thttp.Error(w, \"missing id\", http.StatusBadRequest)",
+		"\t\treturn",
+		"\t}",
+		fmt.Sprintf("\titem, err := store.Get%s(r.Context(), id)", name),
+		"\tif err != nil {",
+		"\t\thttp.Error(w, err.Error(), http.StatusInternalServerError)",
+		"\t\treturn",
+		"\t}",
+		"\tjson.NewEncoder(w).Encode(item)",
+		"}",
+		"",
+	}
+}
+
+// newFileDiff renders lines as a diff adding a new file.
+func newFileDiff(file string, lines []string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "diff --git a/%s b/%s\nnew file mode 100644\n--- /dev/null\n+++ b/%s\n", file, file, file)
+	fmt.Fprintf(&sb, "@@ -0,0 +1,%d @@\n", len(lines))
+	for _, line := range lines {
+		sb.WriteString("+" + line + "\n")
+	}
+	return sb.String()
+}
+
+func TestNearCloneStrategy(t *testing.T) {
+	var handlers []string
+	for _, name := range []string{"User", "Order", "Invoice", "Product"} {
+		handlers = append(handlers, cloneHandler(name)...)
+	}
+
+	varied := []string{
+		"package config",
+		"",
+		"// Load reads the configuration file and applies defaults.",
+		"func Load(path string) (*Config, error) {",
+		"\tdata, err := os.ReadFile(path)",
+		"\tif err != nil {",
+		"\t\treturn nil, fmt.Errorf(\"failed to read config: %w\", err)",
+		"\t}",
+		"\tcfg := &Config{Port: 8080, Timeout: 30 * time.Second}",
+		"\tif err := yaml.Unmarshal(data, cfg); err != nil {",
+		"\t\treturn nil, fmt.Errorf(\"failed to parse config: %w\", err)",
+		"\t}",
+		"\tfor _, origin := range cfg.Origins {",
+		"\t\tif !strings.HasPrefix(origin, \"https://\") {",
+		"\t\t\tlog.Printf(\"insecure origin %s\", origin)",
+		"\t\t}",
+		"\t}",
+		"\tswitch cfg.Mode {",
+		"\tcase \"dev\", \"test\":",
+		"\t\tcfg.Debug = true",
+		"\tdefault:",
+		"\t\tcfg.Workers = runtime.NumCPU() * 2",
+		"\t}",
+		"\treturn cfg, cfg.validate()",
+		"}",
+		"",
+		"type Config struct {",
+		"\tPort    int           `yaml:\"port\"`",
+		"\tTimeout time.Duration `yaml:\"timeout\"`",
+		"\tOrigins []string      `yaml:\"origins\"`",
+		"\tMode    string        `yaml:\"mode\"`",
+		"\tDebug   bool",
+		"\tWorkers int",
+		"}",
+		"",
+		"func (c *Config) validate() error {",
+		"\tif c.Port <= 0 || c.Port > 65535 {",
+		"\t\treturn errors.New(\"port out of range\")",
+		"\t}",
+		"\treturn nil",
+		"}",
+	}
+
+	tests := []struct {
+		name          string
+		diff          string
+		params        map[string]float64
+		wantLocations []Location
+	}{
+		{
+			name: "repeated handlers in one file",
+			diff: newFileDiff("handlers.go", handlers),
+			wantLocations: []Location{
+				{Group: 1, File: "handlers.go", StartLine: 1, EndLine: 13},
+				{Group: 1, File: "handlers.go", StartLine: 15, EndLine: 27},
+				{Group: 1, File: "handlers.go", StartLine: 29, EndLine: 41},
+				{Group: 1, File: "handlers.go", StartLine: 43, EndLine: 55},
+			},
+		},
+		{
+			name: "copies across files",
+			diff: newFileDiff("users.go", append(cloneHandler("User"), cloneHandler("Account")...)) +
+				newFileDiff("orders.go", cloneHandler("Order")),
+			wantLocations: []Location{
+				{Group: 1, File: "users.go", StartLine: 1, EndLine: 13},
+				{Group: 1, File: "users.go", StartLine: 15, EndLine: 27},
+				{Group: 1, File: "orders.go", StartLine: 1, EndLine: 13},
+			},
+		},
+		{
+			name:   "varied code",
+			diff:   newFileDiff("config.go", varied),
+			params: map[string]float64{"min_added_tokens": 100},
+		},
+		{
+			name: "too little code",
+			diff: newFileDiff("handlers.go", append(cloneHandler("User"), cloneHandler("Order")...)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pair := &git.CommitPair{
+				Current:     &git.Commit{Hash: "abc"},
+				Stats:       &git.DiffStats{Additions: 60},
+				DiffContent: tt.diff,
+			}
+			strategy := NewNearCloneStrategy()
+			for name, value := range tt.params {
+				if err := strategy.SetParam(name, value); err != nil {
+					t.Fatal(err)
+				}
+			}
+			finding := strategy.Detect(pair, nil)
+			if tt.wantLocations == nil {
+				if finding != nil {
+					t.Errorf("Detect() = %q, want nil", finding.Message)
+				}
+				return
+			}
+			if finding == nil {
+				t.Fatal("Detect() = nil, want clone finding")
+			}
+			if !reflect.DeepEqual(finding.Locations, tt.wantLocations) {
+				t.Errorf("Locations = %+v, want %+v", finding.Locations, tt.wantLocations)
+			}
+			first := tt.wantLocations[0]
+			if finding.File != first.File || finding.StartLine != first.StartLine || finding.EndLine != first.EndLine {
+				t.Errorf("File, StartLine, EndLine = %s, %d, %d, want %s, %d, %d",
+					finding.File, finding.StartLine, finding.EndLine, first.File, first.StartLine, first.EndLine)
+			}
+		})
+	}
+}
+
+func TestTokenizeAddedRuns(t *testing.T) {
+	diff := strings.Join([]string{
+		"diff --git a/a.go b/a.go",
+		"--- a/a.go",
+		"+++ b/a.go",
+		"@@ -10,3 +10,4 @@ func main() {",
+		" \tkeep()",
+		"+\tx := \"one\" + 2",
+		"-\tgone()",
+		"+\ty := x",
+		" \tkeep()",
+		"@@ -40,1 +41,2 @@",
+		" }",
+		"+// tail",
+	}, "\n")
+
+	runs := tokenizeAddedRuns(diff)
+	if len(runs) != 2 {
+		t.Fatalf("tokenizeAddedRuns() returned %d runs, want 2", len(runs))
+	}
+
+	var first []string
+	for _, tok := range runs[0].tokens {
+		first = append(first, fmt.Sprintf("%s@%d", tok.text, tok.line))
+	}
+	want := []string{"$@11", ":@11", "=@11", "<str>@11", "+@11", "<num>@11", "$@12", ":@12", "=@12", "$@12"}
+	if !reflect.DeepEqual(first, want) {
+		t.Errorf("first run = %v, want %v", first, want)
+	}
+	if runs[1].file != "a.go" || runs[1].tokens[0].line != 42 {
+		t.Errorf("second run starts at %s:%d, want a.go:42", runs[1].file, runs[1].tokens[0].line)
+	}
+}
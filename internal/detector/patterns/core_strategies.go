@@ -23,32 +23,44 @@ func (s *VelocityStrategy) Name() string {
 	return "velocity_analysis"
 }
 
-func (s *VelocityStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+func (s *VelocityStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding {
 	if pair.TimeDelta <= 0 {
-		return false, ""
+		return nil
 	}
 
 	if s.maxAdditionsPerMin > 0 {
 		addVelocity, err := metrics.CalculateVelocityPerMinute(pair.Stats.Additions, pair.TimeDelta)
 		if err == nil && addVelocity > s.maxAdditionsPerMin {
-			return true, fmt.Sprintf(
-				"Addition velocity too high: %.1f additions/min (threshold: %.1f additions/min)",
-				addVelocity, s.maxAdditionsPerMin,
-			)
+			return &Finding{
+				Category:   CategoryVelocity,
+				Confidence: overThreshold(addVelocity, s.maxAdditionsPerMin),
+				Observed:   addVelocity,
+				Threshold:  s.maxAdditionsPerMin,
+				Message: fmt.Sprintf(
+					"Addition velocity too high: %.1f additions/min (threshold: %.1f additions/min)",
+					addVelocity, s.maxAdditionsPerMin,
+				),
+			}
 		}
 	}
 
 	if s.maxDeletionsPerMin > 0 {
 		delVelocity, err := metrics.CalculateVelocityPerMinute(pair.Stats.Deletions, pair.TimeDelta)
 		if err == nil && delVelocity > s.maxDeletionsPerMin {
-			return true, fmt.Sprintf(
-				"Deletion velocity too high: %.1f deletions/min (threshold: %.1f deletions/min)",
-				delVelocity, s.maxDeletionsPerMin,
-			)
+			return &Finding{
+				Category:   CategoryVelocity,
+				Confidence: overThreshold(delVelocity, s.maxDeletionsPerMin),
+				Observed:   delVelocity,
+				Threshold:  s.maxDeletionsPerMin,
+				Message: fmt.Sprintf(
+					"Deletion velocity too high: %.1f deletions/min (threshold: %.1f deletions/min)",
+					delVelocity, s.maxDeletionsPerMin,
+				),
+			}
 		}
 	}
 
-	return false, ""
+	return nil
 }
 
 type SizeStrategy struct {
@@ -67,22 +79,34 @@ func (s *SizeStrategy) Name() string {
 	return "size_analysis"
 }
 
-func (s *SizeStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+func (s *SizeStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding {
 	if s.suspiciousAdditions > 0 && pair.Stats.Additions > s.suspiciousAdditions {
-		return true, fmt.Sprintf(
-			"Suspicious commit size: %d additions (threshold: %d lines)",
-			pair.Stats.Additions, s.suspiciousAdditions,
-		)
+		return &Finding{
+			Category:   CategorySize,
+			Confidence: overThreshold(float64(pair.Stats.Additions), float64(s.suspiciousAdditions)),
+			Observed:   float64(pair.Stats.Additions),
+			Threshold:  float64(s.suspiciousAdditions),
+			Message: fmt.Sprintf(
+				"Suspicious commit size: %d additions (threshold: %d lines)",
+				pair.Stats.Additions, s.suspiciousAdditions,
+			),
+		}
 	}
 
 	if s.suspiciousDeletions > 0 && pair.Stats.Deletions > s.suspiciousDeletions {
-		return true, fmt.Sprintf(
-			"Suspicious commit size: %d deletions (threshold: %d lines)",
-			pair.Stats.Deletions, s.suspiciousDeletions,
-		)
+		return &Finding{
+			Category:   CategorySize,
+			Confidence: overThreshold(float64(pair.Stats.Deletions), float64(s.suspiciousDeletions)),
+			Observed:   float64(pair.Stats.Deletions),
+			Threshold:  float64(s.suspiciousDeletions),
+			Message: fmt.Sprintf(
+				"Suspicious commit size: %d deletions (threshold: %d lines)",
+				pair.Stats.Deletions, s.suspiciousDeletions,
+			),
+		}
 	}
 
-	return false, ""
+	return nil
 }
 
 type TimingStrategy struct {
@@ -99,17 +123,23 @@ func (s *TimingStrategy) Name() string {
 	return "timing_analysis"
 }
 
-func (s *TimingStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+func (s *TimingStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding {
 	if s.minTimeDeltaSeconds > 0 {
 		if pair.TimeDelta.Seconds() < float64(s.minTimeDeltaSeconds) {
-			return true, fmt.Sprintf(
-				"Time between commits too short: %.1f seconds (threshold: %d seconds)",
-				pair.TimeDelta.Seconds(), s.minTimeDeltaSeconds,
-			)
+			return &Finding{
+				Category:   CategoryTiming,
+				Confidence: underThreshold(pair.TimeDelta.Seconds(), float64(s.minTimeDeltaSeconds)),
+				Observed:   pair.TimeDelta.Seconds(),
+				Threshold:  float64(s.minTimeDeltaSeconds),
+				Message: fmt.Sprintf(
+					"Time between commits too short: %.1f seconds (threshold: %d seconds)",
+					pair.TimeDelta.Seconds(), s.minTimeDeltaSeconds,
+				),
+			}
 		}
 	}
 
-	return false, ""
+	return nil
 }
 
 type MergeCommitStrategy struct {
@@ -126,15 +156,21 @@ func (s *MergeCommitStrategy) Name() string {
 	return "merge_commit_filter"
 }
 
-func (s *MergeCommitStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+func (s *MergeCommitStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding {
 	if len(pair.Current.Parents) > 1 {
 		if s.flagAsSuspicious {
-			return true, fmt.Sprintf(
-				"Merge commit with %d parents (potential history rewrite)",
-				len(pair.Current.Parents),
-			)
+			return &Finding{
+				Category:   CategoryStructure,
+				Confidence: 1,
+				Observed:   float64(len(pair.Current.Parents)),
+				Threshold:  1,
+				Message: fmt.Sprintf(
+					"Merge commit with %d parents (potential history rewrite)",
+					len(pair.Current.Parents),
+				),
+			}
 		}
-		return false, ""
+		return nil
 	}
-	return false, ""
+	return nil
 }
@@ -3,31 +3,56 @@ package patterns
 import (
 	"fmt"
 	"strings"
-	"unicode"
 
 	"github.com/TryCadence/Cadence/internal/git"
 	"github.com/TryCadence/Cadence/internal/metrics"
 )
 
+// EmojiPatternStrategy flags emoji bullets and decorations, in the commit
+// message and in the comments and string literals of added code.
+// A message fires on min_message_emojis emoji, or when emoji make up more
+// than max_message_ratio of its characters; code fires on min_code_emojis.
 type EmojiPatternStrategy struct {
-	enabled bool
+	enabled          bool
+	minMessageEmojis float64
+	maxMessageRatio  float64
+	minCodeEmojis    float64
 }
 
 func NewEmojiPatternStrategy() *EmojiPatternStrategy {
-	return &EmojiPatternStrategy{enabled: true}
+	return &EmojiPatternStrategy{
+		enabled:          true,
+		minMessageEmojis: 3,
+		maxMessageRatio:  0.2,
+		minCodeEmojis:    5,
+	}
 }
 
 func (s *EmojiPatternStrategy) Name() string {
 	return "emoji_pattern_analysis"
 }
 
+func (s *EmojiPatternStrategy) params() []param {
+	return []param{
+		{name: "min_message_emojis", value: &s.minMessageEmojis},
+		{name: "max_message_ratio", value: &s.maxMessageRatio, max: 1},
+		{name: "min_code_emojis", value: &s.minCodeEmojis},
+	}
+}
+
+func (s *EmojiPatternStrategy) Params() map[string]float64 { return paramValues(s.params()) }
+
+func (s *EmojiPatternStrategy) SetParam(name string, value float64) error {
+	return setParam(s.Name(), s.params(), name, value)
+}
+
+// isEmoji matches pictographs, dingbats and the emoji-style symbols of
+// the miscellaneous technical block, such as ✅, ✨, ⚡ and 🚀.
 func isEmoji(r rune) bool {
-	return r >= 0x1F300 && r <= 0x1F9FF ||
+	return r >= 0x1F300 && r <= 0x1FAFF ||
 		r >= 0x2600 && r <= 0x27BF ||
-		r >= 0x1F600 && r <= 0x1F64F ||
-		r >= 0x1F900 && r <= 0x1F9FF ||
 		r >= 0x2300 && r <= 0x23FF ||
-		r >= 0x2000 && r <= 0x206F
+		r >= 0x2B00 && r <= 0x2BFF
 }
 
 func countEmojis(text string) int {
@@ -40,68 +65,76 @@ func countEmojis(text string) int {
 	return count
 }
 
-func isEmojiHeavy(text string) bool {
-	if text == "" {
-		return false
-	}
-
-	emojiCount := countEmojis(text)
-	if emojiCount == 0 {
-		return false
-	}
-
-	emojiRatio := float64(emojiCount) / float64(len([]rune(text)))
-	return emojiRatio > 0.2
-}
-
-func hasEmojiOnlyCommit(text string) bool {
-	trimmed := strings.TrimSpace(text)
-	if trimmed == "" {
-		return false
-	}
-
-	emojiCount := countEmojis(trimmed)
-	if emojiCount == 0 {
-		return false
-	}
-
-	nonEmojiChars := 0
-	for _, r := range trimmed {
-		if !isEmoji(r) && !unicode.IsSpace(r) {
-			nonEmojiChars++
+// withEmoji returns the lines holding emoji and how many they hold.
+func withEmoji(lines []textLine) ([]textLine, int) {
+	var found []textLine
+	total := 0
+	for _, line := range lines {
+		if n := countEmojis(line.text); n > 0 {
+			found = append(found, line)
+			total += n
 		}
 	}
-
-	return emojiCount > nonEmojiChars*2
+	return found, total
 }
 
-func (s *EmojiPatternStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+func (s *EmojiPatternStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding {
 	if !s.enabled || pair == nil || pair.Current == nil {
-		return false, ""
+		return nil
 	}
 
-	msg := pair.Current.Message
-
-	emojiCount := countEmojis(msg)
-	msgLength := len([]rune(msg))
-
-	if emojiCount >= 3 {
-		return true, fmt.Sprintf(
-			"Excessive emoji usage in commit message (%d emojis detected)",
-			emojiCount,
-		)
+	var parts []string
+	var examples []textLine
+	var finding *Finding
+
+	msg := strings.TrimSpace(pair.Current.Message)
+	messageLines, messageEmojis := withEmoji(messageProse(msg))
+	if messageEmojis > 0 {
+		ratio := float64(messageEmojis) / float64(len([]rune(msg)))
+		switch {
+		case float64(messageEmojis) >= s.minMessageEmojis:
+			finding = &Finding{
+				Category:   CategoryMessage,
+				Confidence: indicatorStrength(messageEmojis, int(s.minMessageEmojis)),
+				Observed:   float64(messageEmojis),
+				Threshold:  s.minMessageEmojis,
+			}
+			parts = append(parts, fmt.Sprintf("%d in the commit message", messageEmojis))
+			examples = append(examples, messageLines...)
+		case ratio > s.maxMessageRatio:
+			finding = &Finding{
+				Category:   CategoryMessage,
+				Confidence: overThreshold(ratio, s.maxMessageRatio),
+				Observed:   ratio,
+				Threshold:  s.maxMessageRatio,
+			}
+			parts = append(parts, fmt.Sprintf("%.0f%% of the commit message", ratio*100))
+			examples = append(examples, messageLines...)
+		}
 	}
 
-	if isEmojiHeavy(msg) {
-		return true, fmt.Sprintf(
-			"Commit message is predominantly emoji (%.1f%% emoji content)",
-			float64(emojiCount)/float64(msgLength)*100,
-		)
+	codeLines, codeEmojis := withEmoji(addedProse(pair.DiffContent))
+	if float64(codeEmojis) >= s.minCodeEmojis {
+		strength := indicatorStrength(codeEmojis, int(s.minCodeEmojis))
+		if finding == nil {
+			finding = &Finding{
+				Category:   CategoryCode,
+				Confidence: strength,
+				Observed:   float64(codeEmojis),
+				Threshold:  s.minCodeEmojis,
+			}
+		} else if strength > finding.Confidence {
+			finding.Confidence = strength
+		}
+		locate(finding, codeLines)
+		parts = append(parts, fmt.Sprintf("%d in added comments and strings", codeEmojis))
+		examples = append(examples, codeLines...)
 	}
 
-	if hasEmojiOnlyCommit(msg) {
-		return true, "Commit message is primarily emoji with minimal text content"
+	if finding == nil {
+		return nil
 	}
-
-	return false, ""
+	finding.Message = fmt.Sprintf("Emoji used as decoration (%s), e.g. %s",
+		strings.Join(parts, ", "), excerpts(examples))
+	return finding
 }
@@ -0,0 +1,72 @@
+package patterns
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/git"
+)
+
+func TestEmojiPatternStrategy(t *testing.T) {
+	codeDiff := "diff --git a/ui.go b/ui.go\n--- a/ui.go\n+++ b/ui.go\n@@ -0,0 +1,4 @@\n" +
+		"+// 🚀 Fast path ✨\n+status := \"✅ done\"\n+warn := \"⚠️ careful\"\n+// 🎉 finished\n"
+
+	tests := []struct {
+		name         string
+		message      string
+		diff         string
+		wantCategory string
+		wantFile     string
+		wantExcerpt  string
+	}{
+		{
+			name:         "emoji bullets in message",
+			message:      "Improve startup\n\n✨ faster\n🚀 smaller\n✅ tested",
+			wantCategory: CategoryMessage,
+			wantExcerpt:  `"✨ faster" (commit message)`,
+		},
+		{
+			name:         "message mostly emoji",
+			message:      "🎉 ok",
+			wantCategory: CategoryMessage,
+		},
+		{
+			name:         "emoji in comments and strings",
+			message:      "Add status output",
+			diff:         codeDiff,
+			wantCategory: CategoryCode,
+			wantFile:     "ui.go",
+			wantExcerpt:  `"🚀 Fast path ✨" (ui.go:1)`,
+		},
+		{
+			name:    "one emoji in a long message",
+			message: "Fix the cache eviction order so old entries go first 👍",
+		},
+		{
+			name:    "em dashes are not emoji",
+			message: "Fix eviction — old entries first — and log it — twice",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pair := &git.CommitPair{Current: &git.Commit{Message: tt.message}, DiffContent: tt.diff}
+			finding := NewEmojiPatternStrategy().Detect(pair, nil)
+			if tt.wantCategory == "" {
+				if finding != nil {
+					t.Errorf("Detect() = %q, want nil", finding.Message)
+				}
+				return
+			}
+			if finding == nil {
+				t.Fatal("Detect() = nil, want a finding")
+			}
+			if finding.Category != tt.wantCategory || finding.File != tt.wantFile {
+				t.Errorf("Category, File = %q, %q, want %q, %q", finding.Category, finding.File, tt.wantCategory, tt.wantFile)
+			}
+			if !strings.Contains(finding.Message, tt.wantExcerpt) {
+				t.Errorf("Message = %q, want excerpt %s", finding.Message, tt.wantExcerpt)
+			}
+		})
+	}
+}
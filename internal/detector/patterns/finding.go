@@ -0,0 +1,84 @@
+package patterns
+
+import "time"
+
+// Severity buckets a score for reporting and alerting.
+type Severity string
+
+const (
+	SeverityLow    Severity = "low"
+	SeverityMedium Severity = "medium"
+	SeverityHigh   Severity = "high"
+)
+
+// Scope says what a finding is about. Per-pair strategies always report on
+// a commit; repository strategies may also report on an author or on a
+// window of time.
+type Scope string
+
+const (
+	ScopeCommit Scope = "commit"
+	ScopeAuthor Scope = "author"
+	ScopeWindow Scope = "window"
+)
+
+// Finding categories group strategies by the kind of evidence they use.
+const (
+	CategorySize        = "size"
+	CategoryVelocity    = "velocity"
+	CategoryTiming      = "timing"
+	CategoryStructure   = "structure"
+	CategoryMessage     = "message"
+	CategoryCode        = "code"
+	CategoryStatistical = "statistical"
+	CategoryProvenance  = "provenance"
+)
+
+// Finding is one strategy's evidence against a commit, an author or a
+// window of time.
+//
+// Confidence is the strategy's strength in (0, 1]. Observed and Threshold
+// hold the measured value and the limit it crossed when the strategy
+// compares a metric, and are zero otherwise. File and the line span locate
+// the evidence when it comes from a specific place in the diff; when it
+// comes from several, Locations lists them all and File repeats the first.
+// Baseline names what a statistical finding was compared against, such as
+// the author's own history or the whole repository.
+//
+// Repository strategies set Scope and say what the finding is about: Commit
+// holds the hash for commit findings, Author the identity for author
+// findings, and Start, End and Commits the span and the commits inside it
+// for window findings. An author finding sets Start to when the change
+// began. Per-pair findings leave these empty.
+//
+// Strategies fill in Category, Confidence, the values and Message; the
+// detector sets Strategy and Severity.
+type Finding struct {
+	Strategy   string
+	Scope      Scope
+	Commit     string
+	Author     string
+	Start      time.Time
+	End        time.Time
+	Commits    []string
+	Category   string
+	Severity   Severity
+	Confidence float64
+	Observed   float64
+	Threshold  float64
+	Baseline   string
+	File       string
+	StartLine  int
+	EndLine    int
+	Locations  []Location
+	Message    string
+}
+
+// Location is a span of lines in a file's post-image. Locations sharing a
+// Group belong together, such as the copies of one cloned fragment.
+type Location struct {
+	Group     int
+	File      string
+	StartLine int
+	EndLine   int
+}
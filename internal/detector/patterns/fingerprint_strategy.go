@@ -0,0 +1,204 @@
+package patterns
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/TryCadence/Cadence/internal/git"
+	"github.com/TryCadence/Cadence/internal/metrics"
+)
+
+// Fingerprint fields select which part of a commit a Fingerprint inspects.
+const (
+	FingerprintFieldTrailer   = "trailer"
+	FingerprintFieldMessage   = "message"
+	FingerprintFieldAuthor    = "author"
+	FingerprintFieldCommitter = "committer"
+)
+
+// Fingerprint describes a trace an AI tool leaves in commit metadata.
+// Pattern is a case-insensitive regular expression matched against:
+//
+//   - trailer: the value of trailers named Key (any trailer if Key is empty)
+//   - message: each line of the commit message
+//   - author: "Name <email>" of the author
+//   - committer: "Name <email>" of the committer, only when it differs
+//     from the author
+type Fingerprint struct {
+	Name    string
+	Field   string
+	Key     string
+	Pattern string
+}
+
+func (f Fingerprint) Validate() error {
+	if f.Name == "" {
+		return fmt.Errorf("fingerprint name cannot be empty")
+	}
+	switch f.Field {
+	case FingerprintFieldTrailer, FingerprintFieldMessage, FingerprintFieldAuthor, FingerprintFieldCommitter:
+	default:
+		return fmt.Errorf("fingerprint %q: unknown field %q", f.Name, f.Field)
+	}
+	if f.Pattern == "" {
+		return fmt.Errorf("fingerprint %q: pattern cannot be empty", f.Name)
+	}
+	if _, err := regexp.Compile("(?i)" + f.Pattern); err != nil {
+		return fmt.Errorf("fingerprint %q: invalid pattern: %w", f.Name, err)
+	}
+	return nil
+}
+
+// DefaultFingerprints returns the built-in signatures of common AI coding
+// tools.
+func DefaultFingerprints() []Fingerprint {
+	return []Fingerprint{
+		{Name: "Claude Code", Field: FingerprintFieldMessage, Pattern: `generated with \[?claude code`},
+		{Name: "Claude", Field: FingerprintFieldTrailer, Key: "Co-authored-by", Pattern: `<noreply@anthropic\.com>`},
+		{Name: "GitHub Copilot", Field: FingerprintFieldTrailer, Key: "Co-authored-by", Pattern: `copilot@users\.noreply\.github\.com>|copilot(-swe-agent)?\[bot\]`},
+		{Name: "GitHub Copilot", Field: FingerprintFieldAuthor, Pattern: `copilot(-swe-agent)?\[bot\]`},
+		{Name: "GitHub Copilot", Field: FingerprintFieldCommitter, Pattern: `copilot(-swe-agent)?\[bot\]`},
+		{Name: "Aider", Field: FingerprintFieldAuthor, Pattern: `\(aider\) <`},
+		{Name: "Aider", Field: FingerprintFieldTrailer, Key: "Co-authored-by", Pattern: `<noreply@aider\.chat>`},
+		{Name: "Aider", Field: FingerprintFieldMessage, Pattern: `^aider: `},
+		{Name: "Cursor", Field: FingerprintFieldTrailer, Key: "Co-authored-by", Pattern: `@cursor\.(com|sh)>`},
+		{Name: "Devin", Field: FingerprintFieldAuthor, Pattern: `devin-ai-integration\[bot\]`},
+		{Name: "Devin", Field: FingerprintFieldTrailer, Key: "Co-authored-by", Pattern: `devin-ai-integration`},
+		{Name: "OpenAI Codex", Field: FingerprintFieldTrailer, Key: "Co-authored-by", Pattern: `codex[\w-]*\[bot\]`},
+		{Name: "Gemini", Field: FingerprintFieldTrailer, Key: "Co-authored-by", Pattern: `gemini[\w-]*\[bot\]`},
+		{Name: "AI attribution trailer", Field: FingerprintFieldTrailer, Key: "Generated-by", Pattern: `.`},
+		{Name: "AI attribution trailer", Field: FingerprintFieldTrailer, Key: "Assisted-by", Pattern: `.`},
+		{Name: "AI attribution footer", Field: FingerprintFieldMessage, Pattern: `^\W*generated (with|by|using) .*\b(ai|chatgpt|gpt-?\d|claude|copilot|gemini|codex|cursor|llm)\b`},
+	}
+}
+
+type compiledFingerprint struct {
+	Fingerprint
+	re *regexp.Regexp
+}
+
+// FingerprintStrategy flags commits whose trailers, message or identities
+// match a known AI tool signature.
+type FingerprintStrategy struct {
+	fingerprints []compiledFingerprint
+}
+
+// NewFingerprintStrategy compiles fingerprints. Invalid entries are
+// skipped; Fingerprint.Validate reports them up front.
+func NewFingerprintStrategy(fingerprints []Fingerprint) *FingerprintStrategy {
+	compiled := make([]compiledFingerprint, 0, len(fingerprints))
+	for _, f := range fingerprints {
+		if f.Validate() != nil {
+			continue
+		}
+		compiled = append(compiled, compiledFingerprint{
+			Fingerprint: f,
+			re:          regexp.MustCompile("(?i)" + f.Pattern),
+		})
+	}
+	return &FingerprintStrategy{fingerprints: compiled}
+}
+
+func (s *FingerprintStrategy) Name() string {
+	return "ai_tool_fingerprint"
+}
+
+func (s *FingerprintStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding {
+	commit := pair.Current
+	if commit == nil {
+		return nil
+	}
+
+	evidence := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, f := range s.fingerprints {
+		if seen[f.Name] {
+			continue
+		}
+		if match := f.match(commit); match != "" {
+			seen[f.Name] = true
+			evidence = append(evidence, fmt.Sprintf("%s (%s)", f.Name, match))
+		}
+	}
+
+	if len(evidence) == 0 {
+		return nil
+	}
+
+	// A tool naming itself in the commit is direct evidence, not a heuristic.
+	return &Finding{
+		Category:   CategoryProvenance,
+		Confidence: 1,
+		Observed:   float64(len(evidence)),
+		Message: fmt.Sprintf(
+			"Explicit AI tool fingerprint: %s - the commit identifies the tool that produced it",
+			strings.Join(evidence, "; "),
+		),
+	}
+}
+
+// match returns a description of where the fingerprint matched, or "".
+func (f compiledFingerprint) match(commit *git.Commit) string {
+	switch f.Field {
+	case FingerprintFieldTrailer:
+		for _, t := range commit.Trailers {
+			if f.Key != "" && !strings.EqualFold(t.Key, f.Key) {
+				continue
+			}
+			if f.re.MatchString(t.Value) {
+				return fmt.Sprintf("%s trailer: %s", t.Key, t.Value)
+			}
+		}
+
+	case FingerprintFieldMessage:
+		for _, line := range strings.Split(commit.Message, "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" && f.re.MatchString(line) {
+				return fmt.Sprintf("message: %q", line)
+			}
+		}
+
+	case FingerprintFieldAuthor:
+		author, email := rawAuthor(commit)
+		identity := formatIdentity(author, email)
+		if f.re.MatchString(identity) {
+			return "author: " + identity
+		}
+
+	case FingerprintFieldCommitter:
+		author, email := rawAuthor(commit)
+		committer, committerEmail := rawCommitter(commit)
+		if committerEmail == "" || (strings.EqualFold(committerEmail, email) && committer == author) {
+			return ""
+		}
+		identity := formatIdentity(committer, committerEmail)
+		if f.re.MatchString(identity) {
+			return fmt.Sprintf("committed by %s for author %s", identity, formatIdentity(author, email))
+		}
+	}
+
+	return ""
+}
+
+// rawAuthor returns the author as recorded in the commit, so that a
+// .mailmap entry mapping a bot to a person does not hide it. Commits
+// without a raw identity fall back to the resolved one.
+func rawAuthor(commit *git.Commit) (string, string) {
+	if commit.RawEmail == "" && commit.RawAuthor == "" {
+		return commit.Author, commit.Email
+	}
+	return commit.RawAuthor, commit.RawEmail
+}
+
+// rawCommitter is rawAuthor for the committer.
+func rawCommitter(commit *git.Commit) (string, string) {
+	if commit.RawCommitterEmail == "" && commit.RawCommitter == "" {
+		return commit.Committer, commit.CommitterEmail
+	}
+	return commit.RawCommitter, commit.RawCommitterEmail
+}
+
+func formatIdentity(name, email string) string {
+	return fmt.Sprintf("%s <%s>", name, email)
+}
@@ -0,0 +1,191 @@
+package patterns
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/git"
+)
+
+func TestFingerprintStrategy(t *testing.T) {
+	strategy := NewFingerprintStrategy(append(DefaultFingerprints(),
+		Fingerprint{Name: "Codegen", Field: FingerprintFieldTrailer, Key: "X-Generator", Pattern: `^codegen`},
+	))
+
+	tests := []struct {
+		name         string
+		commit       *git.Commit
+		shouldDetect bool
+		wantEvidence string
+	}{
+		{
+			name: "claude co-author trailer",
+			commit: &git.Commit{
+				Author: "Jane", Email: "jane@example.com",
+				Trailers: []git.Trailer{{Key: "Co-Authored-By", Value: "Claude <noreply@anthropic.com>"}},
+			},
+			shouldDetect: true,
+			wantEvidence: "Claude (Co-Authored-By trailer",
+		},
+		{
+			name: "generated with footer",
+			commit: &git.Commit{
+				Author: "Jane", Email: "jane@example.com",
+				Message: "Add feature\n\n🤖 Generated with [Claude Code](https://claude.com/claude-code)\n",
+			},
+			shouldDetect: true,
+			wantEvidence: "Claude Code (message:",
+		},
+		{
+			name: "copilot agent author",
+			commit: &git.Commit{
+				Author: "Copilot", Email: "198982749+Copilot@users.noreply.github.com",
+				Committer: "copilot-swe-agent[bot]", CommitterEmail: "198982749+copilot-swe-agent[bot]@users.noreply.github.com",
+			},
+			shouldDetect: true,
+			wantEvidence: "GitHub Copilot (committed by copilot-swe-agent[bot]",
+		},
+		{
+			name: "copilot co-author trailer",
+			commit: &git.Commit{
+				Author: "Jane", Email: "jane@example.com",
+				Trailers: []git.Trailer{{Key: "Co-authored-by", Value: "Copilot <175728472+Copilot@users.noreply.github.com>"}},
+			},
+			shouldDetect: true,
+			wantEvidence: "GitHub Copilot (Co-authored-by trailer",
+		},
+		{
+			name: "codex co-author trailer",
+			commit: &git.Commit{
+				Author: "Jane", Email: "jane@example.com",
+				Trailers: []git.Trailer{{Key: "Co-authored-by", Value: "chatgpt-codex-connector[bot] <199175422+chatgpt-codex-connector[bot]@users.noreply.github.com>"}},
+			},
+			shouldDetect: true,
+			wantEvidence: "OpenAI Codex (Co-authored-by trailer",
+		},
+		{
+			name: "gemini co-author trailer",
+			commit: &git.Commit{
+				Author: "Jane", Email: "jane@example.com",
+				Trailers: []git.Trailer{{Key: "Co-authored-by", Value: "gemini-code-assist[bot] <176961590+gemini-code-assist[bot]@users.noreply.github.com>"}},
+			},
+			shouldDetect: true,
+			wantEvidence: "Gemini (Co-authored-by trailer",
+		},
+		{
+			name: "copilot agent mapped to a person",
+			commit: &git.Commit{
+				Author: "Dev", Email: "dev@example.com",
+				Committer: "Dev", CommitterEmail: "dev@example.com",
+				RawAuthor: "Copilot", RawEmail: "198982749+Copilot@users.noreply.github.com",
+				RawCommitter: "copilot-swe-agent[bot]", RawCommitterEmail: "198982749+copilot-swe-agent[bot]@users.noreply.github.com",
+			},
+			shouldDetect: true,
+			wantEvidence: "GitHub Copilot (committed by copilot-swe-agent[bot]",
+		},
+		{
+			name: "aider author suffix",
+			commit: &git.Commit{
+				Author: "Jane (aider)", Email: "jane@example.com",
+			},
+			shouldDetect: true,
+			wantEvidence: "Aider (author:",
+		},
+		{
+			name: "custom fingerprint",
+			commit: &git.Commit{
+				Author: "Jane", Email: "jane@example.com",
+				Trailers: []git.Trailer{{Key: "X-Generator", Value: "codegen v2"}},
+			},
+			shouldDetect: true,
+			wantEvidence: "Codegen (X-Generator trailer: codegen v2)",
+		},
+		{
+			name: "human co-author",
+			commit: &git.Commit{
+				Author: "Jane", Email: "jane@example.com",
+				Trailers: []git.Trailer{{Key: "Co-authored-by", Value: "Bob <bob@example.com>"}},
+			},
+			shouldDetect: false,
+		},
+		{
+			name: "human co-authors named after tools",
+			commit: &git.Commit{
+				Author: "Jane", Email: "jane@example.com",
+				Trailers: []git.Trailer{
+					{Key: "Co-authored-by", Value: "Sam Copilot <sam.copilot@example.com>"},
+					{Key: "Co-authored-by", Value: "Codex Team <codex@example.com>"},
+					{Key: "Co-authored-by", Value: "Gemini Lee <gemini.lee@example.com>"},
+				},
+			},
+			shouldDetect: false,
+		},
+		{
+			name: "different human committer",
+			commit: &git.Commit{
+				Author: "Jane", Email: "jane@example.com",
+				Committer: "GitHub", CommitterEmail: "noreply@github.com",
+			},
+			shouldDetect: false,
+		},
+		{
+			name: "trailer key mismatch",
+			commit: &git.Commit{
+				Author: "Jane", Email: "jane@example.com",
+				Trailers: []git.Trailer{{Key: "Reviewed-by", Value: "Claude <noreply@anthropic.com>"}},
+			},
+			shouldDetect: false,
+		},
+		{
+			name: "message mentioning a tool is not a footer",
+			commit: &git.Commit{
+				Author: "Jane", Email: "jane@example.com",
+				Message: "Document how to configure Copilot in the editor",
+			},
+			shouldDetect: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			finding := strategy.Detect(&git.CommitPair{Current: tt.commit}, nil)
+			detected := finding != nil
+			if detected != tt.shouldDetect {
+				t.Fatalf("Detect() = %+v, want detected=%v", finding, tt.shouldDetect)
+			}
+			if !detected {
+				return
+			}
+			reason := finding.Message
+			if tt.wantEvidence != "" && !strings.Contains(reason, tt.wantEvidence) {
+				t.Errorf("reason = %q, want it to contain %q", reason, tt.wantEvidence)
+			}
+			if !strings.HasPrefix(reason, "Explicit AI tool fingerprint:") {
+				t.Errorf("reason = %q, want explicit label", reason)
+			}
+			if finding.Category != CategoryProvenance || finding.Confidence != 1 {
+				t.Errorf("Category, Confidence = %q, %v, want %q, 1", finding.Category, finding.Confidence, CategoryProvenance)
+			}
+		})
+	}
+}
+
+func TestFingerprint_Validate(t *testing.T) {
+	for _, f := range DefaultFingerprints() {
+		if err := f.Validate(); err != nil {
+			t.Errorf("default fingerprint %q invalid: %v", f.Name, err)
+		}
+	}
+
+	invalid := []Fingerprint{
+		{Field: FingerprintFieldMessage, Pattern: "x"},
+		{Name: "a", Field: "body", Pattern: "x"},
+		{Name: "a", Field: FingerprintFieldMessage},
+		{Name: "a", Field: FingerprintFieldMessage, Pattern: "[z-a]"},
+	}
+	for _, f := range invalid {
+		if err := f.Validate(); err == nil {
+			t.Errorf("Validate(%+v) expected error", f)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package patterns
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TryCadence/Cadence/internal/git"
+	"github.com/TryCadence/Cadence/internal/metrics"
+)
+
+// GoSourceStrategy looks for traits of generated Go code in the lines a
+// commit touched, measured from the parsed post-image of its .go files
+// rather than the raw diff: heavy commenting, every error wrapped the same
+// way, functions of near-identical length and long descriptive names.
+type GoSourceStrategy struct {
+	enabled            bool
+	minCodeLines       float64
+	maxCommentRatio    float64
+	minErrorChecks     float64
+	minWrappedShare    float64
+	minFunctions       float64
+	maxLengthVariation float64
+	minIdentifiers     float64
+	minMeanNameLength  float64
+	minIndicators      float64
+}
+
+func NewGoSourceStrategy() *GoSourceStrategy {
+	return &GoSourceStrategy{
+		enabled:            true,
+		minCodeLines:       30,
+		maxCommentRatio:    0.5,
+		minErrorChecks:     5,
+		minWrappedShare:    0.9,
+		minFunctions:       4,
+		maxLengthVariation: 0.2,
+		minIdentifiers:     20,
+		minMeanNameLength:  9,
+		minIndicators:      2,
+	}
+}
+
+func (s *GoSourceStrategy) Name() string {
+	return "go_source_analysis"
+}
+
+func (s *GoSourceStrategy) params() []param {
+	return []param{
+		{name: "min_code_lines", value: &s.minCodeLines},
+		{name: "max_comment_ratio", value: &s.maxCommentRatio},
+		{name: "min_error_checks", value: &s.minErrorChecks},
+		{name: "min_wrapped_share", value: &s.minWrappedShare, max: 1},
+		{name: "min_functions", value: &s.minFunctions},
+		{name: "max_length_variation", value: &s.maxLengthVariation},
+		{name: "min_identifiers", value: &s.minIdentifiers},
+		{name: "min_mean_name_length", value: &s.minMeanNameLength},
+		{name: "min_indicators", value: &s.minIndicators},
+	}
+}
+
+func (s *GoSourceStrategy) Params() map[string]float64 { return paramValues(s.params()) }
+
+func (s *GoSourceStrategy) SetParam(name string, value float64) error {
+	return setParam(s.Name(), s.params(), name, value)
+}
+
+func (s *GoSourceStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding {
+	if !s.enabled || pair.Stats == nil || pair.Stats.Go == nil {
+		return nil
+	}
+
+	signals := pair.Stats.Go
+	if float64(signals.CodeLines) < s.minCodeLines {
+		return nil
+	}
+
+	var traits []string
+	if ratio := signals.CommentRatio(); ratio > s.maxCommentRatio {
+		traits = append(traits, fmt.Sprintf("%.2f comment lines per code line", ratio))
+	}
+	if float64(signals.ErrorReturns()) >= s.minErrorChecks && signals.WrappedShare() >= s.minWrappedShare {
+		traits = append(traits, fmt.Sprintf("%d of %d error checks wrapped", signals.WrappedErrorReturns, signals.ErrorReturns()))
+	}
+	if float64(len(signals.FuncLengths)) >= s.minFunctions && signals.FuncLengthVariation() <= s.maxLengthVariation {
+		traits = append(traits, fmt.Sprintf("%d new functions of uniform length (variation %.2f)",
+			len(signals.FuncLengths), signals.FuncLengthVariation()))
+	}
+	if float64(signals.Naming.Identifiers) >= s.minIdentifiers && signals.Naming.MeanLength() >= s.minMeanNameLength {
+		traits = append(traits, fmt.Sprintf("identifiers average %.1f characters", signals.Naming.MeanLength()))
+	}
+
+	if len(traits) < int(s.minIndicators) {
+		return nil
+	}
+
+	finding := &Finding{
+		Category:   CategoryCode,
+		Confidence: indicatorStrength(len(traits), int(s.minIndicators)),
+		Observed:   float64(len(traits)),
+		Threshold:  s.minIndicators,
+		Message: fmt.Sprintf(
+			"Go code in changed lines shows %d traits typical of generated code: %s",
+			len(traits), strings.Join(traits, ", "),
+		),
+	}
+
+	// The signals are totals over the parsed files, so the finding points
+	// at every hunk adding Go code.
+	var goLines []textLine
+	forEachAddedLine(pair.DiffContent, func(file string, line int, text string) {
+		if strings.HasSuffix(file, ".go") {
+			goLines = append(goLines, textLine{file: file, line: line, text: text})
+		}
+	})
+	locate(finding, goLines)
+	return finding
+}
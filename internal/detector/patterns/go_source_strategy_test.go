@@ -0,0 +1,127 @@
+package patterns
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/git"
+	"github.com/TryCadence/Cadence/internal/gosource"
+)
+
+func TestGoSourceStrategy(t *testing.T) {
+	generated := gosource.Signals{
+		Files:               2,
+		CodeLines:           120,
+		CommentLines:        80,
+		WrappedErrorReturns: 8,
+		FuncLengths:         []int{12, 12, 13, 12},
+		Naming:              gosource.Naming{Identifiers: 30, TotalLength: 330},
+	}
+
+	tests := []struct {
+		name       string
+		signals    *gosource.Signals
+		wantTraits int
+	}{
+		{
+			name:    "no Go code",
+			signals: nil,
+		},
+		{
+			name:       "every trait",
+			signals:    &generated,
+			wantTraits: 4,
+		},
+		{
+			name: "mixed error handling and uneven functions",
+			signals: &gosource.Signals{
+				CodeLines:           120,
+				CommentLines:        80,
+				BareErrorReturns:    5,
+				WrappedErrorReturns: 3,
+				FuncLengths:         []int{4, 30, 12, 60},
+				Naming:              gosource.Naming{Identifiers: 30, TotalLength: 330},
+			},
+			wantTraits: 2,
+		},
+		{
+			name: "idiomatic code",
+			signals: &gosource.Signals{
+				CodeLines:           120,
+				CommentLines:        10,
+				BareErrorReturns:    6,
+				WrappedErrorReturns: 2,
+				FuncLengths:         []int{4, 30, 12, 60},
+				Naming:              gosource.Naming{Identifiers: 30, TotalLength: 150},
+			},
+		},
+		{
+			name: "too little code",
+			signals: &gosource.Signals{
+				CodeLines:    20,
+				CommentLines: 20,
+				Naming:       gosource.Naming{Identifiers: 30, TotalLength: 330},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pair := &git.CommitPair{
+				Current: &git.Commit{Hash: "abc"},
+				Stats:   &git.DiffStats{Additions: 200, Go: tt.signals},
+			}
+			finding := NewGoSourceStrategy().Detect(pair, nil)
+			if tt.wantTraits == 0 {
+				if finding != nil {
+					t.Errorf("Detect() = %q, want nil", finding.Message)
+				}
+				return
+			}
+			if finding == nil {
+				t.Fatalf("Detect() = nil, want %d traits", tt.wantTraits)
+			}
+			if int(finding.Observed) != tt.wantTraits {
+				t.Errorf("Observed = %v, want %d (%s)", finding.Observed, tt.wantTraits, finding.Message)
+			}
+		})
+	}
+}
+
+func TestAddedCodeLines_SkipsParsedGo(t *testing.T) {
+	diff := strings.Join([]string{
+		"diff --git a/main.go b/main.go",
+		"--- a/main.go",
+		"+++ b/main.go",
+		"@@ -0,0 +1 @@",
+		"+func main() {}",
+		"diff --git a/app.js b/app.js",
+		"--- a/app.js",
+		"+++ b/app.js",
+		"@@ -1 +1 @@",
+		"+const x = 1;",
+		"-const y = 2;",
+	}, "\n")
+
+	tests := []struct {
+		name    string
+		signals *gosource.Signals
+		skipGo  bool
+		want    []string
+	}{
+		{"without Go signals", nil, true, []string{"func main() {}", "const x = 1;"}},
+		{"with parsed Go files", &gosource.Signals{Files: 1}, true, []string{"const x = 1;"}},
+		{"when no Go file parsed", &gosource.Signals{ParseErrors: 1}, true, []string{"func main() {}", "const x = 1;"}},
+		{"when go_source_analysis is off", &gosource.Signals{Files: 1}, false, []string{"func main() {}", "const x = 1;"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pair := &git.CommitPair{DiffContent: diff, Stats: &git.DiffStats{Go: tt.signals}}
+			got := texts(addedCodeLines(pair, tt.skipGo))
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("addedCodeLines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
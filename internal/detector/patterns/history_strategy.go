@@ -0,0 +1,247 @@
+package patterns
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/git"
+	"github.com/TryCadence/Cadence/internal/metrics"
+)
+
+// UniformBurstStrategy flags windows of time in which many commits landed
+// with near-identical size, the shape scripted or generated commits leave.
+type UniformBurstStrategy struct {
+	windowMinutes    float64
+	minCommits       float64
+	maxSizeVariation float64
+}
+
+func NewUniformBurstStrategy() *UniformBurstStrategy {
+	return &UniformBurstStrategy{
+		windowMinutes:    10,
+		minCommits:       10,
+		maxSizeVariation: 0.25,
+	}
+}
+
+func (s *UniformBurstStrategy) Name() string {
+	return "uniform_commit_burst"
+}
+
+func (s *UniformBurstStrategy) params() []param {
+	return []param{
+		{name: "window_minutes", value: &s.windowMinutes},
+		{name: "min_commits", value: &s.minCommits},
+		{name: "max_size_variation", value: &s.maxSizeVariation, max: 1},
+	}
+}
+
+func (s *UniformBurstStrategy) Params() map[string]float64 { return paramValues(s.params()) }
+
+func (s *UniformBurstStrategy) SetParam(name string, value float64) error {
+	return setParam(s.Name(), s.params(), name, value)
+}
+
+func (s *UniformBurstStrategy) DetectRepository(pairs []*git.CommitPair, repoStats *metrics.RepositoryStats) []Finding {
+	findings := make([]Finding, 0)
+	minCommits := int(s.minCommits)
+	if minCommits < 2 || len(pairs) < minCommits {
+		return findings
+	}
+	window := time.Duration(s.windowMinutes * float64(time.Minute))
+
+	for start := 0; start < len(pairs); {
+		end := start
+		for end < len(pairs) && pairs[end].Current.Timestamp.Sub(pairs[start].Current.Timestamp) <= window {
+			end++
+		}
+
+		burst := pairs[start:end]
+		if len(burst) < minCommits {
+			start++
+			continue
+		}
+		mean, variation := sizeVariation(burst)
+		if variation > s.maxSizeVariation {
+			start++
+			continue
+		}
+
+		first, last := burst[0].Current, burst[len(burst)-1].Current
+		findings = append(findings, Finding{
+			Scope:      ScopeWindow,
+			Author:     soleAuthor(burst),
+			Start:      first.Timestamp,
+			End:        last.Timestamp,
+			Commits:    commitHashes(burst),
+			Category:   CategoryTiming,
+			Confidence: indicatorStrength(len(burst), minCommits),
+			Observed:   float64(len(burst)),
+			Threshold:  s.minCommits,
+			Message: fmt.Sprintf("%d commits within %s, all about %.0f lines changed (variation %.0f%%)",
+				len(burst), formatGap(last.Timestamp.Sub(first.Timestamp)), mean, variation*100),
+		})
+		start = end
+	}
+	return findings
+}
+
+// sizeVariation returns the mean lines changed per pair and the coefficient
+// of variation around it.
+func sizeVariation(pairs []*git.CommitPair) (mean, variation float64) {
+	sizes := make([]float64, len(pairs))
+	for i, pair := range pairs {
+		if pair.Stats != nil {
+			sizes[i] = float64(pair.Stats.Additions + pair.Stats.Deletions)
+		}
+		mean += sizes[i]
+	}
+	mean /= float64(len(sizes))
+	if mean == 0 {
+		return 0, math.Inf(1)
+	}
+
+	var sumSqDev float64
+	for _, size := range sizes {
+		sumSqDev += (size - mean) * (size - mean)
+	}
+	return mean, math.Sqrt(sumSqDev/float64(len(sizes))) / mean
+}
+
+func soleAuthor(pairs []*git.CommitPair) string {
+	author := pairs[0].Current.Email
+	for _, pair := range pairs[1:] {
+		if pair.Current.Email != author {
+			return ""
+		}
+	}
+	return author
+}
+
+func commitHashes(pairs []*git.CommitPair) []string {
+	hashes := make([]string, len(pairs))
+	for i, pair := range pairs {
+		hashes[i] = pair.Current.Hash
+	}
+	return hashes
+}
+
+// CadenceShiftStrategy flags authors whose typical gap between commits
+// changed abruptly, such as a contributor who committed every few days and
+// then every few minutes. It splits each author's history at the point
+// where the typical gap before and after differ the most.
+type CadenceShiftStrategy struct {
+	minCommits float64
+	minSegment float64
+	minRatio   float64
+}
+
+func NewCadenceShiftStrategy() *CadenceShiftStrategy {
+	return &CadenceShiftStrategy{
+		minCommits: 12,
+		minSegment: 5,
+		minRatio:   10,
+	}
+}
+
+func (s *CadenceShiftStrategy) Name() string {
+	return "cadence_shift"
+}
+
+func (s *CadenceShiftStrategy) params() []param {
+	return []param{
+		{name: "min_commits", value: &s.minCommits},
+		{name: "min_segment", value: &s.minSegment},
+		{name: "min_ratio", value: &s.minRatio},
+	}
+}
+
+func (s *CadenceShiftStrategy) Params() map[string]float64 { return paramValues(s.params()) }
+
+func (s *CadenceShiftStrategy) SetParam(name string, value float64) error {
+	return setParam(s.Name(), s.params(), name, value)
+}
+
+func (s *CadenceShiftStrategy) DetectRepository(pairs []*git.CommitPair, repoStats *metrics.RepositoryStats) []Finding {
+	findings := make([]Finding, 0)
+
+	byAuthor := make(map[string][]*git.Commit)
+	authors := make([]string, 0)
+	for _, pair := range pairs {
+		email := pair.Current.Email
+		if _, ok := byAuthor[email]; !ok {
+			authors = append(authors, email)
+		}
+		byAuthor[email] = append(byAuthor[email], pair.Current)
+	}
+	sort.Strings(authors)
+
+	segment := int(s.minSegment)
+	if segment < 1 {
+		segment = 1
+	}
+	for _, email := range authors {
+		commits := byAuthor[email]
+		if len(commits) < int(s.minCommits) || len(commits) < 2*segment+1 {
+			continue
+		}
+
+		gaps := make([]time.Duration, len(commits)-1)
+		for i := 1; i < len(commits); i++ {
+			gaps[i-1] = commits[i].Timestamp.Sub(commits[i-1].Timestamp)
+			if gaps[i-1] < time.Minute {
+				gaps[i-1] = time.Minute
+			}
+		}
+
+		bestSplit, bestRatio := 0, 0.0
+		var before, after time.Duration
+		for split := segment; split <= len(gaps)-segment; split++ {
+			b, a := typicalGap(gaps[:split]), typicalGap(gaps[split:])
+			ratio := math.Max(float64(b)/float64(a), float64(a)/float64(b))
+			if ratio > bestRatio {
+				bestSplit, bestRatio, before, after = split, ratio, b, a
+			}
+		}
+		if bestRatio < s.minRatio {
+			continue
+		}
+
+		changed := commits[bestSplit]
+		findings = append(findings, Finding{
+			Scope:      ScopeAuthor,
+			Author:     email,
+			Start:      changed.Timestamp,
+			Category:   CategoryTiming,
+			Confidence: overThreshold(bestRatio, s.minRatio),
+			Observed:   bestRatio,
+			Threshold:  s.minRatio,
+			Message: fmt.Sprintf("Commit cadence changed abruptly around %s: typical gap %s before, %s after",
+				changed.Timestamp.Format("2006-01-02"), formatGap(before), formatGap(after)),
+		})
+	}
+	return findings
+}
+
+// typicalGap is the geometric mean of gaps, which a few long pauses do not
+// dominate the way they would an arithmetic mean.
+func typicalGap(gaps []time.Duration) time.Duration {
+	var sumLog float64
+	for _, gap := range gaps {
+		sumLog += math.Log(float64(gap))
+	}
+	return time.Duration(math.Exp(sumLog / float64(len(gaps))))
+}
+
+func formatGap(d time.Duration) string {
+	switch {
+	case d >= 48*time.Hour:
+		return fmt.Sprintf("%.1f days", d.Hours()/24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%.1f hours", d.Hours())
+	default:
+		return fmt.Sprintf("%.0f minutes", d.Minutes())
+	}
+}
@@ -0,0 +1,114 @@
+package patterns
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/git"
+)
+
+func timedPair(hash, email string, at time.Time, lines int64) *git.CommitPair {
+	return &git.CommitPair{
+		Previous: &git.Commit{Hash: hash + "-parent"},
+		Current:  &git.Commit{Hash: hash, Email: email, Timestamp: at},
+		Stats:    &git.DiffStats{Additions: lines, FilesChanged: 1},
+	}
+}
+
+func TestUniformBurstStrategy(t *testing.T) {
+	start := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+
+	burst := func(count int, gap time.Duration, size func(i int) int64) []*git.CommitPair {
+		pairs := make([]*git.CommitPair, 0, count)
+		for i := 0; i < count; i++ {
+			pairs = append(pairs, timedPair(fmt.Sprintf("c%d", i), "bot@example.com", start.Add(time.Duration(i)*gap), size(i)))
+		}
+		return pairs
+	}
+
+	tests := []struct {
+		name    string
+		pairs   []*git.CommitPair
+		commits int
+	}{
+		{"twelve equal commits in six minutes", burst(12, 30*time.Second, func(int) int64 { return 40 }), 12},
+		{"equal commits spread over hours", burst(12, time.Hour, func(int) int64 { return 40 }), 0},
+		{"rapid commits of varied size", burst(12, 30*time.Second, func(i int) int64 { return int64(10 + 50*(i%3)) }), 0},
+		{"too few commits", burst(5, 30*time.Second, func(int) int64 { return 40 }), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := NewUniformBurstStrategy().DetectRepository(tt.pairs, nil)
+			if tt.commits == 0 {
+				if len(findings) != 0 {
+					t.Fatalf("DetectRepository() = %v, want none", findings)
+				}
+				return
+			}
+			if len(findings) != 1 {
+				t.Fatalf("len(findings) = %d, want 1", len(findings))
+			}
+			f := findings[0]
+			if f.Scope != ScopeWindow || len(f.Commits) != tt.commits || f.Author != "bot@example.com" {
+				t.Errorf("Scope, Commits, Author = %q, %d, %q", f.Scope, len(f.Commits), f.Author)
+			}
+			if !f.Start.Equal(start) || !f.End.Equal(start.Add(time.Duration(tt.commits-1)*30*time.Second)) {
+				t.Errorf("Start, End = %v, %v", f.Start, f.End)
+			}
+		})
+	}
+}
+
+func TestCadenceShiftStrategy(t *testing.T) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	changeAt := start.Add(8 * 72 * time.Hour)
+
+	history := func(email string, shift bool) []*git.CommitPair {
+		pairs := make([]*git.CommitPair, 0, 16)
+		at := start
+		for i := 0; i < 16; i++ {
+			pairs = append(pairs, timedPair(fmt.Sprintf("%s-%d", email, i), email, at, 30))
+			if shift && i >= 8 {
+				at = at.Add(10 * time.Minute)
+			} else {
+				at = at.Add(72 * time.Hour)
+			}
+		}
+		return pairs
+	}
+
+	t.Run("abrupt change", func(t *testing.T) {
+		findings := NewCadenceShiftStrategy().DetectRepository(history("dev@example.com", true), nil)
+		if len(findings) != 1 {
+			t.Fatalf("len(findings) = %d, want 1", len(findings))
+		}
+		f := findings[0]
+		if f.Scope != ScopeAuthor || f.Author != "dev@example.com" {
+			t.Errorf("Scope, Author = %q, %q", f.Scope, f.Author)
+		}
+		if !f.Start.Equal(changeAt) {
+			t.Errorf("Start = %v, want %v", f.Start, changeAt)
+		}
+		if f.Observed < 10 || f.Confidence <= 0.5 {
+			t.Errorf("Observed, Confidence = %v, %v", f.Observed, f.Confidence)
+		}
+	})
+
+	t.Run("steady cadence", func(t *testing.T) {
+		if findings := NewCadenceShiftStrategy().DetectRepository(history("dev@example.com", false), nil); len(findings) != 0 {
+			t.Errorf("DetectRepository() = %v, want none", findings)
+		}
+	})
+
+	t.Run("too few commits", func(t *testing.T) {
+		s := NewCadenceShiftStrategy()
+		if err := s.SetParam("min_commits", 20); err != nil {
+			t.Fatalf("SetParam() error = %v", err)
+		}
+		if findings := s.DetectRepository(history("dev@example.com", true), nil); len(findings) != 0 {
+			t.Errorf("DetectRepository() = %v, want none", findings)
+		}
+	})
+}
@@ -0,0 +1,140 @@
+package patterns
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/TryCadence/Cadence/internal/git"
+	"github.com/TryCadence/Cadence/internal/langmodel"
+	"github.com/TryCadence/Cadence/internal/metrics"
+)
+
+// LanguageModelStrategy scores a commit's added code and its message with
+// an n-gram model trained on the repository's own history before a cutoff.
+// Generated text tends to be unusually predictable (low perplexity) and
+// evenly so from line to line (low burstiness); the strategy fires when
+// either falls max_z standard deviations below what held-out commits of the
+// training history scored. Commits from before the cutoff are skipped since
+// the model has already seen them.
+type LanguageModelStrategy struct {
+	enabled            bool
+	model              *langmodel.Model
+	minCodeTokens      float64
+	minMessageWords    float64
+	minLines           float64
+	maxZ               float64
+	minBaselineCommits float64
+}
+
+func NewLanguageModelStrategy(model *langmodel.Model) *LanguageModelStrategy {
+	return &LanguageModelStrategy{
+		enabled:            true,
+		model:              model,
+		minCodeTokens:      100,
+		minMessageWords:    8,
+		minLines:           5,
+		maxZ:               2,
+		minBaselineCommits: 10,
+	}
+}
+
+func (s *LanguageModelStrategy) Name() string {
+	return "language_model_analysis"
+}
+
+func (s *LanguageModelStrategy) params() []param {
+	return []param{
+		{name: "min_code_tokens", value: &s.minCodeTokens},
+		{name: "min_message_words", value: &s.minMessageWords},
+		{name: "min_lines", value: &s.minLines},
+		{name: "max_z", value: &s.maxZ},
+		{name: "min_baseline_commits", value: &s.minBaselineCommits},
+	}
+}
+
+func (s *LanguageModelStrategy) Params() map[string]float64 { return paramValues(s.params()) }
+
+func (s *LanguageModelStrategy) SetParam(name string, value float64) error {
+	return setParam(s.Name(), s.params(), name, value)
+}
+
+// lmSignal is one score that fell below its baseline.
+type lmSignal struct {
+	z           float64
+	description string
+}
+
+func (s *LanguageModelStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding {
+	if !s.enabled || s.model == nil || pair.Current == nil {
+		return nil
+	}
+	if pair.Current.Timestamp.Before(s.model.Cutoff) {
+		return nil
+	}
+
+	var code, message []lmSignal
+	codeScore := s.model.ScoreCode(langmodel.AddedLines(pair.DiffContent))
+	if float64(codeScore.Tokens) >= s.minCodeTokens {
+		code = s.signals(codeScore, s.model.Code.Baseline, "added code")
+	}
+	textScore := s.model.ScoreText(pair.Current.Message)
+	if float64(textScore.Tokens) >= s.minMessageWords {
+		message = s.signals(textScore, s.model.Text.Baseline, "commit message")
+	}
+	if len(code) == 0 && len(message) == 0 {
+		return nil
+	}
+
+	category := CategoryCode
+	if len(code) == 0 {
+		category = CategoryMessage
+	}
+	fired := append(code, message...)
+	strongest := fired[0]
+	descriptions := make([]string, 0, len(fired))
+	for _, signal := range fired {
+		if signal.z < strongest.z {
+			strongest = signal
+		}
+		descriptions = append(descriptions, signal.description)
+	}
+
+	return &Finding{
+		Category:   category,
+		Confidence: overThreshold(-strongest.z, s.maxZ),
+		Observed:   strongest.z,
+		Threshold:  -s.maxZ,
+		Message: fmt.Sprintf(
+			"Text is more predictable than this repository's history: %s",
+			strings.Join(descriptions, ", "),
+		),
+	}
+}
+
+// signals compares a score with the held-out baseline of the same model.
+// Burstiness needs enough lines to vary across.
+func (s *LanguageModelStrategy) signals(score langmodel.Score, baseline langmodel.Baseline, what string) []lmSignal {
+	if float64(baseline.Commits) < s.minBaselineCommits {
+		return nil
+	}
+
+	var found []lmSignal
+	if baseline.StdLogPerplexity > 0 {
+		z := (math.Log(score.Perplexity) - baseline.MeanLogPerplexity) / baseline.StdLogPerplexity
+		if z <= -s.maxZ {
+			found = append(found, lmSignal{z: z, description: fmt.Sprintf(
+				"%s perplexity %.1f (z %.1f, typical %.1f)",
+				what, score.Perplexity, z, math.Exp(baseline.MeanLogPerplexity))})
+		}
+	}
+	if baseline.StdBurstiness > 0 && float64(score.Sequences) >= s.minLines {
+		z := (score.Burstiness - baseline.MeanBurstiness) / baseline.StdBurstiness
+		if z <= -s.maxZ {
+			found = append(found, lmSignal{z: z, description: fmt.Sprintf(
+				"%s burstiness %.2f (z %.1f, typical %.2f)",
+				what, score.Burstiness, z, baseline.MeanBurstiness)})
+		}
+	}
+	return found
+}
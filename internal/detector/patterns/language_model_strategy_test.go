@@ -0,0 +1,136 @@
+package patterns
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/git"
+	"github.com/TryCadence/Cadence/internal/langmodel"
+)
+
+func TestLanguageModelStrategy(t *testing.T) {
+	cutoff := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	var docs []langmodel.Document
+	var code []string
+	for i := 0; i < 20; i++ {
+		lines := []string{
+			fmt.Sprintf("func load%d(path string) error {", i),
+			"\tdata, err := os.ReadFile(path)",
+			"\tif err != nil {",
+			"\t\treturn fmt.Errorf(\"failed to read: %w\", err)",
+			"\t}",
+			"\treturn parse(data)",
+			"}",
+		}
+		docs = append(docs, langmodel.Document{Code: lines, Message: "Load the configuration file from the given path on startup"})
+		if i < 3 {
+			code = append(code, lines...)
+		}
+	}
+	model := langmodel.Train(docs, cutoff)
+	diff := "+" + strings.Join(code, "\n+")
+	message := "Load the configuration file from the given path on startup"
+
+	// Place each baseline so the test commit lands exactly codeZ or textZ
+	// standard deviations from it, whatever the probabilities come out as.
+	codeScore := model.ScoreCode(langmodel.AddedLines(diff))
+	textScore := model.ScoreText(message)
+	baseline := func(score langmodel.Score, z float64) langmodel.Baseline {
+		return langmodel.Baseline{
+			Commits:           20,
+			MeanLogPerplexity: math.Log(score.Perplexity) - z,
+			StdLogPerplexity:  1,
+		}
+	}
+
+	tests := []struct {
+		name         string
+		model        *langmodel.Model
+		codeZ, textZ float64
+		when         time.Time
+		diff         string
+		wantCategory string
+	}{
+		{
+			name:  "no model",
+			codeZ: -3,
+			when:  cutoff.AddDate(0, 1, 0),
+			diff:  diff,
+		},
+		{
+			name:         "predictable code",
+			model:        model,
+			codeZ:        -3,
+			textZ:        -3,
+			when:         cutoff.AddDate(0, 1, 0),
+			diff:         diff,
+			wantCategory: CategoryCode,
+		},
+		{
+			name:         "predictable message only",
+			model:        model,
+			codeZ:        1,
+			textZ:        -3,
+			when:         cutoff.AddDate(0, 1, 0),
+			diff:         diff,
+			wantCategory: CategoryMessage,
+		},
+		{
+			name:  "typical commit",
+			model: model,
+			codeZ: -1,
+			textZ: 1,
+			when:  cutoff.AddDate(0, 1, 0),
+			diff:  diff,
+		},
+		{
+			name:  "commit the model was trained on",
+			model: model,
+			codeZ: -3,
+			textZ: -3,
+			when:  cutoff.AddDate(0, -1, 0),
+			diff:  diff,
+		},
+		{
+			name:  "too little code",
+			model: model,
+			codeZ: -3,
+			textZ: 1,
+			when:  cutoff.AddDate(0, 1, 0),
+			diff:  "+x := 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.model != nil {
+				tt.model.Code.Baseline = baseline(codeScore, tt.codeZ)
+				tt.model.Text.Baseline = baseline(textScore, tt.textZ)
+			}
+			pair := &git.CommitPair{
+				Current:     &git.Commit{Message: message, Timestamp: tt.when},
+				DiffContent: tt.diff,
+			}
+
+			finding := NewLanguageModelStrategy(tt.model).Detect(pair, nil)
+			if tt.wantCategory == "" {
+				if finding != nil {
+					t.Errorf("Detect() = %q, want nil", finding.Message)
+				}
+				return
+			}
+			if finding == nil {
+				t.Fatal("Detect() = nil, want a finding")
+			}
+			if finding.Category != tt.wantCategory {
+				t.Errorf("Category = %q, want %q", finding.Category, tt.wantCategory)
+			}
+			if math.Abs(finding.Observed+3) > 1e-9 || finding.Confidence <= 0.5 {
+				t.Errorf("Observed = %v, Confidence = %v, want -3 and above 0.5", finding.Observed, finding.Confidence)
+			}
+		})
+	}
+}
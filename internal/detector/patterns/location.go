@@ -0,0 +1,103 @@
+package patterns
+
+import "strings"
+
+// hunkGap is the most lines apart two added lines of a file can be and
+// still belong to one hunk. It matches git's default of three lines of
+// context.
+const hunkGap = 3
+
+// hunks merges lines of code into the spans of the hunks holding them, in
+// the order the lines come. Lines of the commit message have no file and
+// are left out.
+func hunks(lines []textLine) []Location {
+	var spans []Location
+	current := -1
+	for _, l := range lines {
+		if l.file == "" {
+			continue
+		}
+		if current >= 0 {
+			span := &spans[current]
+			if span.File == l.file && l.line >= span.StartLine && l.line <= span.EndLine+hunkGap+1 {
+				if l.line > span.EndLine {
+					span.EndLine = l.line
+				}
+				continue
+			}
+		}
+		spans = append(spans, Location{File: l.file, StartLine: l.line, EndLine: l.line})
+		current = len(spans) - 1
+	}
+	return spans
+}
+
+// locate points a finding at the hunks holding lines, with File and the
+// line span repeating the first. It leaves the finding alone when no line
+// comes from a file.
+func locate(finding *Finding, lines []textLine) {
+	spans := hunks(lines)
+	if len(spans) == 0 {
+		return
+	}
+	finding.File = spans[0].File
+	finding.StartLine = spans[0].StartLine
+	finding.EndLine = spans[0].EndLine
+	finding.Locations = spans
+}
+
+// texts returns the text of each line.
+func texts(lines []textLine) []string {
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		out[i] = l.text
+	}
+	return out
+}
+
+// evidence collects the added lines that set off a strategy's checks, so
+// its finding can point at them.
+type evidence struct {
+	lines  []textLine
+	marked []bool
+}
+
+func newEvidence(lines []textLine) *evidence {
+	return &evidence{lines: lines, marked: make([]bool, len(lines))}
+}
+
+// mark marks the lines whose lowercased text matches.
+func (e *evidence) mark(match func(lower string) bool) {
+	for i, l := range e.lines {
+		if match(strings.ToLower(l.text)) {
+			e.marked[i] = true
+		}
+	}
+}
+
+// containing marks the lines holding any of terms, which are lowercase.
+func (e *evidence) containing(terms ...string) {
+	e.mark(func(lower string) bool {
+		for _, term := range terms {
+			if strings.Contains(lower, term) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// found returns the marked lines, or every line when none is marked, as
+// when the checks that fired measure the addition as a whole.
+func (e *evidence) found() []textLine {
+	var found []textLine
+	for i, l := range e.lines {
+		if e.marked[i] {
+			found = append(found, l)
+		}
+	}
+	if len(found) == 0 {
+		return e.lines
+	}
+	return found
+}
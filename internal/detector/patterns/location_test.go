@@ -0,0 +1,80 @@
+package patterns
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/git"
+)
+
+func TestHunks(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []textLine
+		want  []Location
+	}{
+		{
+			name:  "consecutive lines",
+			lines: []textLine{{file: "a.go", line: 3}, {file: "a.go", line: 4}, {file: "a.go", line: 5}},
+			want:  []Location{{File: "a.go", StartLine: 3, EndLine: 5}},
+		},
+		{
+			name:  "gap within context",
+			lines: []textLine{{file: "a.go", line: 3}, {file: "a.go", line: 7}},
+			want:  []Location{{File: "a.go", StartLine: 3, EndLine: 7}},
+		},
+		{
+			name:  "separate hunks and files",
+			lines: []textLine{{file: "a.go", line: 3}, {file: "a.go", line: 20}, {file: "b.go", line: 21}},
+			want: []Location{
+				{File: "a.go", StartLine: 3, EndLine: 3},
+				{File: "a.go", StartLine: 20, EndLine: 20},
+				{File: "b.go", StartLine: 21, EndLine: 21},
+			},
+		},
+		{
+			name:  "message lines",
+			lines: []textLine{{line: 1, text: "Add feature"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hunks(tt.lines); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("hunks() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplatePatternStrategy_LocatesEvidence(t *testing.T) {
+	// Twelve lines of plain code in one file, and the template comments in
+	// a hunk of another.
+	var b strings.Builder
+	b.WriteString("diff --git a/util.js b/util.js\n--- a/util.js\n+++ b/util.js\n@@ -0,0 +1,12 @@\n")
+	for i := 0; i < 12; i++ {
+		fmt.Fprintf(&b, "+v%d = %d;\n", i, i)
+	}
+	b.WriteString("diff --git a/stub.js b/stub.js\n--- a/stub.js\n+++ b/stub.js\n@@ -40,0 +41,3 @@\n")
+	b.WriteString("+// TODO: implement\n+// your code here\n+return null;\n")
+
+	pair := &git.CommitPair{
+		Current:     &git.Commit{Message: "Add helpers"},
+		Stats:       &git.DiffStats{Additions: 60},
+		DiffContent: b.String(),
+	}
+	finding := NewTemplatePatternStrategy(false).Detect(pair, nil)
+	if finding == nil {
+		t.Fatal("Detect() = nil, want a finding")
+	}
+
+	want := []Location{{File: "stub.js", StartLine: 41, EndLine: 42}}
+	if !reflect.DeepEqual(finding.Locations, want) {
+		t.Errorf("Locations = %+v, want %+v", finding.Locations, want)
+	}
+	if finding.File != "stub.js" || finding.StartLine != 41 || finding.EndLine != 42 {
+		t.Errorf("File, StartLine, EndLine = %q, %d, %d", finding.File, finding.StartLine, finding.EndLine)
+	}
+}
@@ -0,0 +1,170 @@
+package patterns
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// textLine is a line of text a commit adds: a line of its message, an
+// added line of code, or the comments and string literals of one. File is
+// empty for the message.
+type textLine struct {
+	file string
+	line int
+	text string
+}
+
+func (p textLine) where() string {
+	if p.file == "" {
+		return "commit message"
+	}
+	return fmt.Sprintf("%s:%d", p.file, p.line)
+}
+
+// Examples quoted in a finding are capped in number and length.
+const (
+	maxExcerpts      = 3
+	maxExcerptLength = 60
+)
+
+// excerpts quotes the first few lines with where each came from.
+func excerpts(lines []textLine) string {
+	quoted := make([]string, 0, maxExcerpts)
+	for i, p := range lines {
+		if i == maxExcerpts {
+			break
+		}
+		text := []rune(strings.TrimSpace(p.text))
+		if len(text) > maxExcerptLength {
+			text = append(text[:maxExcerptLength-3], []rune("...")...)
+		}
+		quoted = append(quoted, fmt.Sprintf("%q (%s)", string(text), p.where()))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// messageProse returns the non-empty lines of a commit message.
+func messageProse(message string) []textLine {
+	var lines []textLine
+	for i, text := range strings.Split(message, "\n") {
+		if strings.TrimSpace(text) != "" {
+			lines = append(lines, textLine{line: i + 1, text: text})
+		}
+	}
+	return lines
+}
+
+// docExtensions are documentation files, prose throughout rather than code
+// with comments, and left to their own conventions.
+var docExtensions = map[string]bool{
+	".md": true, ".markdown": true, ".rst": true, ".txt": true, ".adoc": true,
+}
+
+// addedProse returns the comments and string literals of the lines a diff
+// adds to code files.
+func addedProse(diffContent string) []textLine {
+	var lines []textLine
+	forEachAddedLine(diffContent, func(file string, line int, text string) {
+		if docExtensions[strings.ToLower(path.Ext(file))] {
+			return
+		}
+		if prose := commentsAndStrings(text); strings.TrimSpace(prose) != "" {
+			lines = append(lines, textLine{file: file, line: line, text: prose})
+		}
+	})
+	return lines
+}
+
+// commentPrefixes start a line holding only a comment.
+var commentPrefixes = []string{"//", "/*", "*/", "* ", "#", "--"}
+
+// commentsAndStrings returns the comment and string literal text of a line
+// of code, joined by spaces. It does not know the language, so it takes
+// any quoted run as a string and // or a # after a space as the start of a
+// trailing comment. Literals without a letter, such as "—" in a table of
+// characters, are code rather than prose and are left out.
+func commentsAndStrings(line string) string {
+	trimmed := strings.TrimSpace(line)
+	for _, prefix := range commentPrefixes {
+		if strings.HasPrefix(trimmed, prefix) {
+			return strings.TrimSpace(strings.TrimSuffix(trimmed[len(prefix):], "*/"))
+		}
+	}
+
+	var parts []string
+	runes := []rune(trimmed)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; {
+		case r == '"' || r == '\'' || r == '`':
+			j := i + 1
+			for j < len(runes) && runes[j] != r {
+				if runes[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j > len(runes) {
+				j = len(runes)
+			}
+			if literal := string(runes[i+1 : j]); strings.IndexFunc(literal, unicode.IsLetter) >= 0 {
+				parts = append(parts, literal)
+			}
+			i = j
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '/',
+			r == '#' && i > 0 && unicode.IsSpace(runes[i-1]):
+			parts = append(parts, strings.TrimLeft(string(runes[i:]), "/# "))
+			i = len(runes)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// forEachAddedLine calls fn with each line a unified diff adds, without
+// its + prefix, and the file and post-image line number it lands on.
+func forEachAddedLine(diffContent string, fn func(file string, line int, text string)) {
+	file := ""
+	line := 0
+	inHeader := false
+
+	for _, text := range strings.Split(diffContent, "\n") {
+		switch {
+		case strings.HasPrefix(text, "diff --git "):
+			inHeader = true
+			continue
+		case inHeader && strings.HasPrefix(text, "+++ "):
+			file = strings.TrimPrefix(strings.TrimSpace(text[4:]), "b/")
+			continue
+		case strings.HasPrefix(text, "@@"):
+			inHeader = false
+			line = hunkStart(text)
+			continue
+		case inHeader || text == "":
+			continue
+		}
+
+		switch text[0] {
+		case '+':
+			fn(file, line, text[1:])
+			line++
+		case ' ':
+			line++
+		}
+	}
+}
+
+// hunkStart reads the post-image start line from "@@ -a,b +c,d @@".
+func hunkStart(header string) int {
+	fields := strings.Fields(header)
+	for _, f := range fields {
+		if strings.HasPrefix(f, "+") {
+			start, _, _ := strings.Cut(f[1:], ",")
+			if n, err := strconv.Atoi(start); err == nil {
+				return n
+			}
+		}
+	}
+	return 1
+}
@@ -0,0 +1,42 @@
+package patterns
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCommentsAndStrings(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{"line comment", "\t// Load the file — carefully", "Load the file — carefully"},
+		{"block comment", " * Returns the value. */", "Returns the value."},
+		{"shell comment", "# retry twice", "retry twice"},
+		{"string and trailing comment", `log.Println("done ✅") // all good`, "done ✅ all good"},
+		{"escaped quote", `msg := "say \"hi\""`, `say \"hi\"`},
+		{"url inside a string", `u := "https://example.com"`, "https://example.com"},
+		{"python trailing comment", "x = 1  # counter", "counter"},
+		{"character literals", `marks := []rune{'—', '…'}; sep := "→"`, ""},
+		{"plain code", "x := a + b", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := commentsAndStrings(tt.line); got != tt.want {
+				t.Errorf("commentsAndStrings(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddedProse(t *testing.T) {
+	diff := "diff --git a/main.go b/main.go\n--- a/main.go\n+++ b/main.go\n@@ -10,2 +10,4 @@\n func main() {\n+\t// start up\n+\trun()\n }\n" +
+		"diff --git a/README.md b/README.md\n--- a/README.md\n+++ b/README.md\n@@ -1 +1,2 @@\n # Title\n+# Features ✨\n"
+
+	want := []textLine{{file: "main.go", line: 11, text: "start up"}}
+	if got := addedProse(diff); !reflect.DeepEqual(got, want) {
+		t.Errorf("addedProse() = %+v, want %+v", got, want)
+	}
+}
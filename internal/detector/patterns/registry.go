@@ -5,9 +5,11 @@ import (
 	"github.com/TryCadence/Cadence/internal/metrics"
 )
 
+// Strategy inspects a commit pair. Detect returns a Finding with a
+// Confidence in (0, 1] when the strategy fires, and nil otherwise.
 type Strategy interface {
 	Name() string
-	Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (bool, string)
+	Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding
 }
 
 type Registry struct {
@@ -36,11 +38,12 @@ func (r *Registry) All() []Strategy {
 	return strategies
 }
 
-func (r *Registry) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) []string {
-	results := make([]string, 0)
+func (r *Registry) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) []Finding {
+	results := make([]Finding, 0)
 	for _, strategy := range r.strategies {
-		if detected, reason := strategy.Detect(pair, repoStats); detected {
-			results = append(results, reason)
+		if finding := strategy.Detect(pair, repoStats); finding != nil {
+			finding.Strategy = strategy.Name()
+			results = append(results, *finding)
 		}
 	}
 	return results
@@ -0,0 +1,101 @@
+package patterns
+
+import (
+	"fmt"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/git"
+	"github.com/TryCadence/Cadence/internal/metrics"
+)
+
+// RepositoryStrategy inspects the whole list of pairs at once, oldest
+// commit first, for patterns no single pair shows on its own. Each finding
+// it returns is scoped to a commit, an author or a window of time.
+type RepositoryStrategy interface {
+	Name() string
+	DetectRepository(pairs []*git.CommitPair, repoStats *metrics.RepositoryStats) []Finding
+}
+
+// TimingClusterStrategy flags runs of rapid-fire commits, reported against
+// the last commit of each run.
+type TimingClusterStrategy struct{}
+
+func NewTimingClusterStrategy() *TimingClusterStrategy {
+	return &TimingClusterStrategy{}
+}
+
+func (s *TimingClusterStrategy) Name() string {
+	return string(analysis.AnomalyTimingCluster)
+}
+
+func (s *TimingClusterStrategy) DetectRepository(pairs []*git.CommitPair, repoStats *metrics.RepositoryStats) []Finding {
+	findings := make([]Finding, 0)
+	for _, anomaly := range analysis.DetectTimingClusters(pairs) {
+		findings = append(findings, Finding{
+			Scope:      ScopeCommit,
+			Commit:     anomaly.CommitHash,
+			Category:   CategoryTiming,
+			Confidence: indicatorStrength(int(anomaly.ObservedValue), 3),
+			Observed:   anomaly.ObservedValue,
+			Threshold:  3,
+			Message:    fmt.Sprintf("%s: %.0f commits less than 5 minutes apart", anomaly.Description, anomaly.ObservedValue),
+		})
+	}
+	return findings
+}
+
+// AuthorBehaviorStrategy flags commits far larger than their author's own
+// average commit.
+type AuthorBehaviorStrategy struct{}
+
+func NewAuthorBehaviorStrategy() *AuthorBehaviorStrategy {
+	return &AuthorBehaviorStrategy{}
+}
+
+func (s *AuthorBehaviorStrategy) Name() string {
+	return string(analysis.AnomalyAuthorBehavior)
+}
+
+func (s *AuthorBehaviorStrategy) DetectRepository(pairs []*git.CommitPair, repoStats *metrics.RepositoryStats) []Finding {
+	findings := make([]Finding, 0)
+	for _, anomaly := range analysis.DetectAuthorBehaviorAnomalies(pairs) {
+		findings = append(findings, Finding{
+			Scope:      ScopeCommit,
+			Commit:     anomaly.CommitHash,
+			Category:   CategoryStatistical,
+			Confidence: overThreshold(anomaly.Score, 5),
+			Observed:   anomaly.ObservedValue,
+			Threshold:  anomaly.BaselineValue,
+			Message: fmt.Sprintf("%s (%.0f lines, %.1fx the author's average of %.0f)",
+				anomaly.Description, anomaly.ObservedValue, anomaly.Score, anomaly.BaselineValue),
+		})
+	}
+	return findings
+}
+
+// CommitGapStrategy flags commits whose gap since the previous commit lies
+// more than two standard deviations from the mean gap across the history.
+type CommitGapStrategy struct{}
+
+func NewCommitGapStrategy() *CommitGapStrategy {
+	return &CommitGapStrategy{}
+}
+
+func (s *CommitGapStrategy) Name() string {
+	return "commit_gap_anomaly"
+}
+
+func (s *CommitGapStrategy) DetectRepository(pairs []*git.CommitPair, repoStats *metrics.RepositoryStats) []Finding {
+	findings := make([]Finding, 0)
+	for _, anomaly := range analysis.DetectTimingAnomalies(pairs) {
+		findings = append(findings, Finding{
+			Scope:      ScopeCommit,
+			Commit:     anomaly.CommitHash,
+			Category:   CategoryTiming,
+			Confidence: 0.5,
+			Observed:   anomaly.TimeSinceLastCommit,
+			Message:    fmt.Sprintf("%s (%.1f minutes)", anomaly.Description, anomaly.TimeSinceLastCommit),
+		})
+	}
+	return findings
+}
@@ -0,0 +1,49 @@
+package patterns
+
+// Strategies report a strength in (0, 1] when they fire and 0 when they do
+// not. A strength of 0.5 means the evidence sits right at the configured
+// threshold; it approaches 1 as the evidence grows past it.
+
+// overThreshold scores a value that must stay at or below threshold.
+// It returns 0.5 just past the threshold and 0.75 at twice the threshold.
+func overThreshold(value, threshold float64) float64 {
+	if threshold <= 0 || value <= threshold {
+		return 0.5
+	}
+	return clampStrength(1 - 0.5*threshold/value)
+}
+
+// underThreshold scores a value that must stay at or above threshold.
+// It returns 0.5 just under the threshold and 1 at zero.
+func underThreshold(value, threshold float64) float64 {
+	if threshold <= 0 || value >= threshold {
+		return 0.5
+	}
+	if value < 0 {
+		value = 0
+	}
+	return clampStrength(1 - 0.5*value/threshold)
+}
+
+// indicatorStrength scores a count of matched indicators against the number
+// required to fire: 0.5 at required, rising linearly to 1 at twice that.
+func indicatorStrength(found, required int) float64 {
+	if required <= 0 {
+		required = 1
+	}
+	if found <= required {
+		return 0.5
+	}
+	return clampStrength(0.5 + 0.5*float64(found-required)/float64(required))
+}
+
+func clampStrength(v float64) float64 {
+	switch {
+	case v < 0:
+		return 0
+	case v > 1:
+		return 1
+	default:
+		return v
+	}
+}
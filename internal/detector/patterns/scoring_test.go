@@ -0,0 +1,34 @@
+package patterns
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStrengthHelpers(t *testing.T) {
+	tests := []struct {
+		name     string
+		got      float64
+		expected float64
+	}{
+		{"over at threshold", overThreshold(100, 100), 0.5},
+		{"over at twice threshold", overThreshold(200, 100), 0.75},
+		{"over far past threshold", overThreshold(1e6, 100), 1 - 0.5e-4},
+		{"under at threshold", underThreshold(60, 60), 0.5},
+		{"under at half threshold", underThreshold(30, 60), 0.75},
+		{"under at zero", underThreshold(0, 60), 1},
+		{"indicators at required", indicatorStrength(2, 2), 0.5},
+		{"indicators at twice required", indicatorStrength(4, 2), 1},
+		{"indicators capped", indicatorStrength(10, 2), 1},
+		{"z-score at three", zScoreStrength(-3), 1 - 1.0/3},
+		{"z-score floor", zScoreStrength(1.2), 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if math.Abs(tt.got-tt.expected) > 1e-9 {
+				t.Errorf("got %v, want %v", tt.got, tt.expected)
+			}
+		})
+	}
+}
@@ -8,18 +8,75 @@ import (
 	"github.com/TryCadence/Cadence/internal/metrics"
 )
 
+// SpecialCharacterPatternStrategy flags typography that language models
+// produce and people rarely type, such as em dashes, ellipses, curly quotes
+// and arrows, along with markdown decoration and separator runs in commit
+// messages. Typographic marks are also counted in the comments and string
+// literals of added code.
 type SpecialCharacterPatternStrategy struct {
-	enabled bool
+	enabled         bool
+	minMessageMarks float64
+	minCodeMarks    float64
+	maxLineDensity  float64
 }
 
 func NewSpecialCharacterPatternStrategy() *SpecialCharacterPatternStrategy {
-	return &SpecialCharacterPatternStrategy{enabled: true}
+	return &SpecialCharacterPatternStrategy{
+		enabled:         true,
+		minMessageMarks: 2,
+		minCodeMarks:    3,
+		maxLineDensity:  0.25,
+	}
 }
 
 func (s *SpecialCharacterPatternStrategy) Name() string {
 	return "special_character_pattern_analysis"
 }
 
+func (s *SpecialCharacterPatternStrategy) params() []param {
+	return []param{
+		{name: "min_message_marks", value: &s.minMessageMarks},
+		{name: "min_code_marks", value: &s.minCodeMarks},
+		{name: "max_line_density", value: &s.maxLineDensity, max: 1},
+	}
+}
+
+func (s *SpecialCharacterPatternStrategy) Params() map[string]float64 { return paramValues(s.params()) }
+
+func (s *SpecialCharacterPatternStrategy) SetParam(name string, value float64) error {
+	return setParam(s.Name(), s.params(), name, value)
+}
+
+// typographicMarks need a compose key or an editor's autocorrect to type.
+var typographicMarks = []rune{'—', '–', '…', '“', '”', '→', '⇒', '•'}
+
+func countTypographicMarks(text string) int {
+	count := 0
+	for _, r := range text {
+		for _, mark := range typographicMarks {
+			if r == mark {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// withTypographicMarks returns the lines holding typographic marks and how
+// many they hold.
+func withTypographicMarks(lines []textLine) ([]textLine, int) {
+	var found []textLine
+	total := 0
+	for _, line := range lines {
+		if n := countTypographicMarks(line.text); n > 0 {
+			found = append(found, line)
+			total += n
+		}
+	}
+	return found, total
+}
+
 func countCharacter(text string, char rune) int {
 	count := 0
 	for _, r := range text {
@@ -61,10 +118,6 @@ func getConsecutiveCount(text string, char rune) int {
 	return maxConsecutive
 }
 
-func splitByLines(text string) []string {
-	return strings.Split(text, "\n")
-}
-
 func countSpecialCharactersInLine(line string) map[rune]int {
 	specialChars := make(map[rune]int)
 	suspiciousChars := []rune{'-', '*', '_', '=', '+', '#', '!', '?', '.', ','}
@@ -78,86 +131,116 @@ func countSpecialCharactersInLine(line string) map[rune]int {
 	return specialChars
 }
 
-func (s *SpecialCharacterPatternStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+func (s *SpecialCharacterPatternStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding {
 	if !s.enabled || pair == nil || pair.Current == nil {
-		return false, ""
+		return nil
 	}
 
-	msg := pair.Current.Message
-	lines := splitByLines(msg)
-
-	hyphenCount := countCharacter(msg, '-')
-	if hyphenCount >= 5 {
-		return true, fmt.Sprintf(
-			"Excessive hyphens in commit message (%d occurrences)",
-			hyphenCount,
-		)
+	var parts []string
+	var examples []textLine
+	finding, part, lines := s.detectMessage(pair.Current.Message)
+	if finding != nil {
+		parts = append(parts, part)
+		examples = append(examples, lines...)
 	}
 
-	if detectConsecutiveCharacters(msg, '-', 3) {
-		maxConsec := getConsecutiveCount(msg, '-')
-		return true, fmt.Sprintf(
-			"Multiple consecutive hyphens detected (%d in a row)",
-			maxConsec,
-		)
+	codeLines, codeMarks := withTypographicMarks(addedProse(pair.DiffContent))
+	if float64(codeMarks) >= s.minCodeMarks {
+		strength := indicatorStrength(codeMarks, int(s.minCodeMarks))
+		if finding == nil {
+			finding = &Finding{
+				Category:   CategoryCode,
+				Confidence: strength,
+				Observed:   float64(codeMarks),
+				Threshold:  s.minCodeMarks,
+			}
+		} else if strength > finding.Confidence {
+			finding.Confidence = strength
+		}
+		locate(finding, codeLines)
+		parts = append(parts, fmt.Sprintf("%d typographic marks in added comments and strings", codeMarks))
+		examples = append(examples, codeLines...)
 	}
 
-	asteriskCount := countCharacter(msg, '*')
-	if asteriskCount >= 4 {
-		return true, fmt.Sprintf(
-			"Excessive asterisks in commit message (%d occurrences)",
-			asteriskCount,
-		)
+	if finding == nil {
+		return nil
 	}
+	finding.Message = fmt.Sprintf("Unusual special characters (%s), e.g. %s",
+		strings.Join(parts, ", "), excerpts(examples))
+	return finding
+}
 
-	if detectConsecutiveCharacters(msg, '*', 3) {
-		maxConsec := getConsecutiveCount(msg, '*')
-		return true, fmt.Sprintf(
-			"Multiple consecutive asterisks detected (%d in a row)",
-			maxConsec,
-		)
+// detectMessage applies the message checks in turn and reports the first
+// that fires, with a description and the lines it fired on.
+func (s *SpecialCharacterPatternStrategy) detectMessage(msg string) (*Finding, string, []textLine) {
+	lines := messageProse(msg)
+	linesWith := func(pattern string) []textLine {
+		var found []textLine
+		for _, line := range lines {
+			if strings.Contains(line.text, pattern) {
+				found = append(found, line)
+			}
+		}
+		return found
 	}
 
-	underscoreCount := countCharacter(msg, '_')
-	if underscoreCount >= 4 {
-		return true, fmt.Sprintf(
-			"Excessive underscores in commit message (%d occurrences)",
-			underscoreCount,
-		)
+	if marked, marks := withTypographicMarks(lines); marks > 0 && float64(marks) >= s.minMessageMarks {
+		return &Finding{
+			Category:   CategoryMessage,
+			Confidence: indicatorStrength(marks, int(s.minMessageMarks)),
+			Observed:   float64(marks),
+			Threshold:  s.minMessageMarks,
+		}, fmt.Sprintf("%d typographic marks in the commit message", marks), marked
 	}
 
-	specialCharPatterns := []string{
-		"---", "***", "===", "+++", "!!!",
+	if asteriskCount := countCharacter(msg, '*'); asteriskCount >= 4 {
+		return &Finding{
+			Category:   CategoryMessage,
+			Confidence: indicatorStrength(asteriskCount, 4),
+			Observed:   float64(asteriskCount),
+			Threshold:  4,
+		}, fmt.Sprintf("%d asterisks in the commit message", asteriskCount), linesWith("*")
 	}
 
-	for _, pattern := range specialCharPatterns {
-		if strings.Count(msg, pattern) > 1 {
-			return true, fmt.Sprintf(
-				"Special character pattern clustering detected: '%s' repeated %d times",
-				pattern, strings.Count(msg, pattern),
-			)
+	for _, pattern := range []string{"---", "***", "===", "+++", "!!!"} {
+		if count := strings.Count(msg, pattern); count > 1 {
+			return &Finding{
+				Category:   CategoryMessage,
+				Confidence: indicatorStrength(count, 2),
+				Observed:   float64(count),
+				Threshold:  2,
+			}, fmt.Sprintf("'%s' repeated %d times in the commit message", pattern, count), linesWith(pattern)
 		}
 	}
 
-	for lineIdx, line := range lines {
-		if line == "" {
-			continue
-		}
+	if detectConsecutiveCharacters(msg, '-', 3) {
+		maxConsec := getConsecutiveCount(msg, '-')
+		return &Finding{
+			Category:   CategoryMessage,
+			Confidence: indicatorStrength(maxConsec, 3),
+			Observed:   float64(maxConsec),
+			Threshold:  3,
+		}, fmt.Sprintf("%d consecutive hyphens in the commit message", maxConsec), linesWith("---")
+	}
 
-		charCounts := countSpecialCharactersInLine(line)
+	for _, line := range lines {
+		charCounts := countSpecialCharactersInLine(line.text)
 		totalSpecialChars := 0
 		for _, count := range charCounts {
 			totalSpecialChars += count
 		}
 
-		lineLength := len([]rune(line))
-		if lineLength > 5 && float64(totalSpecialChars)/float64(lineLength) > 0.25 {
-			return true, fmt.Sprintf(
-				"High special character density on line %d (%.1f%% of line)",
-				lineIdx+1, float64(totalSpecialChars)/float64(lineLength)*100,
-			)
+		lineLength := len([]rune(line.text))
+		density := float64(totalSpecialChars) / float64(lineLength)
+		if lineLength > 5 && density > s.maxLineDensity {
+			return &Finding{
+				Category:   CategoryMessage,
+				Confidence: overThreshold(density, s.maxLineDensity),
+				Observed:   density,
+				Threshold:  s.maxLineDensity,
+			}, fmt.Sprintf("line %d of the commit message is %.0f%% special characters", line.line, density*100), []textLine{line}
 		}
 	}
 
-	return false, ""
+	return nil, "", nil
 }
@@ -0,0 +1,73 @@
+package patterns
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/git"
+)
+
+func TestSpecialCharacterPatternStrategy(t *testing.T) {
+	codeDiff := "diff --git a/cache.go b/cache.go\n--- a/cache.go\n+++ b/cache.go\n@@ -4,0 +5,3 @@\n" +
+		"+// Evict removes stale entries — oldest first.\n+// Keys → values… in order.\n+x := 1\n"
+
+	tests := []struct {
+		name         string
+		message      string
+		diff         string
+		wantCategory string
+		wantFile     string
+		wantExcerpt  string
+	}{
+		{
+			name:         "em dashes in message",
+			message:      "Refactor cache — simpler and faster — fewer locks",
+			wantCategory: CategoryMessage,
+			wantExcerpt:  `(commit message)`,
+		},
+		{
+			name:         "markdown emphasis in message",
+			message:      "Add cache\n\n**Changes**:\n- **eviction** order",
+			wantCategory: CategoryMessage,
+			wantExcerpt:  `"**Changes**:" (commit message)`,
+		},
+		{
+			name:         "typography in added comments",
+			message:      "Add eviction",
+			diff:         codeDiff,
+			wantCategory: CategoryCode,
+			wantFile:     "cache.go",
+			wantExcerpt:  `(cache.go:5)`,
+		},
+		{
+			name:    "plain bullet list",
+			message: "Fix cache\n\n- evict oldest first\n- log evictions\n- add tests\n- update docs\n- bump version",
+		},
+		{
+			name:    "snake_case identifiers",
+			message: "Rename max_size to max_entries in cache_config and eviction_policy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pair := &git.CommitPair{Current: &git.Commit{Message: tt.message}, DiffContent: tt.diff}
+			finding := NewSpecialCharacterPatternStrategy().Detect(pair, nil)
+			if tt.wantCategory == "" {
+				if finding != nil {
+					t.Errorf("Detect() = %q, want nil", finding.Message)
+				}
+				return
+			}
+			if finding == nil {
+				t.Fatal("Detect() = nil, want a finding")
+			}
+			if finding.Category != tt.wantCategory || finding.File != tt.wantFile {
+				t.Errorf("Category, File = %q, %q, want %q, %q", finding.Category, finding.File, tt.wantCategory, tt.wantFile)
+			}
+			if !strings.Contains(finding.Message, tt.wantExcerpt) {
+				t.Errorf("Message = %q, want excerpt %s", finding.Message, tt.wantExcerpt)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package patterns
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/TryCadence/Cadence/internal/git"
+	"github.com/TryCadence/Cadence/internal/metrics"
+	"github.com/TryCadence/Cadence/internal/stylometry"
+)
+
+// StyleDriftStrategy builds a stylometric profile of each author from
+// their earlier commits (indentation, identifier length, comment phrasing,
+// commit message length and casing, punctuation) and flags commits whose
+// style lies more than max_distance from it. The distance is the root mean
+// square of the per-feature z-scores, so one habit changing a lot or
+// several changing somewhat both count. Baseline pairs outside the
+// analyzed ones, such as the history before a --since, count as earlier
+// commits too.
+type StyleDriftStrategy struct {
+	minCommits  float64
+	minFeatures float64
+	maxDistance float64
+	minDrift    float64
+	baseline    []*git.CommitPair
+}
+
+func NewStyleDriftStrategy() *StyleDriftStrategy {
+	return &StyleDriftStrategy{
+		minCommits:  10,
+		minFeatures: 4,
+		maxDistance: 2.5,
+		minDrift:    2,
+	}
+}
+
+func (s *StyleDriftStrategy) Name() string {
+	return "author_style_drift"
+}
+
+func (s *StyleDriftStrategy) params() []param {
+	return []param{
+		{name: "min_commits", value: &s.minCommits},
+		{name: "min_features", value: &s.minFeatures},
+		{name: "max_distance", value: &s.maxDistance},
+		{name: "min_drift", value: &s.minDrift},
+	}
+}
+
+func (s *StyleDriftStrategy) Params() map[string]float64 { return paramValues(s.params()) }
+
+func (s *StyleDriftStrategy) SetParam(name string, value float64) error {
+	return setParam(s.Name(), s.params(), name, value)
+}
+
+// SetBaseline sets the pairs that seed the authors' profiles. Those among
+// the analyzed pairs are judged as usual instead.
+func (s *StyleDriftStrategy) SetBaseline(pairs []*git.CommitPair) {
+	s.baseline = pairs
+}
+
+func (s *StyleDriftStrategy) DetectRepository(pairs []*git.CommitPair, repoStats *metrics.RepositoryStats) []Finding {
+	findings := make([]Finding, 0)
+
+	// A feature needs half the profile's commits to be compared, so a
+	// habit seen in a couple of commits does not define the author.
+	minSamples := int(s.minCommits / 2)
+	if minSamples < 2 {
+		minSamples = 2
+	}
+
+	analyzed := make(map[*git.CommitPair]bool, len(pairs))
+	hashes := make(map[string]bool, len(pairs))
+	for _, pair := range pairs {
+		analyzed[pair] = true
+		hashes[pair.Current.Hash] = true
+	}
+	timeline := make([]*git.CommitPair, 0, len(s.baseline)+len(pairs))
+	for _, pair := range s.baseline {
+		if !hashes[pair.Current.Hash] {
+			timeline = append(timeline, pair)
+		}
+	}
+	timeline = append(timeline, pairs...)
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].Current.Timestamp.Before(timeline[j].Current.Timestamp)
+	})
+
+	profiles := make(map[string]*stylometry.Profile)
+	for _, pair := range timeline {
+		email := pair.Current.Email
+		profile, ok := profiles[email]
+		if !ok {
+			profile = &stylometry.Profile{}
+			profiles[email] = profile
+		}
+
+		features := stylometry.Extract(pair.DiffContent, pair.Current.Message)
+		if analyzed[pair] && float64(profile.Commits) >= s.minCommits {
+			if finding := s.compare(pair, profile, features, minSamples); finding != nil {
+				findings = append(findings, *finding)
+			}
+		}
+		profile.Add(features)
+	}
+	return findings
+}
+
+func (s *StyleDriftStrategy) compare(pair *git.CommitPair, profile *stylometry.Profile, features stylometry.Features, minSamples int) *Finding {
+	distance, drifts := profile.Compare(features, minSamples)
+	if float64(len(drifts)) < s.minFeatures || distance <= s.maxDistance {
+		return nil
+	}
+
+	described := make([]string, 0, len(drifts))
+	for _, d := range drifts {
+		if d.Z < s.minDrift {
+			break
+		}
+		described = append(described, fmt.Sprintf("%s %s vs %s typical", d.Feature, formatFeature(d.Observed), formatFeature(d.Typical)))
+	}
+	if len(described) == 0 {
+		described = append(described, "small shifts across every feature")
+	}
+
+	return &Finding{
+		Scope:      ScopeCommit,
+		Commit:     pair.Current.Hash,
+		Author:     pair.Current.Email,
+		Category:   CategoryStatistical,
+		Confidence: overThreshold(distance, s.maxDistance),
+		Observed:   distance,
+		Threshold:  s.maxDistance,
+		Message: fmt.Sprintf("Style differs from the author's %d earlier commits (distance %.1f over %d features): %s",
+			profile.Commits, distance, len(drifts), strings.Join(described, ", ")),
+	}
+}
+
+// formatFeature prints counts and lengths with one decimal and shares and
+// rates with two.
+func formatFeature(v float64) string {
+	if v >= 2 {
+		return fmt.Sprintf("%.1f", v)
+	}
+	return fmt.Sprintf("%.2f", v)
+}
@@ -0,0 +1,126 @@
+package patterns
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/git"
+)
+
+func stylePair(hash, email, message string, lines ...string) *git.CommitPair {
+	pair := timedPair(hash, email, time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC), int64(len(lines)))
+	pair.Current.Message = message
+	pair.DiffContent = "+++ b/main.go\n+" + strings.Join(lines, "\n+") + "\n"
+	return pair
+}
+
+// terse is one author's habit: tabs, short lowercase comments and
+// subjects, short names.
+func terse(hash, email string, i int) *git.CommitPair {
+	return stylePair(hash, email, fmt.Sprintf("fix cfg %d", i),
+		"// load cfg",
+		"// no retry",
+		"// see below",
+		fmt.Sprintf("func ld%d(p string) error {", i),
+		"\tb, err := rd(p)",
+		"\tif err != nil {",
+		"\t\treturn err",
+		"\t}",
+		"\tc.b = b",
+		"\tlog(b)",
+		"\treturn nil",
+		"}",
+	)
+}
+
+// verbose is the opposite habit: spaces, long capitalized comments and
+// subjects, long names.
+func verbose(hash, email string) *git.CommitPair {
+	return stylePair(hash, email, "Implement comprehensive configuration loading with robust error handling.",
+		"// LoadConfiguration reads the configuration file from the provided path.",
+		"// It returns a descriptive error when the file cannot be read.",
+		"// The parsed configuration is stored on the receiver for later use.",
+		"func loadConfiguration(configurationPath string) error {",
+		"    configurationBytes, readError := readConfigurationFile(configurationPath)",
+		"    if readError != nil {",
+		"        return wrapConfigurationError(readError)",
+		"    }",
+		"    currentConfiguration.rawBytes = configurationBytes",
+		"    return nil",
+		"}",
+	)
+}
+
+func TestStyleDriftStrategy(t *testing.T) {
+	history := func(email string, count int) []*git.CommitPair {
+		pairs := make([]*git.CommitPair, 0, count)
+		for i := 0; i < count; i++ {
+			pairs = append(pairs, terse(fmt.Sprintf("%s-%d", email, i), email, i))
+		}
+		return pairs
+	}
+
+	tests := []struct {
+		name     string
+		baseline []*git.CommitPair
+		pairs    []*git.CommitPair
+		flagged  string
+	}{
+		{
+			name:    "author changes style",
+			pairs:   append(history("a@example.com", 12), verbose("new", "a@example.com")),
+			flagged: "new",
+		},
+		{
+			name:  "consistent author",
+			pairs: history("a@example.com", 13),
+		},
+		{
+			name:  "too little history",
+			pairs: append(history("a@example.com", 5), verbose("new", "a@example.com")),
+		},
+		{
+			name:  "another author's own style",
+			pairs: append(history("a@example.com", 12), verbose("new", "b@example.com")),
+		},
+		{
+			name:     "filtered run seeded from the baseline",
+			baseline: history("a@example.com", 12),
+			pairs:    []*git.CommitPair{verbose("new", "a@example.com")},
+			flagged:  "new",
+		},
+		{
+			name:     "baseline of the analyzed pairs counts once",
+			baseline: append(history("a@example.com", 6), verbose("new", "a@example.com")),
+			pairs:    append(history("a@example.com", 6), verbose("new", "a@example.com")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy := NewStyleDriftStrategy()
+			strategy.SetBaseline(tt.baseline)
+			findings := strategy.DetectRepository(tt.pairs, nil)
+			if tt.flagged == "" {
+				if len(findings) != 0 {
+					t.Fatalf("DetectRepository() = %q, want none", findings[0].Message)
+				}
+				return
+			}
+			if len(findings) != 1 {
+				t.Fatalf("len(findings) = %d, want 1", len(findings))
+			}
+			f := findings[0]
+			if f.Scope != ScopeCommit || f.Commit != tt.flagged || f.Author != "a@example.com" {
+				t.Errorf("Scope, Commit, Author = %q, %q, %q", f.Scope, f.Commit, f.Author)
+			}
+			for _, feature := range []string{"indent_tabs", "identifier_length", "comment_capitalized", "subject_length"} {
+				if !strings.Contains(f.Message, feature) {
+					t.Errorf("Message %q does not name drifted feature %s", f.Message, feature)
+				}
+			}
+		})
+	}
+}
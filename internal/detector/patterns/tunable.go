@@ -0,0 +1,55 @@
+package patterns
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Tunable is implemented by strategies whose parameters can be overridden
+// from configuration.
+type Tunable interface {
+	// Params returns the current value of every parameter by name.
+	Params() map[string]float64
+	// SetParam changes one parameter, rejecting unknown names and values
+	// outside the parameter's range.
+	SetParam(name string, value float64) error
+}
+
+// param binds a configuration name to a strategy field. Values must be at
+// least 0 and, when max is non-zero, at most max.
+type param struct {
+	name  string
+	value *float64
+	max   float64
+}
+
+func paramValues(params []param) map[string]float64 {
+	values := make(map[string]float64, len(params))
+	for _, p := range params {
+		values[p.name] = *p.value
+	}
+	return values
+}
+
+func setParam(strategy string, params []param, name string, value float64) error {
+	for _, p := range params {
+		if p.name != name {
+			continue
+		}
+		if value < 0 || (p.max > 0 && value > p.max) {
+			if p.max > 0 {
+				return fmt.Errorf("%s.%s must be between 0 and %g", strategy, name, p.max)
+			}
+			return fmt.Errorf("%s.%s cannot be negative", strategy, name)
+		}
+		*p.value = value
+		return nil
+	}
+
+	names := make([]string, 0, len(params))
+	for _, p := range params {
+		names = append(names, p.name)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("unknown parameter %q for %s (valid: %v)", name, strategy, names)
+}
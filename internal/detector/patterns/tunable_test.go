@@ -0,0 +1,42 @@
+package patterns
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTunable_SetParam(t *testing.T) {
+	tests := []struct {
+		name          string
+		strategy      Tunable
+		param         string
+		value         float64
+		errorContains string
+	}{
+		{name: "valid", strategy: NewBurstPatternStrategy(), param: "window_seconds", value: 120},
+		{name: "zero allowed", strategy: NewCommitMessageStrategy(), param: "min_generic_phrases", value: 0},
+		{name: "unknown name", strategy: NewBurstPatternStrategy(), param: "window", value: 1, errorContains: "unknown parameter"},
+		{name: "negative", strategy: NewTimingAnomalyStrategy(), param: "long_gap_hours", value: -1, errorContains: "cannot be negative"},
+		{name: "above max", strategy: NewStructuralConsistencyStrategy(), param: "balance_tolerance", value: 2, errorContains: "between 0 and 1"},
+		{name: "ratio", strategy: NewNamingPatternStrategy(false), param: "max_camel_case_ratio", value: 0.5},
+		{name: "ratio above max", strategy: NewFileExtensionPatternStrategy(), param: "min_consistency", value: 1.5, errorContains: "between 0 and 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.strategy.SetParam(tt.param, tt.value)
+			if tt.errorContains != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+					t.Fatalf("SetParam() error = %v, want containing %q", err, tt.errorContains)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetParam() unexpected error = %v", err)
+			}
+			if got := tt.strategy.Params()[tt.param]; got != tt.value {
+				t.Errorf("Params()[%q] = %v, want %v", tt.param, got, tt.value)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package detector
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TryCadence/Cadence/internal/detector/patterns"
+)
+
+// Severity buckets a commit score for reporting and alerting.
+type Severity = patterns.Severity
+
+const (
+	SeverityLow    = patterns.SeverityLow
+	SeverityMedium = patterns.SeverityMedium
+	SeverityHigh   = patterns.SeverityHigh
+)
+
+// Score bands: below MediumScore is low, below HighScore is medium, and
+// anything at or above HighScore is high.
+const (
+	MediumScore = 0.4
+	HighScore   = 0.7
+)
+
+// SeverityFor maps a score in [0, 1] to its band. A finding's severity is
+// the band of its weighted confidence, the amount it alone adds to a score.
+func SeverityFor(score float64) Severity {
+	switch {
+	case score >= HighScore:
+		return SeverityHigh
+	case score >= MediumScore:
+		return SeverityMedium
+	default:
+		return SeverityLow
+	}
+}
+
+// severityRank orders the severities, least severe first.
+var severityRank = map[Severity]int{
+	SeverityLow:    1,
+	SeverityMedium: 2,
+	SeverityHigh:   3,
+}
+
+// ParseSeverity reads a severity by name, in any case.
+func ParseSeverity(name string) (Severity, error) {
+	s := Severity(strings.ToLower(name))
+	if _, ok := severityRank[s]; !ok {
+		return "", fmt.Errorf("unknown severity %q (want low, medium or high)", name)
+	}
+	return s, nil
+}
+
+// SeverityAtLeast reports whether s is threshold or more severe. The
+// empty severity of nothing found is below every other.
+func SeverityAtLeast(s, threshold Severity) bool {
+	return severityRank[s] >= severityRank[threshold]
+}
+
+// DefaultWeight applies to strategies missing from the weight table.
+const DefaultWeight = 0.3
+
+// DefaultWeights returns how much each strategy's detection counts toward
+// the commit score, keyed by strategy name. A weight is the probability
+// that a full-strength detection by that strategy alone means the commit
+// is AI-generated: explicit fingerprints near-certain, size and speed
+// signals moderate, message phrasing weak.
+func DefaultWeights() map[string]float64 {
+	return map[string]float64{
+		"ai_tool_fingerprint":                0.95,
+		"velocity_analysis":                  0.6,
+		"size_analysis":                      0.4,
+		"StatisticalAnomaly":                 0.4,
+		"author_behavior_change":             0.35,
+		"author_style_drift":                 0.35,
+		"timing_analysis":                    0.3,
+		"file_dispersion_analysis":           0.3,
+		"ratio_analysis":                     0.3,
+		"precision_analysis":                 0.3,
+		"burst_pattern_analysis":             0.3,
+		"timing_cluster":                     0.3,
+		"uniform_commit_burst":               0.3,
+		"near_clone_analysis":                0.3,
+		"structural_consistency_analysis":    0.25,
+		"template_pattern_analysis":          0.25,
+		"go_source_analysis":                 0.25,
+		"language_model_analysis":            0.25,
+		"TimingAnomaly":                      0.25,
+		"file_dispersion_anomaly":            0.25,
+		"cadence_shift":                      0.25,
+		"naming_pattern_analysis":            0.2,
+		"file_extension_analysis":            0.2,
+		"entropy_anomaly":                    0.2,
+		"commit_message_analysis":            0.15,
+		"error_handling_analysis":            0.15,
+		"commit_gap_anomaly":                 0.15,
+		"emoji_pattern_analysis":             0.15,
+		"special_character_pattern_analysis": 0.1,
+	}
+}
+
+func validateWeights(weights map[string]float64) error {
+	for name, w := range weights {
+		if !isStrategyName(name) {
+			return fmt.Errorf("unknown strategy %q in weights", name)
+		}
+		if w < 0 || w > 1 {
+			return fmt.Errorf("weight for %q must be between 0.0 and 1.0", name)
+		}
+	}
+	return nil
+}
+
+// mergeWeights overlays configured weights on the defaults.
+func mergeWeights(configured map[string]float64) map[string]float64 {
+	weights := DefaultWeights()
+	for name, w := range configured {
+		weights[name] = w
+	}
+	return weights
+}
+
+func (d *Detector) weightFor(name string) float64 {
+	if w, ok := d.weights[name]; ok {
+		return w
+	}
+	return DefaultWeight
+}
+
+// CombineScores merges weighted detections with a noisy-OR:
+//
+//	score = 1 - Π (1 - weight_i * strength_i)
+//
+// Each detection is treated as independent evidence that would on its own
+// mark the commit with probability weight*strength. The score only grows
+// as detections are added, stays in [0, 1], and is not diluted by the
+// number of strategies that did not fire.
+func CombineScores(weighted []float64) float64 {
+	miss := 1.0
+	for _, p := range weighted {
+		if p <= 0 {
+			continue
+		}
+		if p > 1 {
+			p = 1
+		}
+		miss *= 1 - p
+	}
+	return 1 - miss
+}
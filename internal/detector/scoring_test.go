@@ -0,0 +1,159 @@
+package detector
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/git"
+)
+
+func TestCombineScores(t *testing.T) {
+	tests := []struct {
+		name     string
+		weighted []float64
+		expected float64
+	}{
+		{name: "no detections", weighted: nil, expected: 0},
+		{name: "single detection", weighted: []float64{0.4}, expected: 0.4},
+		{name: "independent detections reinforce", weighted: []float64{0.5, 0.5}, expected: 0.75},
+		{name: "three detections", weighted: []float64{0.6, 0.3, 0.15}, expected: 1 - 0.4*0.7*0.85},
+		{name: "zero weight ignored", weighted: []float64{0, 0.3}, expected: 0.3},
+		{name: "certain detection", weighted: []float64{1, 0.2}, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CombineScores(tt.weighted); math.Abs(got-tt.expected) > 1e-9 {
+				t.Errorf("CombineScores(%v) = %v, want %v", tt.weighted, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSeverityFor(t *testing.T) {
+	tests := []struct {
+		score    float64
+		expected Severity
+	}{
+		{0, SeverityLow},
+		{0.39, SeverityLow},
+		{0.4, SeverityMedium},
+		{0.69, SeverityMedium},
+		{0.7, SeverityHigh},
+		{1, SeverityHigh},
+	}
+
+	for _, tt := range tests {
+		if got := SeverityFor(tt.score); got != tt.expected {
+			t.Errorf("SeverityFor(%v) = %q, want %q", tt.score, got, tt.expected)
+		}
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Severity
+		wantErr bool
+	}{
+		{"low", SeverityLow, false},
+		{"Medium", SeverityMedium, false},
+		{"HIGH", SeverityHigh, false},
+		{"critical", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSeverity(tt.name)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseSeverity(%q) = %q, %v, want %q, error %v", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestSeverityAtLeast(t *testing.T) {
+	tests := []struct {
+		s, threshold Severity
+		want         bool
+	}{
+		{SeverityHigh, SeverityMedium, true},
+		{SeverityMedium, SeverityMedium, true},
+		{SeverityLow, SeverityMedium, false},
+		{"", SeverityLow, false},
+	}
+
+	for _, tt := range tests {
+		if got := SeverityAtLeast(tt.s, tt.threshold); got != tt.want {
+			t.Errorf("SeverityAtLeast(%q, %q) = %v, want %v", tt.s, tt.threshold, got, tt.want)
+		}
+	}
+}
+
+func TestDetector_Weights(t *testing.T) {
+	// Twice the size threshold: the size strategy fires at strength 0.75.
+	pair := &git.CommitPair{
+		Previous:  &git.Commit{Hash: "parent"},
+		Current:   &git.Commit{Hash: "abc1234", Message: "Rework parser"},
+		TimeDelta: 2 * time.Hour,
+		Stats:     &git.DiffStats{Additions: 200, Deletions: 0, FilesChanged: 1},
+	}
+	thresholds := func(weights map[string]float64) *Thresholds {
+		return &Thresholds{SuspiciousAdditions: 100, Fingerprints: []Fingerprint{}, Weights: weights}
+	}
+
+	t.Run("default weight", func(t *testing.T) {
+		d, err := New(thresholds(nil))
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		result := d.DetectSuspicious([]*git.CommitPair{pair}, nil)
+		if len(result) != 1 {
+			t.Fatalf("len(result) = %d, want 1", len(result))
+		}
+		want := DefaultWeights()["size_analysis"] * 0.75
+		if math.Abs(result[0].Score-want) > 1e-9 {
+			t.Errorf("Score = %v, want %v", result[0].Score, want)
+		}
+		if result[0].Severity != SeverityLow {
+			t.Errorf("Severity = %q, want %q", result[0].Severity, SeverityLow)
+		}
+	})
+
+	t.Run("configured weight", func(t *testing.T) {
+		d, err := New(thresholds(map[string]float64{"size_analysis": 1}))
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		result := d.DetectSuspicious([]*git.CommitPair{pair}, nil)
+		if len(result) != 1 {
+			t.Fatalf("len(result) = %d, want 1", len(result))
+		}
+		if math.Abs(result[0].Score-0.75) > 1e-9 {
+			t.Errorf("Score = %v, want 0.75", result[0].Score)
+		}
+		if result[0].Severity != SeverityHigh {
+			t.Errorf("Severity = %q, want %q", result[0].Severity, SeverityHigh)
+		}
+	})
+
+	t.Run("zero weight keeps reasons", func(t *testing.T) {
+		d, err := New(thresholds(map[string]float64{"size_analysis": 0}))
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		result := d.DetectSuspicious([]*git.CommitPair{pair}, nil)
+		if len(result) != 1 {
+			t.Fatalf("len(result) = %d, want 1", len(result))
+		}
+		if result[0].Score != 0 || len(result[0].Reasons) == 0 {
+			t.Errorf("Score = %v, Reasons = %v; want 0 with reasons", result[0].Score, result[0].Reasons)
+		}
+	})
+}
+
+func TestDefaultWeights_NameStrategies(t *testing.T) {
+	if err := validateWeights(DefaultWeights()); err != nil {
+		t.Errorf("validateWeights(DefaultWeights()) error = %v", err)
+	}
+}
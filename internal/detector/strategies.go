@@ -3,12 +3,25 @@ package detector
 import (
 	"github.com/TryCadence/Cadence/internal/detector/patterns"
 	"github.com/TryCadence/Cadence/internal/git"
+	"github.com/TryCadence/Cadence/internal/langmodel"
 	"github.com/TryCadence/Cadence/internal/metrics"
 )
 
+// DetectionStrategy is one heuristic. Detect returns a Finding when the
+// strategy fires and nil otherwise; the detector weights and combines the
+// findings' confidences into the commit score.
 type DetectionStrategy interface {
 	Name() string
-	Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (bool, string)
+	Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) *Finding
+}
+
+// RepositoryStrategy is a heuristic that needs the whole history at once.
+// It receives the pairs oldest first and scopes each finding to a commit,
+// an author or a window of time. Commit findings join that commit's
+// per-pair findings in the score; the others are reported on their own.
+type RepositoryStrategy interface {
+	Name() string
+	DetectRepository(pairs []*git.CommitPair, repoStats *metrics.RepositoryStats) []Finding
 }
 
 type VelocityStrategy = patterns.VelocityStrategy
@@ -25,9 +38,36 @@ type StructuralConsistencyStrategy = patterns.StructuralConsistencyStrategy
 type BurstPatternStrategy = patterns.BurstPatternStrategy
 type ErrorHandlingPatternStrategy = patterns.ErrorHandlingPatternStrategy
 type TemplatePatternStrategy = patterns.TemplatePatternStrategy
+type GoSourceStrategy = patterns.GoSourceStrategy
+type NearCloneStrategy = patterns.NearCloneStrategy
+type LanguageModelStrategy = patterns.LanguageModelStrategy
 type FileExtensionPatternStrategy = patterns.FileExtensionPatternStrategy
 type StatisticalAnomalyStrategy = patterns.StatisticalAnomalyStrategy
 type TimingAnomalyStrategy = patterns.TimingAnomalyStrategy
+type EntropyAnomalyStrategy = patterns.EntropyAnomalyStrategy
+type FileDispersionAnomalyStrategy = patterns.FileDispersionAnomalyStrategy
+type TimingClusterStrategy = patterns.TimingClusterStrategy
+type AuthorBehaviorStrategy = patterns.AuthorBehaviorStrategy
+type CommitGapStrategy = patterns.CommitGapStrategy
+type UniformBurstStrategy = patterns.UniformBurstStrategy
+type CadenceShiftStrategy = patterns.CadenceShiftStrategy
+type StyleDriftStrategy = patterns.StyleDriftStrategy
+type FingerprintStrategy = patterns.FingerprintStrategy
+type EmojiPatternStrategy = patterns.EmojiPatternStrategy
+type SpecialCharacterPatternStrategy = patterns.SpecialCharacterPatternStrategy
+
+type Fingerprint = patterns.Fingerprint
+type Finding = patterns.Finding
+type Location = patterns.Location
+
+// Scope says whether a finding concerns a commit, an author or a window.
+type Scope = patterns.Scope
+
+const (
+	ScopeCommit = patterns.ScopeCommit
+	ScopeAuthor = patterns.ScopeAuthor
+	ScopeWindow = patterns.ScopeWindow
+)
 
 func NewVelocityStrategy(additionsPerMin, deletionsPerMin float64) *VelocityStrategy {
 	return patterns.NewVelocityStrategy(additionsPerMin, deletionsPerMin)
@@ -53,32 +93,44 @@ func NewRatioStrategy(maxAdd, minDel float64, minSize int64) *RatioStrategy {
 	return patterns.NewRatioStrategy(maxAdd, minDel, minSize)
 }
 
-func NewPrecisionStrategy(consistency float64) *PrecisionStrategy {
-	return patterns.NewPrecisionStrategy(consistency)
+func NewPrecisionStrategy() *PrecisionStrategy {
+	return patterns.NewPrecisionStrategy()
 }
 
 func NewCommitMessageStrategy() *CommitMessageStrategy {
 	return patterns.NewCommitMessageStrategy()
 }
 
-func NewNamingPatternStrategy() *NamingPatternStrategy {
-	return patterns.NewNamingPatternStrategy()
+func NewNamingPatternStrategy(skipGo bool) *NamingPatternStrategy {
+	return patterns.NewNamingPatternStrategy(skipGo)
 }
 
 func NewStructuralConsistencyStrategy() *StructuralConsistencyStrategy {
 	return patterns.NewStructuralConsistencyStrategy()
 }
 
-func NewBurstPatternStrategy(maxPerHour int) *BurstPatternStrategy {
-	return patterns.NewBurstPatternStrategy(maxPerHour)
+func NewBurstPatternStrategy() *BurstPatternStrategy {
+	return patterns.NewBurstPatternStrategy()
 }
 
-func NewErrorHandlingPatternStrategy() *ErrorHandlingPatternStrategy {
-	return patterns.NewErrorHandlingPatternStrategy()
+func NewErrorHandlingPatternStrategy(skipGo bool) *ErrorHandlingPatternStrategy {
+	return patterns.NewErrorHandlingPatternStrategy(skipGo)
 }
 
-func NewTemplatePatternStrategy() *TemplatePatternStrategy {
-	return patterns.NewTemplatePatternStrategy()
+func NewTemplatePatternStrategy(skipGo bool) *TemplatePatternStrategy {
+	return patterns.NewTemplatePatternStrategy(skipGo)
+}
+
+func NewGoSourceStrategy() *GoSourceStrategy {
+	return patterns.NewGoSourceStrategy()
+}
+
+func NewNearCloneStrategy() *NearCloneStrategy {
+	return patterns.NewNearCloneStrategy()
+}
+
+func NewLanguageModelStrategy(model *langmodel.Model) *LanguageModelStrategy {
+	return patterns.NewLanguageModelStrategy(model)
 }
 
 func NewFileExtensionPatternStrategy() *FileExtensionPatternStrategy {
@@ -92,3 +144,51 @@ func NewStatisticalAnomalyStrategy() *StatisticalAnomalyStrategy {
 func NewTimingAnomalyStrategy() *TimingAnomalyStrategy {
 	return patterns.NewTimingAnomalyStrategy()
 }
+
+func NewEntropyAnomalyStrategy() *EntropyAnomalyStrategy {
+	return patterns.NewEntropyAnomalyStrategy()
+}
+
+func NewFileDispersionAnomalyStrategy() *FileDispersionAnomalyStrategy {
+	return patterns.NewFileDispersionAnomalyStrategy()
+}
+
+func NewTimingClusterStrategy() *TimingClusterStrategy {
+	return patterns.NewTimingClusterStrategy()
+}
+
+func NewAuthorBehaviorStrategy() *AuthorBehaviorStrategy {
+	return patterns.NewAuthorBehaviorStrategy()
+}
+
+func NewCommitGapStrategy() *CommitGapStrategy {
+	return patterns.NewCommitGapStrategy()
+}
+
+func NewUniformBurstStrategy() *UniformBurstStrategy {
+	return patterns.NewUniformBurstStrategy()
+}
+
+func NewCadenceShiftStrategy() *CadenceShiftStrategy {
+	return patterns.NewCadenceShiftStrategy()
+}
+
+func NewStyleDriftStrategy() *StyleDriftStrategy {
+	return patterns.NewStyleDriftStrategy()
+}
+
+func NewEmojiPatternStrategy() *EmojiPatternStrategy {
+	return patterns.NewEmojiPatternStrategy()
+}
+
+func NewSpecialCharacterPatternStrategy() *SpecialCharacterPatternStrategy {
+	return patterns.NewSpecialCharacterPatternStrategy()
+}
+
+func NewFingerprintStrategy(fingerprints []Fingerprint) *FingerprintStrategy {
+	return patterns.NewFingerprintStrategy(fingerprints)
+}
+
+func DefaultFingerprints() []Fingerprint {
+	return patterns.DefaultFingerprints()
+}
@@ -0,0 +1,319 @@
+package detector
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/TryCadence/Cadence/internal/detector/patterns"
+)
+
+type Tunable = patterns.Tunable
+
+// StrategyConfig switches one strategy on or off and overrides its
+// parameters. A nil Enabled keeps the strategy's default.
+type StrategyConfig struct {
+	Enabled *bool
+	Params  map[string]float64
+}
+
+// strategySpec describes how New builds a strategy and whether it runs
+// when the configuration does not say. Exactly one of build and
+// buildRepository is set.
+type strategySpec struct {
+	name             string
+	enabledByDefault func(t *Thresholds) bool
+	build            func(t *Thresholds) DetectionStrategy
+	buildRepository  func(t *Thresholds) RepositoryStrategy
+}
+
+// namedStrategy is what per-pair and repository strategies share.
+type namedStrategy interface {
+	Name() string
+}
+
+func (spec strategySpec) instance(t *Thresholds) namedStrategy {
+	if spec.buildRepository != nil {
+		return spec.buildRepository(t)
+	}
+	return spec.build(t)
+}
+
+func (spec strategySpec) enabled(t *Thresholds) bool {
+	if enabled := t.Strategies[spec.name].Enabled; enabled != nil {
+		return *enabled
+	}
+	return spec.enabledByDefault(t)
+}
+
+func always(*Thresholds) bool { return true }
+
+// strategySpecs lists every strategy New can build, in detection order.
+// Threshold-driven strategies take their parameters from Thresholds and run
+// when those are set; the heuristics run by default and expose their
+// parameters through Tunable; opt-in strategies only run when enabled.
+// Repository strategies run once over the whole history.
+func strategySpecs() []strategySpec {
+	return []strategySpec{
+		{
+			name:             "size_analysis",
+			enabledByDefault: func(t *Thresholds) bool { return t.SuspiciousAdditions > 0 || t.SuspiciousDeletions > 0 },
+			build: func(t *Thresholds) DetectionStrategy {
+				return NewSizeStrategy(t.SuspiciousAdditions, t.SuspiciousDeletions)
+			},
+		},
+		{
+			name:             "velocity_analysis",
+			enabledByDefault: func(t *Thresholds) bool { return t.MaxAdditionsPerMin > 0 || t.MaxDeletionsPerMin > 0 },
+			build: func(t *Thresholds) DetectionStrategy {
+				return NewVelocityStrategy(t.MaxAdditionsPerMin, t.MaxDeletionsPerMin)
+			},
+		},
+		{
+			name:             "timing_analysis",
+			enabledByDefault: func(t *Thresholds) bool { return t.MinTimeDeltaSeconds > 0 },
+			build:            func(t *Thresholds) DetectionStrategy { return NewTimingStrategy(t.MinTimeDeltaSeconds) },
+		},
+		{
+			name:             "file_dispersion_analysis",
+			enabledByDefault: func(t *Thresholds) bool { return t.MaxFilesPerCommit > 0 },
+			build:            func(t *Thresholds) DetectionStrategy { return NewDispersionStrategy(t.MaxFilesPerCommit) },
+		},
+		{
+			name:             "ratio_analysis",
+			enabledByDefault: func(t *Thresholds) bool { return t.MaxAdditionRatio > 0 || t.MinDeletionRatio > 0 },
+			build: func(t *Thresholds) DetectionStrategy {
+				return NewRatioStrategy(t.MaxAdditionRatio, t.MinDeletionRatio, t.MinCommitSizeRatio)
+			},
+		},
+		{
+			name:             "precision_analysis",
+			enabledByDefault: func(t *Thresholds) bool { return t.EnablePrecisionAnalysis },
+			build:            func(*Thresholds) DetectionStrategy { return NewPrecisionStrategy() },
+		},
+		{
+			name:             "commit_message_analysis",
+			enabledByDefault: always,
+			build:            func(*Thresholds) DetectionStrategy { return NewCommitMessageStrategy() },
+		},
+		{
+			name:             "naming_pattern_analysis",
+			enabledByDefault: always,
+			build: func(t *Thresholds) DetectionStrategy {
+				return NewNamingPatternStrategy(t.StrategyEnabled("go_source_analysis"))
+			},
+		},
+		{
+			name:             "structural_consistency_analysis",
+			enabledByDefault: always,
+			build:            func(*Thresholds) DetectionStrategy { return NewStructuralConsistencyStrategy() },
+		},
+		{
+			name:             "burst_pattern_analysis",
+			enabledByDefault: always,
+			build:            func(*Thresholds) DetectionStrategy { return NewBurstPatternStrategy() },
+		},
+		{
+			name:             "error_handling_analysis",
+			enabledByDefault: always,
+			build: func(t *Thresholds) DetectionStrategy {
+				return NewErrorHandlingPatternStrategy(t.StrategyEnabled("go_source_analysis"))
+			},
+		},
+		{
+			name:             "template_pattern_analysis",
+			enabledByDefault: always,
+			build: func(t *Thresholds) DetectionStrategy {
+				return NewTemplatePatternStrategy(t.StrategyEnabled("go_source_analysis"))
+			},
+		},
+		{
+			name:             "go_source_analysis",
+			enabledByDefault: always,
+			build:            func(*Thresholds) DetectionStrategy { return NewGoSourceStrategy() },
+		},
+		{
+			name:             "near_clone_analysis",
+			enabledByDefault: always,
+			build:            func(*Thresholds) DetectionStrategy { return NewNearCloneStrategy() },
+		},
+		{
+			name:             "language_model_analysis",
+			enabledByDefault: func(t *Thresholds) bool { return t.LanguageModel != nil },
+			build:            func(t *Thresholds) DetectionStrategy { return NewLanguageModelStrategy(t.LanguageModel) },
+		},
+		{
+			name:             "file_extension_analysis",
+			enabledByDefault: always,
+			build:            func(*Thresholds) DetectionStrategy { return NewFileExtensionPatternStrategy() },
+		},
+		{
+			name:             "StatisticalAnomaly",
+			enabledByDefault: always,
+			build:            func(*Thresholds) DetectionStrategy { return NewStatisticalAnomalyStrategy() },
+		},
+		{
+			name:             "TimingAnomaly",
+			enabledByDefault: always,
+			build:            func(*Thresholds) DetectionStrategy { return NewTimingAnomalyStrategy() },
+		},
+		{
+			name:             "entropy_anomaly",
+			enabledByDefault: always,
+			build:            func(*Thresholds) DetectionStrategy { return NewEntropyAnomalyStrategy() },
+		},
+		{
+			name:             "file_dispersion_anomaly",
+			enabledByDefault: always,
+			build:            func(*Thresholds) DetectionStrategy { return NewFileDispersionAnomalyStrategy() },
+		},
+		{
+			name:             "emoji_pattern_analysis",
+			enabledByDefault: always,
+			build:            func(*Thresholds) DetectionStrategy { return NewEmojiPatternStrategy() },
+		},
+		{
+			name:             "special_character_pattern_analysis",
+			enabledByDefault: always,
+			build:            func(*Thresholds) DetectionStrategy { return NewSpecialCharacterPatternStrategy() },
+		},
+		{
+			name:             "ai_tool_fingerprint",
+			enabledByDefault: func(t *Thresholds) bool { return t.Fingerprints == nil || len(t.Fingerprints) > 0 },
+			build: func(t *Thresholds) DetectionStrategy {
+				fingerprints := t.Fingerprints
+				if fingerprints == nil {
+					fingerprints = DefaultFingerprints()
+				}
+				return NewFingerprintStrategy(fingerprints)
+			},
+		},
+		{
+			name:             "timing_cluster",
+			enabledByDefault: always,
+			buildRepository:  func(*Thresholds) RepositoryStrategy { return NewTimingClusterStrategy() },
+		},
+		{
+			name:             "author_behavior_change",
+			enabledByDefault: always,
+			buildRepository:  func(*Thresholds) RepositoryStrategy { return NewAuthorBehaviorStrategy() },
+		},
+		{
+			name:             "commit_gap_anomaly",
+			enabledByDefault: always,
+			buildRepository:  func(*Thresholds) RepositoryStrategy { return NewCommitGapStrategy() },
+		},
+		{
+			name:             "uniform_commit_burst",
+			enabledByDefault: always,
+			buildRepository:  func(*Thresholds) RepositoryStrategy { return NewUniformBurstStrategy() },
+		},
+		{
+			name:             "cadence_shift",
+			enabledByDefault: always,
+			buildRepository:  func(*Thresholds) RepositoryStrategy { return NewCadenceShiftStrategy() },
+		},
+		{
+			name:             "author_style_drift",
+			enabledByDefault: always,
+			buildRepository:  func(*Thresholds) RepositoryStrategy { return NewStyleDriftStrategy() },
+		},
+	}
+}
+
+// StrategyNames lists the names accepted under strategies and
+// scoring.weights.
+func StrategyNames() []string {
+	names := make([]string, 0)
+	for _, spec := range strategySpecs() {
+		names = append(names, spec.name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// StrategyEnabled reports whether New runs the named strategy: as
+// configured under strategies, else by its default.
+func (t *Thresholds) StrategyEnabled(name string) bool {
+	for _, spec := range strategySpecs() {
+		if spec.name == name {
+			return spec.enabled(t)
+		}
+	}
+	return false
+}
+
+// buildStrategies constructs the enabled per-pair and repository
+// strategies and applies their configured parameters.
+func buildStrategies(t *Thresholds) ([]DetectionStrategy, []RepositoryStrategy, error) {
+	strategies := make([]DetectionStrategy, 0)
+	repoStrategies := make([]RepositoryStrategy, 0)
+	for _, spec := range strategySpecs() {
+		if !spec.enabled(t) {
+			continue
+		}
+		cfg := t.Strategies[spec.name]
+
+		strategy := spec.instance(t)
+		if err := applyParams(strategy, cfg.Params); err != nil {
+			return nil, nil, err
+		}
+		switch s := strategy.(type) {
+		case RepositoryStrategy:
+			repoStrategies = append(repoStrategies, s)
+		case DetectionStrategy:
+			strategies = append(strategies, s)
+		}
+	}
+	return strategies, repoStrategies, nil
+}
+
+func applyParams(strategy namedStrategy, params map[string]float64) error {
+	if len(params) == 0 {
+		return nil
+	}
+	tunable, ok := strategy.(Tunable)
+	if !ok {
+		return fmt.Errorf("strategy %s has no tunable parameters", strategy.Name())
+	}
+
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := tunable.SetParam(name, params[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateStrategies rejects unknown strategy names and parameters the
+// strategy does not accept.
+func validateStrategies(t *Thresholds) error {
+	specs := make(map[string]strategySpec)
+	for _, spec := range strategySpecs() {
+		specs[spec.name] = spec
+	}
+
+	names := make([]string, 0, len(t.Strategies))
+	for name := range t.Strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		spec, ok := specs[name]
+		if !ok {
+			return fmt.Errorf("unknown strategy %q in strategies", name)
+		}
+		if err := applyParams(spec.instance(t), t.Strategies[name].Params); err != nil {
+			return err
+		}
+		if enabled := t.Strategies[name].Enabled; name == "language_model_analysis" && enabled != nil && *enabled && t.LanguageModel == nil {
+			return fmt.Errorf("strategy %s is enabled but no language_model is configured", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package detector
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/git"
+	"github.com/TryCadence/Cadence/internal/gosource"
+)
+
+func strategyNames(d *Detector) map[string]bool {
+	names := make(map[string]bool)
+	for _, s := range d.strategies {
+		names[s.Name()] = true
+	}
+	return names
+}
+
+func TestNew_Strategies(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		d, err := New(&Thresholds{SuspiciousAdditions: 100})
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		names := strategyNames(d)
+		for _, want := range []string{"size_analysis", "commit_message_analysis", "burst_pattern_analysis", "TimingAnomaly", "ai_tool_fingerprint", "emoji_pattern_analysis", "special_character_pattern_analysis"} {
+			if !names[want] {
+				t.Errorf("strategy %s missing by default", want)
+			}
+		}
+		for _, unwanted := range []string{"velocity_analysis", "language_model_analysis"} {
+			if names[unwanted] {
+				t.Errorf("strategy %s enabled by default", unwanted)
+			}
+		}
+	})
+
+	t.Run("enable and disable", func(t *testing.T) {
+		d, err := New(&Thresholds{
+			SuspiciousAdditions: 100,
+			Strategies: map[string]StrategyConfig{
+				"size_analysis":           {Enabled: boolPtr(false)},
+				"commit_message_analysis": {Enabled: boolPtr(false)},
+				"emoji_pattern_analysis":  {Enabled: boolPtr(false)},
+				"velocity_analysis":       {Enabled: boolPtr(true)},
+			},
+		})
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		names := strategyNames(d)
+		if names["size_analysis"] || names["commit_message_analysis"] || names["emoji_pattern_analysis"] {
+			t.Errorf("disabled strategies still present: %v", names)
+		}
+		if !names["velocity_analysis"] {
+			t.Errorf("enabled velocity_analysis missing: %v", names)
+		}
+	})
+}
+
+func TestDetector_StrategyParams(t *testing.T) {
+	// 200 seconds apart with 80 additions: inside the default burst window.
+	pair := &git.CommitPair{
+		Previous:  &git.Commit{Hash: "parent"},
+		Current:   &git.Commit{Hash: "abc1234", Message: "Rework parser"},
+		TimeDelta: 200 * time.Second,
+		Stats:     &git.DiffStats{Additions: 80, Deletions: 0, FilesChanged: 1},
+	}
+	hasBurst := func(params map[string]float64) bool {
+		d, err := New(&Thresholds{
+			SuspiciousAdditions: 100000,
+			Strategies:          map[string]StrategyConfig{"burst_pattern_analysis": {Params: params}},
+		})
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		for _, s := range d.DetectSuspicious([]*git.CommitPair{pair}, nil) {
+			for _, f := range s.Findings {
+				if f.Strategy == "burst_pattern_analysis" {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	if !hasBurst(nil) {
+		t.Error("burst pattern not detected with default parameters")
+	}
+	if hasBurst(map[string]float64{"window_seconds": 120}) {
+		t.Error("burst pattern detected outside a 120 second window")
+	}
+	if hasBurst(map[string]float64{"min_additions": 100}) {
+		t.Error("burst pattern detected below min_additions")
+	}
+}
+
+func TestDetector_GoLinesFollowGoSourceAnalysis(t *testing.T) {
+	lines := []string{"diff --git a/main.go b/main.go", "--- a/main.go", "+++ b/main.go", "@@ -0,0 +1,30 @@"}
+	for i := 0; i < 30; i++ {
+		lines = append(lines, fmt.Sprintf("+\tif err := handle(%d); err != nil { return err }", i))
+	}
+	pair := &git.CommitPair{
+		Previous:    &git.Commit{Hash: "parent"},
+		Current:     &git.Commit{Hash: "abc1234", Message: "Add handlers"},
+		TimeDelta:   2 * time.Hour,
+		Stats:       &git.DiffStats{Additions: 60, FilesChanged: 1, Go: &gosource.Signals{Files: 1}},
+		DiffContent: strings.Join(lines, "\n"),
+	}
+	hasErrorHandling := func(goSource bool) bool {
+		d, err := New(&Thresholds{
+			SuspiciousAdditions: 100000,
+			Strategies:          map[string]StrategyConfig{"go_source_analysis": {Enabled: boolPtr(goSource)}},
+		})
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		for _, s := range d.DetectSuspicious([]*git.CommitPair{pair}, nil) {
+			for _, f := range s.Findings {
+				if f.Strategy == "error_handling_analysis" {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	if hasErrorHandling(true) {
+		t.Error("error handling judged on Go lines left to go_source_analysis")
+	}
+	if !hasErrorHandling(false) {
+		t.Error("error handling skipped Go lines with go_source_analysis disabled")
+	}
+}
+
+func TestDetector_RepositoryStrategies(t *testing.T) {
+	pairs := make([]*git.CommitPair, 0, 12)
+	for i := 0; i < 11; i++ {
+		pairs = append(pairs, &git.CommitPair{
+			Previous:  &git.Commit{Hash: fmt.Sprintf("p%d", i)},
+			Current:   &git.Commit{Hash: fmt.Sprintf("c%d", i), Email: "dev@example.com", Message: "fix parser"},
+			TimeDelta: 3 * time.Hour,
+			Stats:     &git.DiffStats{Additions: int64(20 + i), Deletions: 5, FilesChanged: 1},
+		})
+	}
+	pairs = append(pairs, &git.CommitPair{
+		Previous:  &git.Commit{Hash: "p-huge"},
+		Current:   &git.Commit{Hash: "huge", Email: "dev@example.com", Message: "fix parser"},
+		TimeDelta: 3 * time.Hour,
+		Stats:     &git.DiffStats{Additions: 1500, Deletions: 900, FilesChanged: 1},
+	})
+
+	t.Run("findings join the commit they name", func(t *testing.T) {
+		d, err := New(&Thresholds{SuspiciousAdditions: 100000})
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		result := d.DetectSuspicious(pairs, nil)
+		if len(result) != 1 || result[0].Pair.Current.Hash != "huge" {
+			t.Fatalf("DetectSuspicious() = %d commits, want only huge", len(result))
+		}
+
+		var found *Finding
+		for i, f := range result[0].Findings {
+			if f.Strategy == "author_behavior_change" {
+				found = &result[0].Findings[i]
+			}
+		}
+		if found == nil {
+			t.Fatalf("author_behavior_change missing from %v", result[0].Reasons)
+		}
+		if found.Commit != "huge" || found.Severity == "" {
+			t.Errorf("Commit, Severity = %q, %q, want huge and a severity", found.Commit, found.Severity)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		d, err := New(&Thresholds{SuspiciousAdditions: 100000, Strategies: map[string]StrategyConfig{
+			"author_behavior_change": {Enabled: boolPtr(false)},
+		}})
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		for _, s := range d.DetectSuspicious(pairs, nil) {
+			for _, f := range s.Findings {
+				if f.Strategy == "author_behavior_change" {
+					t.Errorf("disabled strategy reported %q", f.Message)
+				}
+			}
+		}
+	})
+}
+
+func TestDetector_Detect(t *testing.T) {
+	start := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	pairs := make([]*git.CommitPair, 0, 12)
+	// Newest first, as the repository returns them.
+	for i := 11; i >= 0; i-- {
+		pairs = append(pairs, &git.CommitPair{
+			Previous:  &git.Commit{Hash: fmt.Sprintf("p%d", i)},
+			Current:   &git.Commit{Hash: fmt.Sprintf("c%d", i), Email: "bot@example.com", Timestamp: start.Add(time.Duration(i) * 30 * time.Second)},
+			TimeDelta: 30 * time.Second,
+			Stats:     &git.DiffStats{Additions: 40, FilesChanged: 1},
+		})
+	}
+
+	d, err := New(&Thresholds{SuspiciousAdditions: 100000, Fingerprints: []Fingerprint{}})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	result := d.Detect(pairs, nil)
+
+	if len(result.Windows) != 1 {
+		t.Fatalf("len(Windows) = %d, want 1", len(result.Windows))
+	}
+	w := result.Windows[0]
+	if w.Strategy != "uniform_commit_burst" || w.Scope != ScopeWindow || w.Severity == "" {
+		t.Errorf("Strategy, Scope, Severity = %q, %q, %q", w.Strategy, w.Scope, w.Severity)
+	}
+	if len(w.Commits) != 12 || w.Commits[0] != "c0" || !w.Start.Equal(start) {
+		t.Errorf("Commits, Start = %v, %v, want oldest first from %v", w.Commits, w.Start, start)
+	}
+	if len(result.Authors) != 0 {
+		t.Errorf("Authors = %v, want none", result.Authors)
+	}
+	for _, s := range result.Suspicious {
+		for _, f := range s.Findings {
+			if f.Strategy == "uniform_commit_burst" {
+				t.Errorf("window finding attached to commit %s", s.Pair.Current.Hash)
+			}
+		}
+	}
+}
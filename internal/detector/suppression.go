@@ -0,0 +1,187 @@
+package detector
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/git"
+)
+
+// Suppression clears findings a reviewer has looked at. It matches a
+// finding when every field it sets matches: Commit is a hash or a prefix
+// of at least 7 characters, Author the commit or finding author's email or
+// name, Path a gitignore-style pattern every file the finding points at
+// must match, and Strategy the finding's strategy. Reason is required and
+// kept with each finding it clears.
+//
+// Expires is an optional YYYY-MM-DD date. The suppression applies through
+// that day and is reported as expired afterwards. Source says where the
+// suppression was declared.
+type Suppression struct {
+	Commit   string
+	Author   string
+	Path     string
+	Strategy string
+	Reason   string
+	Expires  string
+	Source   string
+
+	expires time.Time
+	paths   *git.ExcludeMatcher
+}
+
+// SuppressedFinding is a finding a suppression cleared, kept for the audit
+// trail. Pair is nil for author and window findings.
+type SuppressedFinding struct {
+	Pair        *git.CommitPair
+	Finding     Finding
+	Suppression Suppression
+}
+
+var commitPrefixPattern = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// Validate checks the suppression and compiles its expiry and path.
+func (s *Suppression) Validate() error {
+	if s.Commit == "" && s.Author == "" && s.Path == "" && s.Strategy == "" {
+		return fmt.Errorf("suppression must set at least one of commit, author, path or strategy")
+	}
+	if strings.TrimSpace(s.Reason) == "" {
+		return fmt.Errorf("suppression of %s is missing a reason", s.Describe())
+	}
+
+	s.Commit = strings.ToLower(s.Commit)
+	if s.Commit != "" && !commitPrefixPattern.MatchString(s.Commit) {
+		return fmt.Errorf("suppression commit %q must be a hash of at least 7 characters", s.Commit)
+	}
+
+	if s.Strategy != "" && !isStrategyName(s.Strategy) {
+		return fmt.Errorf("suppression of %s: unknown strategy %q", s.Describe(), s.Strategy)
+	}
+
+	if s.Path != "" {
+		paths, err := git.NewExcludeMatcher([]string{s.Path})
+		if err != nil {
+			return fmt.Errorf("suppression of %s: invalid path: %w", s.Describe(), err)
+		}
+		s.paths = paths
+	}
+
+	if s.Expires != "" {
+		expires, err := time.Parse("2006-01-02", s.Expires)
+		if err != nil {
+			return fmt.Errorf("suppression of %s: expires must be a YYYY-MM-DD date: %w", s.Describe(), err)
+		}
+		s.expires = expires
+	}
+	return nil
+}
+
+// Describe names what the suppression matches, such as
+// "commit abc1234, strategy emoji_pattern_analysis".
+func (s *Suppression) Describe() string {
+	var parts []string
+	for _, field := range []struct{ name, value string }{
+		{"commit", s.Commit},
+		{"author", s.Author},
+		{"path", s.Path},
+		{"strategy", s.Strategy},
+	} {
+		if field.value != "" {
+			parts = append(parts, field.name+" "+field.value)
+		}
+	}
+	if len(parts) == 0 {
+		return "nothing"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Expired reports whether the suppression's last day has passed at now.
+func (s *Suppression) Expired(now time.Time) bool {
+	return !s.expires.IsZero() && !now.Before(s.expires.AddDate(0, 0, 1))
+}
+
+func isStrategyName(name string) bool {
+	for _, n := range StrategyNames() {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether the suppression clears a finding. Pair is nil
+// for author and window findings, which a commit or path never matches.
+func (s *Suppression) matches(pair *git.CommitPair, f Finding) bool {
+	if s.Strategy != "" && s.Strategy != f.Strategy {
+		return false
+	}
+
+	if s.Commit != "" {
+		if pair == nil || !strings.HasPrefix(strings.ToLower(pair.Current.Hash), s.Commit) {
+			return false
+		}
+	}
+
+	if s.Author != "" {
+		var identities []string
+		if pair != nil {
+			identities = append(identities, pair.Current.Email, pair.Current.Author)
+		}
+		if f.Author != "" {
+			identities = append(identities, f.Author)
+		}
+		matched := false
+		for _, identity := range identities {
+			if strings.EqualFold(identity, s.Author) {
+				matched = true
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if s.paths != nil {
+		files := findingFiles(pair, f)
+		if len(files) == 0 {
+			return false
+		}
+		for _, file := range files {
+			if !s.paths.Match(file) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// findingFiles returns the files a finding points at, or every file its
+// commit changed when it points at none.
+func findingFiles(pair *git.CommitPair, f Finding) []string {
+	var files []string
+	for _, loc := range f.Locations {
+		files = append(files, loc.File)
+	}
+	if len(files) == 0 && f.File != "" {
+		files = append(files, f.File)
+	}
+	if len(files) == 0 && pair != nil && pair.Stats != nil {
+		for _, file := range pair.Stats.Files {
+			files = append(files, file.Path)
+		}
+	}
+	return files
+}
+
+// suppress returns the first active suppression clearing a finding, or nil.
+func suppress(suppressions []Suppression, pair *git.CommitPair, f Finding) *Suppression {
+	for i := range suppressions {
+		if suppressions[i].matches(pair, f) {
+			return &suppressions[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package detector
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/git"
+)
+
+func TestSuppression_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		suppression Suppression
+		wantErr     string
+	}{
+		{"commit", Suppression{Commit: "ABC1234", Reason: "reviewed"}, ""},
+		{"author and strategy", Suppression{Author: "bot@example.com", Strategy: "emoji_pattern_analysis", Reason: "bot"}, ""},
+		{"path with expiry", Suppression{Path: "docs/**", Reason: "prose", Expires: "2030-01-31"}, ""},
+		{"nothing matched", Suppression{Reason: "reviewed"}, "at least one of"},
+		{"missing reason", Suppression{Commit: "abc1234"}, "missing a reason"},
+		{"short commit", Suppression{Commit: "abc12", Reason: "reviewed"}, "at least 7 characters"},
+		{"unknown strategy", Suppression{Strategy: "vibes", Reason: "reviewed"}, "unknown strategy"},
+		{"bad expiry", Suppression{Commit: "abc1234", Reason: "reviewed", Expires: "next week"}, "YYYY-MM-DD"},
+		{"bad path", Suppression{Path: "[", Reason: "reviewed"}, "invalid path"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.suppression.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSuppression_Expired(t *testing.T) {
+	s := Suppression{Commit: "abc1234", Reason: "reviewed", Expires: "2026-03-01"}
+	if err := s.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if s.Expired(time.Date(2026, 3, 1, 23, 59, 0, 0, time.UTC)) {
+		t.Error("Expired() on the last day = true, want false")
+	}
+	if !s.Expired(time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Error("Expired() the day after = false, want true")
+	}
+}
+
+func TestSuppression_Matches(t *testing.T) {
+	pair := &git.CommitPair{
+		Current: &git.Commit{Hash: "abc1234def", Author: "Dev", Email: "dev@example.com"},
+		Stats: &git.DiffStats{Files: []git.FileStats{
+			{Path: "docs/guide.md", Additions: 10},
+			{Path: "docs/api.md", Additions: 5},
+		}},
+	}
+	located := Finding{Strategy: "emoji_pattern_analysis", File: "src/main.go", Locations: []Location{{File: "src/main.go"}}}
+	unlocated := Finding{Strategy: "size_analysis"}
+
+	tests := []struct {
+		name        string
+		suppression Suppression
+		pair        *git.CommitPair
+		finding     Finding
+		want        bool
+	}{
+		{"commit prefix", Suppression{Commit: "abc1234"}, pair, unlocated, true},
+		{"other commit", Suppression{Commit: "fff1234"}, pair, unlocated, false},
+		{"author email", Suppression{Author: "DEV@example.com"}, pair, unlocated, true},
+		{"author of an author finding", Suppression{Author: "dev@example.com"}, nil, Finding{Author: "dev@example.com"}, true},
+		{"strategy", Suppression{Strategy: "size_analysis"}, pair, located, false},
+		{"commit and strategy", Suppression{Commit: "abc1234", Strategy: "size_analysis"}, pair, unlocated, true},
+		{"path of located finding", Suppression{Path: "src/"}, pair, located, true},
+		{"path of changed files", Suppression{Path: "docs/**"}, pair, unlocated, true},
+		{"path must match every file", Suppression{Path: "docs/guide.md"}, pair, unlocated, false},
+		{"commit never matches a window", Suppression{Commit: "abc1234"}, nil, Finding{Commits: []string{"abc1234def"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.suppression.Reason = "reviewed"
+			if err := tt.suppression.Validate(); err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			if got := tt.suppression.matches(tt.pair, tt.finding); got != tt.want {
+				t.Errorf("matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetector_Suppressions(t *testing.T) {
+	pair := &git.CommitPair{
+		Previous:  &git.Commit{Hash: "parent"},
+		Current:   &git.Commit{Hash: "abc1234def", Message: "Rework parser"},
+		TimeDelta: 2 * time.Hour,
+		Stats:     &git.DiffStats{Additions: 200, Deletions: 0, FilesChanged: 1},
+	}
+
+	detect := func(suppressions ...Suppression) *Result {
+		t.Helper()
+		d, err := New(&Thresholds{SuspiciousAdditions: 100, Fingerprints: []Fingerprint{}, Suppressions: suppressions})
+		if err != nil {
+			t.Fatalf("New() error = %v", err)
+		}
+		return d.Detect([]*git.CommitPair{pair}, nil)
+	}
+
+	unsuppressed := detect()
+	if len(unsuppressed.Suspicious) != 1 {
+		t.Fatalf("len(Suspicious) = %d, want 1", len(unsuppressed.Suspicious))
+	}
+	findings := len(unsuppressed.Suspicious[0].Findings)
+
+	t.Run("commit", func(t *testing.T) {
+		result := detect(Suppression{Commit: "abc1234", Reason: "reviewed in #12", Source: "cadence.yml"})
+		if len(result.Suspicious) != 0 {
+			t.Errorf("len(Suspicious) = %d, want 0", len(result.Suspicious))
+		}
+		if len(result.Suppressed) != findings {
+			t.Fatalf("len(Suppressed) = %d, want %d", len(result.Suppressed), findings)
+		}
+		if s := result.Suppressed[0]; s.Pair != pair || s.Suppression.Reason != "reviewed in #12" {
+			t.Errorf("Suppressed[0] = %+v", s)
+		}
+	})
+
+	t.Run("strategy", func(t *testing.T) {
+		result := detect(Suppression{Commit: "abc1234", Strategy: "size_analysis", Reason: "intended"})
+		if len(result.Suppressed) != 1 || result.Suppressed[0].Finding.Strategy != "size_analysis" {
+			t.Fatalf("Suppressed = %+v, want the size finding", result.Suppressed)
+		}
+		for _, s := range result.Suspicious {
+			for _, f := range s.Findings {
+				if f.Strategy == "size_analysis" {
+					t.Error("size_analysis finding still counted")
+				}
+			}
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		result := detect(Suppression{Commit: "abc1234", Reason: "reviewed", Expires: "2001-01-01"})
+		if len(result.Suspicious) != 1 || len(result.Suppressed) != 0 {
+			t.Errorf("len(Suspicious), len(Suppressed) = %d, %d, want 1, 0", len(result.Suspicious), len(result.Suppressed))
+		}
+		if len(result.ExpiredSuppressions) != 1 {
+			t.Errorf("len(ExpiredSuppressions) = %d, want 1", len(result.ExpiredSuppressions))
+		}
+	})
+}
@@ -1,6 +1,10 @@
 package detector
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/TryCadence/Cadence/internal/langmodel"
+)
 
 type Thresholds struct {
 	SuspiciousAdditions int64
@@ -18,6 +22,28 @@ type Thresholds struct {
 	MinCommitSizeRatio int64
 
 	EnablePrecisionAnalysis bool
+
+	// Fingerprints are the AI tool signatures looked for in commit
+	// metadata. Nil uses DefaultFingerprints; an empty slice disables the
+	// check.
+	Fingerprints []Fingerprint
+
+	// LanguageModel is the repository's trained n-gram model. Nil leaves
+	// language_model_analysis off.
+	LanguageModel *langmodel.Model
+
+	// Weights overrides DefaultWeights per strategy name. Each weight is
+	// between 0 and 1; 0 keeps the strategy's reasons but drops it from
+	// the score.
+	Weights map[string]float64
+
+	// Strategies switches strategies on or off and tunes their
+	// parameters, keyed by strategy name.
+	Strategies map[string]StrategyConfig
+
+	// Suppressions clear reviewed findings from the score. Cleared findings
+	// are reported apart; expired suppressions no longer apply.
+	Suppressions []Suppression
 }
 
 func (t *Thresholds) Validate() error {
@@ -53,6 +79,26 @@ func (t *Thresholds) Validate() error {
 		return fmt.Errorf("MinDeletionRatio must be between 0.0 and 1.0")
 	}
 
+	for _, f := range t.Fingerprints {
+		if err := f.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := validateWeights(t.Weights); err != nil {
+		return err
+	}
+
+	if err := validateStrategies(t); err != nil {
+		return err
+	}
+
+	for i := range t.Suppressions {
+		if err := t.Suppressions[i].Validate(); err != nil {
+			return err
+		}
+	}
+
 	if t.SuspiciousAdditions == 0 &&
 		t.SuspiciousDeletions == 0 &&
 		t.MaxAdditionsPerMin == 0 &&
@@ -107,6 +107,112 @@ func TestThresholds_Validate(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "invalid fingerprint pattern",
+			thresholds: Thresholds{
+				SuspiciousAdditions: 100,
+				Fingerprints:        []Fingerprint{{Name: "bad", Field: "trailer", Pattern: "(unclosed"}},
+			},
+			expectError:   true,
+			errorContains: "invalid pattern",
+		},
+		{
+			name: "unknown fingerprint field",
+			thresholds: Thresholds{
+				SuspiciousAdditions: 100,
+				Fingerprints:        []Fingerprint{{Name: "bad", Field: "subject", Pattern: "x"}},
+			},
+			expectError:   true,
+			errorContains: "unknown field",
+		},
+		{
+			name: "valid weights",
+			thresholds: Thresholds{
+				SuspiciousAdditions: 100,
+				Weights:             map[string]float64{"velocity_analysis": 0.8, "commit_message_analysis": 0},
+			},
+			expectError: false,
+		},
+		{
+			name: "weight for unknown strategy",
+			thresholds: Thresholds{
+				SuspiciousAdditions: 100,
+				Weights:             map[string]float64{"velocity": 0.8},
+			},
+			expectError:   true,
+			errorContains: "unknown strategy",
+		},
+		{
+			name: "valid strategy config",
+			thresholds: Thresholds{
+				SuspiciousAdditions: 100,
+				Strategies: map[string]StrategyConfig{
+					"commit_message_analysis": {Enabled: boolPtr(false)},
+					"burst_pattern_analysis":  {Params: map[string]float64{"window_seconds": 120}},
+				},
+			},
+			expectError: false,
+		},
+		{
+			name: "unknown strategy",
+			thresholds: Thresholds{
+				SuspiciousAdditions: 100,
+				Strategies:          map[string]StrategyConfig{"burst": {Enabled: boolPtr(false)}},
+			},
+			expectError:   true,
+			errorContains: "unknown strategy",
+		},
+		{
+			name: "language model strategy without a model",
+			thresholds: Thresholds{
+				SuspiciousAdditions: 100,
+				Strategies:          map[string]StrategyConfig{"language_model_analysis": {Enabled: boolPtr(true)}},
+			},
+			expectError:   true,
+			errorContains: "no language_model",
+		},
+		{
+			name: "unknown strategy parameter",
+			thresholds: Thresholds{
+				SuspiciousAdditions: 100,
+				Strategies: map[string]StrategyConfig{
+					"burst_pattern_analysis": {Params: map[string]float64{"window": 120}},
+				},
+			},
+			expectError:   true,
+			errorContains: "unknown parameter",
+		},
+		{
+			name: "negative strategy parameter",
+			thresholds: Thresholds{
+				SuspiciousAdditions: 100,
+				Strategies: map[string]StrategyConfig{
+					"TimingAnomaly": {Params: map[string]float64{"long_gap_hours": -1}},
+				},
+			},
+			expectError:   true,
+			errorContains: "cannot be negative",
+		},
+		{
+			name: "parameters for strategy without any",
+			thresholds: Thresholds{
+				SuspiciousAdditions: 100,
+				Strategies: map[string]StrategyConfig{
+					"size_analysis": {Params: map[string]float64{"additions": 10}},
+				},
+			},
+			expectError:   true,
+			errorContains: "no tunable parameters",
+		},
+		{
+			name: "weight out of range",
+			thresholds: Thresholds{
+				SuspiciousAdditions: 100,
+				Weights:             map[string]float64{"size_analysis": 1.5},
+			},
+			expectError:   true,
+			errorContains: "must be between 0.0 and 1.0",
+		},
 	}
 
 	for _, tt := range tests {
@@ -210,3 +316,7 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,103 @@
+// Package eval measures detection quality against commits labeled as
+// AI-generated or human-written, and fits thresholds and weights to them.
+package eval
+
+import (
+	"github.com/TryCadence/Cadence/internal/detector"
+	"github.com/TryCadence/Cadence/internal/git"
+	"github.com/TryCadence/Cadence/internal/metrics"
+)
+
+// StrategyResult is one strategy's performance. A strategy predicts AI
+// when it fires; its confidence is the score behind ROC-AUC.
+type StrategyResult struct {
+	Name    string
+	Fired   int
+	Metrics Metrics
+}
+
+// Result is the evaluation of a detector against labeled commits.
+type Result struct {
+	AI        int
+	Human     int
+	Unmatched []string
+	// ScoreThreshold is the combined score at or above which a commit is
+	// predicted AI-generated.
+	ScoreThreshold float64
+	Combined       Metrics
+	Strategies     []StrategyResult
+
+	samples []labeledPair
+}
+
+// labeledPair keeps what fitting needs from each labeled commit.
+type labeledPair struct {
+	pair     *git.CommitPair
+	ai       bool
+	score    float64
+	findings map[string]float64
+}
+
+// Run detects over every pair, so repository strategies and baselines see
+// the whole history, and scores only the labeled commits. Labeled commits
+// the detector skips, such as merges, score 0.
+func Run(det *detector.Detector, pairs []*git.CommitPair, repoStats *metrics.RepositoryStats, labels Labels, scoreThreshold float64) *Result {
+	hashes := make([]string, len(pairs))
+	for i, pair := range pairs {
+		hashes[i] = pair.Current.Hash
+	}
+	matched, unmatched := labels.Match(hashes)
+
+	detection := det.Detect(pairs, repoStats)
+	byHash := make(map[string]*detector.SuspiciousCommit, len(detection.Suspicious))
+	for _, s := range detection.Suspicious {
+		byHash[s.Pair.Current.Hash] = s
+	}
+
+	result := &Result{Unmatched: unmatched, ScoreThreshold: scoreThreshold}
+	for _, pair := range pairs {
+		ai, ok := matched[pair.Current.Hash]
+		if !ok {
+			continue
+		}
+		if ai {
+			result.AI++
+		} else {
+			result.Human++
+		}
+
+		sample := labeledPair{pair: pair, ai: ai, findings: make(map[string]float64)}
+		if s, ok := byHash[pair.Current.Hash]; ok {
+			sample.score = s.Score
+			for _, f := range s.Findings {
+				if f.Confidence > sample.findings[f.Strategy] {
+					sample.findings[f.Strategy] = f.Confidence
+				}
+			}
+		}
+		result.samples = append(result.samples, sample)
+	}
+
+	combined := make([]Sample, len(result.samples))
+	for i, s := range result.samples {
+		combined[i] = Sample{Score: s.score, AI: s.ai}
+	}
+	result.Combined = Evaluate(combined, scoreThreshold)
+
+	for _, name := range det.Strategies() {
+		samples := make([]Sample, len(result.samples))
+		fired := 0
+		for i, s := range result.samples {
+			samples[i] = Sample{Score: s.findings[name], AI: s.ai}
+			if s.findings[name] > 0 {
+				fired++
+			}
+		}
+		result.Strategies = append(result.Strategies, StrategyResult{
+			Name:    name,
+			Fired:   fired,
+			Metrics: Evaluate(samples, 0),
+		})
+	}
+	return result
+}
@@ -0,0 +1,310 @@
+package eval
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/TryCadence/Cadence/internal/detector"
+	"github.com/TryCadence/Cadence/internal/git"
+	"github.com/TryCadence/Cadence/internal/metrics"
+)
+
+// FittedValue is a threshold chosen to maximize F1 on the labeled commits
+// when used alone.
+type FittedValue struct {
+	Key   string
+	Value float64
+	F1    float64
+}
+
+// Fit holds thresholds and weights fitted to labeled commits, with the
+// combined metrics before and after applying them.
+type Fit struct {
+	Thresholds []FittedValue
+	Weights    map[string]float64
+	AI         int
+	Human      int
+	Before     Metrics
+	After      Metrics
+}
+
+// thresholdRule ties a thresholds key to the commit metric it limits.
+// Above rules flag values greater than the threshold; the others flag
+// values less than it.
+type thresholdRule struct {
+	key     string
+	above   bool
+	integer bool
+	value   func(pair *git.CommitPair) (float64, bool)
+	apply   func(t *detector.Thresholds, v float64)
+}
+
+func perMinute(lines int64, pair *git.CommitPair) (float64, bool) {
+	if pair.TimeDelta <= 0 {
+		return 0, false
+	}
+	return float64(lines) / pair.TimeDelta.Minutes(), true
+}
+
+var thresholdRules = []thresholdRule{
+	{
+		key: "suspicious_additions", above: true, integer: true,
+		value: func(p *git.CommitPair) (float64, bool) { return float64(p.Stats.Additions), true },
+		apply: func(t *detector.Thresholds, v float64) { t.SuspiciousAdditions = int64(v) },
+	},
+	{
+		key: "suspicious_deletions", above: true, integer: true,
+		value: func(p *git.CommitPair) (float64, bool) { return float64(p.Stats.Deletions), true },
+		apply: func(t *detector.Thresholds, v float64) { t.SuspiciousDeletions = int64(v) },
+	},
+	{
+		key: "max_additions_per_min", above: true,
+		value: func(p *git.CommitPair) (float64, bool) { return perMinute(p.Stats.Additions, p) },
+		apply: func(t *detector.Thresholds, v float64) { t.MaxAdditionsPerMin = v },
+	},
+	{
+		key: "max_deletions_per_min", above: true,
+		value: func(p *git.CommitPair) (float64, bool) { return perMinute(p.Stats.Deletions, p) },
+		apply: func(t *detector.Thresholds, v float64) { t.MaxDeletionsPerMin = v },
+	},
+	{
+		key: "min_time_delta_seconds", integer: true,
+		value: func(p *git.CommitPair) (float64, bool) { return p.TimeDelta.Seconds(), p.TimeDelta > 0 },
+		apply: func(t *detector.Thresholds, v float64) { t.MinTimeDeltaSeconds = int64(v) },
+	},
+	{
+		key: "max_files_per_commit", above: true, integer: true,
+		value: func(p *git.CommitPair) (float64, bool) { return float64(p.Stats.FilesChanged), true },
+		apply: func(t *detector.Thresholds, v float64) { t.MaxFilesPerCommit = int(v) },
+	},
+}
+
+// FitDetector fits each numeric threshold on its own, then fits strategy
+// weights to the detections the fitted thresholds produce. Fitted weights
+// are dropped when they lower the combined F1 the fitted thresholds reach
+// with the configured weights.
+func FitDetector(base *detector.Thresholds, pairs []*git.CommitPair, repoStats *metrics.RepositoryStats, labels Labels, scoreThreshold float64) (*Fit, error) {
+	det, err := detector.New(base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create detector: %w", err)
+	}
+	before := Run(det, pairs, repoStats, labels, scoreThreshold)
+	if before.AI == 0 || before.Human == 0 {
+		return nil, fmt.Errorf("fitting needs both ai and human labels (got %d ai, %d human)", before.AI, before.Human)
+	}
+
+	fit := &Fit{
+		Thresholds: FitThresholds(before),
+		AI:         before.AI,
+		Human:      before.Human,
+		Before:     before.Combined,
+	}
+
+	fitted := *base
+	for _, v := range fit.Thresholds {
+		for _, rule := range thresholdRules {
+			if rule.key == v.Key {
+				rule.apply(&fitted, v.Value)
+			}
+		}
+	}
+	if det, err = detector.New(&fitted); err != nil {
+		return nil, fmt.Errorf("failed to create detector with fitted thresholds: %w", err)
+	}
+	withThresholds := Run(det, pairs, repoStats, labels, scoreThreshold)
+	fit.Weights = FitWeights(withThresholds)
+
+	weights := make(map[string]float64, len(base.Weights)+len(fit.Weights))
+	for name, w := range base.Weights {
+		weights[name] = w
+	}
+	for name, w := range fit.Weights {
+		weights[name] = w
+	}
+	fitted.Weights = weights
+	if det, err = detector.New(&fitted); err != nil {
+		return nil, fmt.Errorf("failed to create detector with fitted weights: %w", err)
+	}
+	fit.After = Run(det, pairs, repoStats, labels, scoreThreshold).Combined
+	if fit.After.F1 < withThresholds.Combined.F1 {
+		fit.Weights = nil
+		fit.After = withThresholds.Combined
+	}
+	return fit, nil
+}
+
+// FitThresholds picks, for each numeric threshold, the value that best
+// separates the labeled commits by F1. Thresholds that flag no AI commit
+// are left out.
+func FitThresholds(r *Result) []FittedValue {
+	fitted := make([]FittedValue, 0, len(thresholdRules))
+	for _, rule := range thresholdRules {
+		samples := make([]Sample, 0, len(r.samples))
+		for _, s := range r.samples {
+			if s.pair.Stats == nil {
+				continue
+			}
+			if v, ok := rule.value(s.pair); ok {
+				samples = append(samples, Sample{Score: v, AI: s.ai})
+			}
+		}
+		if v, f1, ok := bestThreshold(samples, rule.above); ok {
+			if !rule.integer {
+				v = math.Round(v*100) / 100
+			}
+			fitted = append(fitted, FittedValue{Key: rule.key, Value: v, F1: f1})
+		}
+	}
+	return fitted
+}
+
+// bestThreshold sweeps the observed values as candidate thresholds. Above
+// a candidate t, values greater than t are flagged; otherwise values less
+// than t are. Ties in F1 go to the threshold that flags fewer commits.
+func bestThreshold(samples []Sample, above bool) (float64, float64, bool) {
+	sorted := make([]Sample, len(samples))
+	copy(sorted, samples)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Score < sorted[j].Score })
+
+	positives := 0
+	for _, s := range sorted {
+		if s.AI {
+			positives++
+		}
+	}
+	if positives == 0 {
+		return 0, 0, false
+	}
+
+	// prefixAI[i] counts AI samples among sorted[:i].
+	prefixAI := make([]int, len(sorted)+1)
+	for i, s := range sorted {
+		prefixAI[i+1] = prefixAI[i]
+		if s.AI {
+			prefixAI[i+1]++
+		}
+	}
+
+	bestF1, best, found := 0.0, 0.0, false
+	for i := 0; i < len(sorted); {
+		j := i
+		for j < len(sorted) && sorted[j].Score == sorted[i].Score {
+			j++
+		}
+
+		// Above flags sorted[j:]; below flags sorted[:i].
+		var flagged, tp int
+		if above {
+			flagged, tp = len(sorted)-j, positives-prefixAI[j]
+		} else {
+			flagged, tp = i, prefixAI[i]
+		}
+		if tp > 0 {
+			precision := float64(tp) / float64(flagged)
+			recall := float64(tp) / float64(positives)
+			f1 := 2 * precision * recall / (precision + recall)
+			better := f1 > bestF1
+			if f1 == bestF1 && found {
+				// Prefer flagging fewer commits: a higher bound above, a
+				// lower one below.
+				better = above
+			}
+			if better {
+				bestF1, best, found = f1, sorted[i].Score, true
+			}
+		}
+		i = j
+	}
+	return best, bestF1, found
+}
+
+// FitWeights sets each strategy's weight so that a detection at the
+// strategy's average confidence adds what its firing added to the chance
+// that a commit is AI-generated, over the labeled base rate. Strategies
+// that never fired keep their current weight.
+func FitWeights(r *Result) map[string]float64 {
+	weights := make(map[string]float64)
+	total := r.AI + r.Human
+	if total == 0 || r.Human == 0 {
+		return weights
+	}
+	base := float64(r.AI) / float64(total)
+
+	for _, name := range strategyNames(r) {
+		var fired, firedAI int
+		var confidence float64
+		for _, s := range r.samples {
+			c, ok := s.findings[name]
+			if !ok {
+				continue
+			}
+			fired++
+			confidence += c
+			if s.ai {
+				firedAI++
+			}
+		}
+		if fired == 0 {
+			continue
+		}
+
+		// Laplace smoothing keeps a strategy that fired once or twice from
+		// landing on 0 or 1.
+		precision := (float64(firedAI) + 1) / (float64(fired) + 2)
+		lift := math.Max(0, (precision-base)/(1-base))
+		weight := math.Min(1, lift/(confidence/float64(fired)))
+		weights[name] = math.Round(weight*100) / 100
+	}
+	return weights
+}
+
+func strategyNames(r *Result) []string {
+	names := make([]string, len(r.Strategies))
+	for i, s := range r.Strategies {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// WriteFit writes the fitted values as a cadence.yml snippet.
+func WriteFit(w io.Writer, fit *Fit) error {
+	out := &errWriter{w: w}
+	out.printf("# Fitted by cadence eval on %d labeled commits (%d ai, %d human).\n", fit.AI+fit.Human, fit.AI, fit.Human)
+	out.printf("# Combined F1 %.3f before fitting, %.3f after.\n", fit.Before.F1, fit.After.F1)
+
+	if len(fit.Thresholds) > 0 {
+		out.printf("thresholds:\n")
+		for _, v := range fit.Thresholds {
+			out.printf("  %s: %s  # F1 %.3f alone\n", v.Key, strconv.FormatFloat(v.Value, 'f', -1, 64), v.F1)
+		}
+	}
+
+	if len(fit.Weights) > 0 {
+		names := make([]string, 0, len(fit.Weights))
+		for name := range fit.Weights {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		out.printf("scoring:\n  weights:\n")
+		for _, name := range names {
+			out.printf("    %s: %s\n", name, strconv.FormatFloat(fit.Weights[name], 'f', -1, 64))
+		}
+	}
+	return out.err
+}
+
+// errWriter keeps the first write error so callers can check once.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (e *errWriter) printf(format string, args ...interface{}) {
+	if e.err == nil {
+		_, e.err = fmt.Fprintf(e.w, format, args...)
+	}
+}
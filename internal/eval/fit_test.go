@@ -0,0 +1,168 @@
+package eval
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/detector"
+	"github.com/TryCadence/Cadence/internal/git"
+)
+
+func TestBestThreshold(t *testing.T) {
+	tests := []struct {
+		name    string
+		samples []Sample
+		above   bool
+		want    float64
+		wantF1  float64
+		wantOK  bool
+	}{
+		{
+			name:    "above separates large values",
+			samples: []Sample{{10, false}, {20, false}, {30, false}, {500, true}, {800, true}},
+			above:   true,
+			want:    30,
+			wantF1:  1,
+			wantOK:  true,
+		},
+		{
+			name:    "below separates small values",
+			samples: []Sample{{5, true}, {8, true}, {60, false}, {120, false}},
+			want:    60,
+			wantF1:  1,
+			wantOK:  true,
+		},
+		{
+			name:    "ties in F1 flag fewer commits",
+			samples: []Sample{{1, false}, {2, true}, {3, false}, {4, false}, {5, true}},
+			above:   true,
+			want:    4,
+			wantF1:  2.0 / 3,
+			wantOK:  true,
+		},
+		{
+			name:    "no ai samples",
+			samples: []Sample{{1, false}, {2, false}},
+			above:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, f1, ok := bestThreshold(tt.samples, tt.above)
+			if ok != tt.wantOK {
+				t.Fatalf("bestThreshold() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if got != tt.want || fmt.Sprintf("%.6f", f1) != fmt.Sprintf("%.6f", tt.wantF1) {
+				t.Errorf("bestThreshold() = %v (F1 %.6f), want %v (F1 %.6f)", got, f1, tt.want, tt.wantF1)
+			}
+		})
+	}
+}
+
+func TestFitWeights(t *testing.T) {
+	sample := func(ai bool, findings map[string]float64) labeledPair {
+		return labeledPair{ai: ai, findings: findings}
+	}
+	r := &Result{
+		AI:    4,
+		Human: 4,
+		Strategies: []StrategyResult{
+			{Name: "precise"}, {Name: "noisy"}, {Name: "silent"},
+		},
+		samples: []labeledPair{
+			sample(true, map[string]float64{"precise": 0.5, "noisy": 1}),
+			sample(true, map[string]float64{"precise": 0.5}),
+			sample(true, map[string]float64{"precise": 0.5}),
+			sample(true, map[string]float64{}),
+			sample(false, map[string]float64{"noisy": 1}),
+			sample(false, map[string]float64{"noisy": 1}),
+			sample(false, map[string]float64{"noisy": 1}),
+			sample(false, map[string]float64{}),
+		},
+	}
+
+	got := FitWeights(r)
+	// precise: smoothed precision 4/5 over a base rate of 1/2 gives lift
+	// 0.6, divided by its mean confidence of 0.5.
+	if got["precise"] != 1 {
+		t.Errorf("precise weight = %v, want 1", got["precise"])
+	}
+	// noisy: smoothed precision 2/6 is under the base rate.
+	if got["noisy"] != 0 {
+		t.Errorf("noisy weight = %v, want 0", got["noisy"])
+	}
+	if _, ok := got["silent"]; ok {
+		t.Error("strategy that never fired should keep its configured weight")
+	}
+}
+
+func TestFitDetector(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	labels := Labels{}
+	var pairs []*git.CommitPair
+	for i := 0; i < 20; i++ {
+		ai := i%4 == 0
+		additions := int64(20 + i)
+		if ai {
+			additions = int64(1500 + 10*i)
+		}
+		hash := fmt.Sprintf("%07d%033d", i, 0)
+		pairs = append(pairs, &git.CommitPair{
+			Previous:  &git.Commit{Hash: fmt.Sprintf("p%039d", i)},
+			Current:   &git.Commit{Hash: hash, Author: "dev", Message: "update", Timestamp: start.Add(time.Duration(i) * 3 * time.Hour)},
+			TimeDelta: 3 * time.Hour,
+			Stats:     &git.DiffStats{Additions: additions, Deletions: 5, FilesChanged: 2},
+		})
+		labels[hash[:MinHashLength]] = ai
+	}
+
+	base := &detector.Thresholds{SuspiciousAdditions: 100000}
+	fit, err := FitDetector(base, pairs, nil, labels, detector.MediumScore)
+	if err != nil {
+		t.Fatalf("FitDetector() error = %v", err)
+	}
+	if fit.AI != 5 || fit.Human != 15 {
+		t.Errorf("labeled = %d ai, %d human, want 5, 15", fit.AI, fit.Human)
+	}
+
+	var additions *FittedValue
+	for i := range fit.Thresholds {
+		if fit.Thresholds[i].Key == "suspicious_additions" {
+			additions = &fit.Thresholds[i]
+		}
+	}
+	if additions == nil || additions.Value != 39 || additions.F1 != 1 {
+		t.Fatalf("suspicious_additions = %+v, want 39 with F1 1", additions)
+	}
+	if fit.After.F1 < fit.Before.F1 {
+		t.Errorf("After F1 %.3f is below Before F1 %.3f", fit.After.F1, fit.Before.F1)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteFit(&buf, fit); err != nil {
+		t.Fatalf("WriteFit() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"(5 ai, 15 human)", "thresholds:\n", "  suspicious_additions: 39  # F1 1.000 alone\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("WriteFit() output missing %q:\n%s", want, out)
+		}
+	}
+
+	onlyAI := Labels{}
+	for hash, ai := range labels {
+		if ai {
+			onlyAI[hash] = true
+		}
+	}
+	if _, err := FitDetector(base, pairs, nil, onlyAI, detector.MediumScore); err == nil {
+		t.Error("FitDetector() with only ai labels should fail")
+	}
+}
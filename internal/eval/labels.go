@@ -0,0 +1,172 @@
+package eval
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// MinHashLength is the shortest commit hash prefix a label may use.
+const MinHashLength = 7
+
+// Labels maps commit hashes, or prefixes of them, to whether the commit is
+// AI-generated.
+type Labels map[string]bool
+
+// LoadLabels reads a labeled dataset. Files ending in .csv hold hash and
+// label columns, with an optional header row naming them; .jsonl and .json
+// files hold one {"hash": ..., "label": ...} object per line. Labels are
+// "ai" or "human".
+func LoadLabels(path string) (Labels, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open labels: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return parseCSV(f)
+	case ".jsonl", ".json":
+		return parseJSONL(f)
+	default:
+		return nil, fmt.Errorf("unsupported labels file extension: %s (use .csv or .jsonl)", filepath.Ext(path))
+	}
+}
+
+func parseCSV(r io.Reader) (Labels, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read labels CSV: %w", err)
+	}
+
+	hashCol, labelCol := 0, 1
+	if len(records) > 0 && isHeader(records[0]) {
+		hashCol, labelCol = -1, -1
+		for i, name := range records[0] {
+			switch strings.ToLower(strings.TrimSpace(name)) {
+			case "hash", "commit":
+				hashCol = i
+			case "label":
+				labelCol = i
+			}
+		}
+		if hashCol < 0 || labelCol < 0 {
+			return nil, fmt.Errorf("labels CSV header must name hash and label columns")
+		}
+		records = records[1:]
+	}
+
+	labels := make(Labels, len(records))
+	for i, record := range records {
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+		if len(record) <= hashCol || len(record) <= labelCol {
+			return nil, fmt.Errorf("labels CSV row %d: expected hash and label", i+1)
+		}
+		if err := labels.add(record[hashCol], record[labelCol]); err != nil {
+			return nil, fmt.Errorf("labels CSV row %d: %w", i+1, err)
+		}
+	}
+	return labels, nil
+}
+
+func isHeader(record []string) bool {
+	for _, name := range record {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "hash", "commit", "label":
+			return true
+		}
+	}
+	return false
+}
+
+func parseJSONL(r io.Reader) (Labels, error) {
+	labels := make(Labels)
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var entry struct {
+			Hash  string `json:"hash"`
+			Label string `json:"label"`
+		}
+		if err := json.Unmarshal([]byte(text), &entry); err != nil {
+			return nil, fmt.Errorf("labels line %d: %w", line, err)
+		}
+		if err := labels.add(entry.Hash, entry.Label); err != nil {
+			return nil, fmt.Errorf("labels line %d: %w", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read labels: %w", err)
+	}
+	return labels, nil
+}
+
+func (l Labels) add(hash, label string) error {
+	hash = strings.ToLower(strings.TrimSpace(hash))
+	if len(hash) < MinHashLength {
+		return fmt.Errorf("commit hash %q is shorter than %d characters", hash, MinHashLength)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(label)) {
+	case "ai":
+		l[hash] = true
+	case "human":
+		l[hash] = false
+	default:
+		return fmt.Errorf("label %q for %s must be ai or human", label, hash)
+	}
+	return nil
+}
+
+// Match resolves the labels against full commit hashes. It returns the
+// label of every hash some label matches, and the labels that matched no
+// hash.
+func (l Labels) Match(hashes []string) (map[string]bool, []string) {
+	lengths := make(map[int]bool)
+	for prefix := range l {
+		lengths[len(prefix)] = true
+	}
+
+	matched := make(map[string]bool, len(l))
+	used := make(map[string]bool, len(l))
+	for _, hash := range hashes {
+		for n := range lengths {
+			if n > len(hash) {
+				continue
+			}
+			if ai, ok := l[hash[:n]]; ok {
+				matched[hash] = ai
+				used[hash[:n]] = true
+				break
+			}
+		}
+	}
+
+	unmatched := make([]string, 0)
+	for prefix := range l {
+		if !used[prefix] {
+			unmatched = append(unmatched, prefix)
+		}
+	}
+	sort.Strings(unmatched)
+	return matched, unmatched
+}
@@ -0,0 +1,93 @@
+package eval
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    Labels
+		wantErr string
+	}{
+		{
+			name:    "csv without header",
+			file:    "labels.csv",
+			content: "abc1234def,ai\n9876543aaa, Human\n",
+			want:    Labels{"abc1234def": true, "9876543aaa": false},
+		},
+		{
+			name:    "csv with header in any column order",
+			file:    "labels.csv",
+			content: "label,notes,commit\nai,copilot session,ABC1234DEF\nhuman,,9876543aaa\n",
+			want:    Labels{"abc1234def": true, "9876543aaa": false},
+		},
+		{
+			name:    "jsonl",
+			file:    "labels.jsonl",
+			content: "{\"hash\": \"abc1234def\", \"label\": \"ai\"}\n\n{\"hash\": \"9876543aaa\", \"label\": \"human\"}\n",
+			want:    Labels{"abc1234def": true, "9876543aaa": false},
+		},
+		{
+			name:    "unknown label",
+			file:    "labels.csv",
+			content: "abc1234def,maybe\n",
+			wantErr: "must be ai or human",
+		},
+		{
+			name:    "short hash",
+			file:    "labels.jsonl",
+			content: "{\"hash\": \"abc12\", \"label\": \"ai\"}\n",
+			wantErr: "shorter than 7",
+		},
+		{
+			name:    "unsupported extension",
+			file:    "labels.txt",
+			content: "abc1234def ai\n",
+			wantErr: "unsupported labels file extension",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := LoadLabels(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadLabels() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadLabels() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadLabels() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLabels_Match(t *testing.T) {
+	labels := Labels{"abc1234": true, "def5678901": false, "0000000": true}
+	hashes := []string{"abc1234ffffffff", "def5678901aaaaaa", "1111111111111111"}
+
+	matched, unmatched := labels.Match(hashes)
+	want := map[string]bool{"abc1234ffffffff": true, "def5678901aaaaaa": false}
+	if !reflect.DeepEqual(matched, want) {
+		t.Errorf("matched = %v, want %v", matched, want)
+	}
+	if !reflect.DeepEqual(unmatched, []string{"0000000"}) {
+		t.Errorf("unmatched = %v, want [0000000]", unmatched)
+	}
+}
@@ -0,0 +1,91 @@
+package eval
+
+import (
+	"math"
+	"sort"
+)
+
+// Sample is one labeled commit's score from a strategy or the detector.
+type Sample struct {
+	Score float64
+	AI    bool
+}
+
+// Metrics summarizes how well scores separate AI-generated commits from
+// human ones. ROCAUC is NaN when the samples hold only one class.
+type Metrics struct {
+	TruePositives  int
+	FalsePositives int
+	FalseNegatives int
+	TrueNegatives  int
+	Precision      float64
+	Recall         float64
+	F1             float64
+	ROCAUC         float64
+}
+
+// Evaluate predicts AI for samples scoring above zero and at least
+// threshold, so a threshold of 0 asks whether a strategy fired at all.
+// ROC-AUC uses the scores alone and does not depend on threshold.
+func Evaluate(samples []Sample, threshold float64) Metrics {
+	var m Metrics
+	for _, s := range samples {
+		predicted := s.Score > 0 && s.Score >= threshold
+		switch {
+		case predicted && s.AI:
+			m.TruePositives++
+		case predicted:
+			m.FalsePositives++
+		case s.AI:
+			m.FalseNegatives++
+		default:
+			m.TrueNegatives++
+		}
+	}
+
+	if m.TruePositives+m.FalsePositives > 0 {
+		m.Precision = float64(m.TruePositives) / float64(m.TruePositives+m.FalsePositives)
+	}
+	if m.TruePositives+m.FalseNegatives > 0 {
+		m.Recall = float64(m.TruePositives) / float64(m.TruePositives+m.FalseNegatives)
+	}
+	if m.Precision+m.Recall > 0 {
+		m.F1 = 2 * m.Precision * m.Recall / (m.Precision + m.Recall)
+	}
+	m.ROCAUC = rocAUC(samples)
+	return m
+}
+
+// rocAUC is the probability that a random AI sample outscores a random
+// human one, counting ties as half, computed from score ranks.
+func rocAUC(samples []Sample) float64 {
+	sorted := make([]Sample, len(samples))
+	copy(sorted, samples)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Score < sorted[j].Score })
+
+	var positives, negatives int
+	var positiveRanks float64
+	for i := 0; i < len(sorted); {
+		j := i
+		for j < len(sorted) && sorted[j].Score == sorted[i].Score {
+			j++
+		}
+		// Tied scores share the average of ranks i+1 through j.
+		rank := float64(i+1+j) / 2
+		for _, s := range sorted[i:j] {
+			if s.AI {
+				positives++
+				positiveRanks += rank
+			} else {
+				negatives++
+			}
+		}
+		i = j
+	}
+
+	if positives == 0 || negatives == 0 {
+		return math.NaN()
+	}
+	p, n := float64(positives), float64(negatives)
+	return (positiveRanks - p*(p+1)/2) / (p * n)
+}
@@ -0,0 +1,92 @@
+package eval
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEvaluate(t *testing.T) {
+	samples := []Sample{
+		{Score: 0.9, AI: true},
+		{Score: 0.6, AI: true},
+		{Score: 0.5, AI: false},
+		{Score: 0.2, AI: true},
+		{Score: 0.1, AI: false},
+		{Score: 0, AI: false},
+	}
+
+	tests := []struct {
+		name           string
+		threshold      float64
+		tp, fp, fn, tn int
+		precision      float64
+		recall         float64
+	}{
+		{"any score", 0, 3, 2, 0, 1, 0.6, 1},
+		{"medium band", 0.4, 2, 1, 1, 2, 2.0 / 3, 2.0 / 3},
+		{"nothing reaches", 0.95, 0, 0, 3, 3, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Evaluate(samples, tt.threshold)
+			if m.TruePositives != tt.tp || m.FalsePositives != tt.fp || m.FalseNegatives != tt.fn || m.TrueNegatives != tt.tn {
+				t.Errorf("TP, FP, FN, TN = %d, %d, %d, %d, want %d, %d, %d, %d",
+					m.TruePositives, m.FalsePositives, m.FalseNegatives, m.TrueNegatives, tt.tp, tt.fp, tt.fn, tt.tn)
+			}
+			if math.Abs(m.Precision-tt.precision) > 1e-9 || math.Abs(m.Recall-tt.recall) > 1e-9 {
+				t.Errorf("Precision, Recall = %v, %v, want %v, %v", m.Precision, m.Recall, tt.precision, tt.recall)
+			}
+			wantF1 := 0.0
+			if tt.precision+tt.recall > 0 {
+				wantF1 = 2 * tt.precision * tt.recall / (tt.precision + tt.recall)
+			}
+			if math.Abs(m.F1-wantF1) > 1e-9 {
+				t.Errorf("F1 = %v, want %v", m.F1, wantF1)
+			}
+		})
+	}
+}
+
+func TestROCAUC(t *testing.T) {
+	tests := []struct {
+		name    string
+		samples []Sample
+		want    float64
+	}{
+		{
+			name:    "perfect separation",
+			samples: []Sample{{0.9, true}, {0.8, true}, {0.3, false}, {0.1, false}},
+			want:    1,
+		},
+		{
+			name:    "inverted",
+			samples: []Sample{{0.1, true}, {0.9, false}},
+			want:    0,
+		},
+		{
+			name:    "ties count half",
+			samples: []Sample{{0, true}, {0, false}, {0, false}, {0.5, true}},
+			want:    0.75,
+		},
+		{
+			// 7 of 9 AI/human pairs ordered correctly, as the Mann-Whitney U
+			// statistic counts them.
+			name:    "two swaps",
+			samples: []Sample{{0.9, true}, {0.7, true}, {0.8, false}, {0.2, true}, {0.1, false}, {0.05, false}},
+			want:    7.0 / 9,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rocAUC(tt.samples); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("rocAUC() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if got := rocAUC([]Sample{{0.5, true}, {0.7, true}}); !math.IsNaN(got) {
+		t.Errorf("rocAUC() with one class = %v, want NaN", got)
+	}
+}
@@ -0,0 +1,51 @@
+package eval
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// maxUnmatchedListed caps how many unmatched labels WriteReport names.
+const maxUnmatchedListed = 10
+
+// WriteReport writes the per-strategy and combined metrics as a table.
+func WriteReport(w io.Writer, r *Result) error {
+	out := &errWriter{w: w}
+
+	out.printf("EVALUATION\n")
+	out.printf("----------\n")
+	out.printf("Labeled commits:    %d (%d ai, %d human)\n", r.AI+r.Human, r.AI, r.Human)
+	if len(r.Unmatched) > 0 {
+		listed := r.Unmatched
+		if len(listed) > maxUnmatchedListed {
+			listed = listed[:maxUnmatchedListed]
+		}
+		more := ""
+		if len(r.Unmatched) > len(listed) {
+			more = fmt.Sprintf(" and %d more", len(r.Unmatched)-len(listed))
+		}
+		out.printf("Unmatched labels:   %d (%s%s)\n", len(r.Unmatched), strings.Join(listed, ", "), more)
+	}
+	out.printf("\n")
+
+	out.printf("%-36s %6s %10s %8s %8s %8s\n", "STRATEGY", "FIRED", "PRECISION", "RECALL", "F1", "ROC-AUC")
+	for _, s := range r.Strategies {
+		writeRow(out, s.Name, fmt.Sprintf("%d", s.Fired), s.Metrics)
+	}
+	flagged := r.Combined.TruePositives + r.Combined.FalsePositives
+	writeRow(out, fmt.Sprintf("combined (score >= %.2f)", r.ScoreThreshold), fmt.Sprintf("%d", flagged), r.Combined)
+	return out.err
+}
+
+func writeRow(out *errWriter, name, fired string, m Metrics) {
+	out.printf("%-36s %6s %10.3f %8.3f %8.3f %8s\n", name, fired, m.Precision, m.Recall, m.F1, formatAUC(m.ROCAUC))
+}
+
+func formatAUC(auc float64) string {
+	if math.IsNaN(auc) {
+		return "-"
+	}
+	return fmt.Sprintf("%.3f", auc)
+}
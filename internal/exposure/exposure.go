@@ -0,0 +1,129 @@
+// Package exposure measures how much of the code at a revision was last
+// changed by commits the detector flagged, by directory, package and file.
+package exposure
+
+import (
+	"path"
+	"sort"
+
+	"github.com/TryCadence/Cadence/internal/git"
+)
+
+// Entry is the exposure of a file or a group of files. A line is flagged
+// when the commit that last changed it scored at least the report's
+// MinScore; MeanScore averages the scores of all lines, counting lines of
+// unflagged commits as zero.
+type Entry struct {
+	Path         string  `json:"path"`
+	Files        int     `json:"files"`
+	Lines        int     `json:"lines"`
+	FlaggedLines int     `json:"flagged_lines"`
+	Share        float64 `json:"share"`
+	MeanScore    float64 `json:"mean_score"`
+
+	scoreSum float64
+}
+
+func (e *Entry) add(lines, flagged int, scoreSum float64) {
+	e.Lines += lines
+	e.FlaggedLines += flagged
+	e.scoreSum += scoreSum
+}
+
+func (e *Entry) finish() {
+	if e.Lines > 0 {
+		e.Share = float64(e.FlaggedLines) / float64(e.Lines)
+		e.MeanScore = e.scoreSum / float64(e.Lines)
+	}
+}
+
+// Report is the exposure of a revision. Directories count every file below
+// them, packages only the files directly inside, with "." for the root.
+// Each list is ordered by flagged lines, most first. SkippedFiles counts
+// the files that could not be blamed and are missing from every entry.
+type Report struct {
+	Revision     string  `json:"revision,omitempty"`
+	MinScore     float64 `json:"min_score"`
+	SkippedFiles int     `json:"skipped_files,omitempty"`
+	Total        Entry   `json:"total"`
+	Directories  []Entry `json:"directories"`
+	Packages     []Entry `json:"packages"`
+	Files        []Entry `json:"files"`
+}
+
+// Compute maps each blamed line to the score of its commit. Commits missing
+// from scores, such as those the detector did not flag, score zero.
+func Compute(blames []git.FileBlame, scores map[string]float64, minScore float64) *Report {
+	report := &Report{MinScore: minScore, Total: Entry{Path: "."}}
+	directories := make(map[string]*Entry)
+	packages := make(map[string]*Entry)
+
+	group := func(groups map[string]*Entry, dir string) *Entry {
+		e, ok := groups[dir]
+		if !ok {
+			e = &Entry{Path: dir}
+			groups[dir] = e
+		}
+		return e
+	}
+
+	for _, blame := range blames {
+		flagged := 0
+		scoreSum := 0.0
+		for hash, lines := range blame.Commits {
+			score := scores[hash]
+			scoreSum += score * float64(lines)
+			if score >= minScore && score > 0 {
+				flagged += lines
+			}
+		}
+
+		file := Entry{Path: blame.Path, Files: 1}
+		file.add(blame.Lines, flagged, scoreSum)
+		file.finish()
+		report.Files = append(report.Files, file)
+
+		report.Total.Files++
+		report.Total.add(blame.Lines, flagged, scoreSum)
+
+		dir := path.Dir(blame.Path)
+		pkg := group(packages, dir)
+		pkg.Files++
+		pkg.add(blame.Lines, flagged, scoreSum)
+		for ; dir != "."; dir = path.Dir(dir) {
+			d := group(directories, dir)
+			d.Files++
+			d.add(blame.Lines, flagged, scoreSum)
+		}
+	}
+
+	report.Total.finish()
+	report.Directories = sorted(directories)
+	report.Packages = sorted(packages)
+	sortEntries(report.Files)
+	return report
+}
+
+func sorted(groups map[string]*Entry) []Entry {
+	entries := make([]Entry, 0, len(groups))
+	for _, e := range groups {
+		e.finish()
+		entries = append(entries, *e)
+	}
+	sortEntries(entries)
+	return entries
+}
+
+// sortEntries orders by flagged lines, then share, then path.
+func sortEntries(entries []Entry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.FlaggedLines != b.FlaggedLines {
+			return a.FlaggedLines > b.FlaggedLines
+		}
+		if a.Share != b.Share {
+			return a.Share > b.Share
+		}
+		return a.Path < b.Path
+	})
+}
@@ -0,0 +1,104 @@
+package exposure
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/git"
+)
+
+func TestCompute(t *testing.T) {
+	blames := []git.FileBlame{
+		{Path: "README.md", Lines: 10, Commits: map[string]int{"human": 10}},
+		{Path: "internal/api/handler.go", Lines: 100, Commits: map[string]int{"ai": 80, "human": 20}},
+		{Path: "internal/api/model.go", Lines: 50, Commits: map[string]int{"weak": 50}},
+		{Path: "internal/db/db.go", Lines: 40, Commits: map[string]int{"ai": 10, "gone": 30}},
+	}
+	scores := map[string]float64{"ai": 0.8, "weak": 0.2}
+
+	r := Compute(blames, scores, 0.4)
+
+	tests := []struct {
+		name    string
+		entries []Entry
+		path    string
+		want    Entry
+	}{
+		{"total", []Entry{r.Total}, ".", Entry{Files: 4, Lines: 200, FlaggedLines: 90, Share: 0.45, MeanScore: (90*0.8 + 50*0.2) / 200}},
+		{"directory counts files below it", r.Directories, "internal", Entry{Files: 3, Lines: 190, FlaggedLines: 90}},
+		{"package counts files inside it", r.Packages, "internal/api", Entry{Files: 2, Lines: 150, FlaggedLines: 80}},
+		{"root package", r.Packages, ".", Entry{Files: 1, Lines: 10}},
+		{"file", r.Files, "internal/db/db.go", Entry{Files: 1, Lines: 40, FlaggedLines: 10, Share: 0.25, MeanScore: 0.2}},
+		{"weak scores are not flagged", r.Files, "internal/api/model.go", Entry{Files: 1, Lines: 50, MeanScore: 0.2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *Entry
+			for i := range tt.entries {
+				if tt.entries[i].Path == tt.path {
+					got = &tt.entries[i]
+				}
+			}
+			if got == nil {
+				t.Fatalf("no entry for %s", tt.path)
+			}
+			if got.Files != tt.want.Files || got.Lines != tt.want.Lines || got.FlaggedLines != tt.want.FlaggedLines {
+				t.Errorf("Files, Lines, FlaggedLines = %d, %d, %d, want %d, %d, %d",
+					got.Files, got.Lines, got.FlaggedLines, tt.want.Files, tt.want.Lines, tt.want.FlaggedLines)
+			}
+			if tt.want.Share != 0 && math.Abs(got.Share-tt.want.Share) > 1e-9 {
+				t.Errorf("Share = %v, want %v", got.Share, tt.want.Share)
+			}
+			if tt.want.MeanScore != 0 && math.Abs(got.MeanScore-tt.want.MeanScore) > 1e-9 {
+				t.Errorf("MeanScore = %v, want %v", got.MeanScore, tt.want.MeanScore)
+			}
+		})
+	}
+
+	if r.Files[0].Path != "internal/api/handler.go" {
+		t.Errorf("Files[0] = %s, want the file with most flagged lines", r.Files[0].Path)
+	}
+}
+
+func TestWriteReports(t *testing.T) {
+	r := Compute([]git.FileBlame{
+		{Path: "a/x.go", Lines: 10, Commits: map[string]int{"ai": 6, "human": 4}},
+		{Path: "a/y.go", Lines: 10, Commits: map[string]int{"ai": 2, "human": 8}},
+		{Path: "b/z.go", Lines: 10, Commits: map[string]int{"human": 10}},
+	}, map[string]float64{"ai": 0.9}, 0.4)
+	r.SkippedFiles = 2
+
+	var text bytes.Buffer
+	if err := WriteText(&text, r, 1); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+	for _, want := range []string{
+		"Likely AI lines:    8 (26.7%)",
+		"Skipped files:      2 (blame failed)",
+		"a/x.go", "... and 1 more",
+	} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("text report missing %q:\n%s", want, text.String())
+		}
+	}
+	if strings.Contains(text.String(), "b/z.go") {
+		t.Error("text report should leave out files without flagged lines")
+	}
+
+	var out bytes.Buffer
+	if err := WriteJSON(&out, r); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	var decoded Report
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("WriteJSON() wrote invalid JSON: %v", err)
+	}
+	if len(decoded.Files) != 3 || decoded.Total.FlaggedLines != 8 || decoded.SkippedFiles != 2 {
+		t.Errorf("decoded %d files, %d flagged lines, %d skipped, want 3, 8, 2",
+			len(decoded.Files), decoded.Total.FlaggedLines, decoded.SkippedFiles)
+	}
+}
@@ -0,0 +1,73 @@
+package exposure
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// WriteJSON writes the whole report as indented JSON.
+func WriteJSON(w io.Writer, r *Report) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+	if _, err := w.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// WriteText writes the totals and, for directories, packages and files,
+// the top entries holding flagged lines. top of zero lists them all.
+func WriteText(w io.Writer, r *Report, top int) error {
+	out := bufio.NewWriter(w)
+
+	fmt.Fprintf(out, "AI EXPOSURE\n")
+	fmt.Fprintf(out, "-----------\n")
+	if r.Revision != "" {
+		fmt.Fprintf(out, "Revision:           %s\n", r.Revision)
+	}
+	fmt.Fprintf(out, "Flagged at score:   %.2f\n", r.MinScore)
+	fmt.Fprintf(out, "Files:              %d\n", r.Total.Files)
+	fmt.Fprintf(out, "Lines:              %d\n", r.Total.Lines)
+	fmt.Fprintf(out, "Likely AI lines:    %d (%.1f%%)\n", r.Total.FlaggedLines, r.Total.Share*100)
+	fmt.Fprintf(out, "Mean line score:    %.3f\n", r.Total.MeanScore)
+	if r.SkippedFiles > 0 {
+		fmt.Fprintf(out, "Skipped files:      %d (blame failed)\n", r.SkippedFiles)
+	}
+
+	writeSection(out, "DIRECTORIES", r.Directories, top)
+	writeSection(out, "PACKAGES", r.Packages, top)
+	writeSection(out, "FILES", r.Files, top)
+
+	// A bufio.Writer keeps its first error, so one check covers every line.
+	if err := out.Flush(); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+func writeSection(out *bufio.Writer, title string, entries []Entry, top int) {
+	fmt.Fprintf(out, "\n%-48s %8s %8s %7s %6s\n", title, "LINES", "AI", "SHARE", "MEAN")
+	flagged := 0
+	for flagged < len(entries) && entries[flagged].FlaggedLines > 0 {
+		flagged++
+	}
+	if flagged == 0 {
+		fmt.Fprintf(out, "No likely AI lines.\n")
+		return
+	}
+
+	listed := flagged
+	if top > 0 && listed > top {
+		listed = top
+	}
+	for _, e := range entries[:listed] {
+		fmt.Fprintf(out, "%-48s %8d %8d %6.1f%% %6.3f\n", e.Path, e.Lines, e.FlaggedLines, e.Share*100, e.MeanScore)
+	}
+	if flagged > listed {
+		fmt.Fprintf(out, "... and %d more\n", flagged-listed)
+	}
+}
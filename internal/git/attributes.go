@@ -0,0 +1,170 @@
+package git
+
+import (
+	"bufio"
+	"path"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitattributes"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+const attributesFile = ".gitattributes"
+
+// builtinMacros mirrors the macros git defines without any .gitattributes.
+var builtinMacros = []string{"[attr]binary -diff -merge -text"}
+
+type exclusionReason int
+
+const (
+	notExcluded exclusionReason = iota
+	excludedByPattern
+	excludedGenerated
+	excludedVendored
+	excludedNoDiff
+)
+
+func (d *DroppedLines) count(reason exclusionReason, lines int64) {
+	switch reason {
+	case excludedByPattern:
+		d.ExcludeFiles += lines
+	case excludedGenerated:
+		d.Generated += lines
+	case excludedVendored:
+		d.Vendored += lines
+	case excludedNoDiff:
+		d.NoDiff += lines
+	}
+}
+
+// attributeSource resolves .gitattributes rules as of one revision. Files
+// are read lazily from the root and from each directory on the way to a
+// path, so a diff only visits the directories it touches.
+type attributeSource struct {
+	tree   *object.Tree
+	dirs   map[string][]gitattributes.MatchAttribute
+	macros map[string][]gitattributes.Attribute
+}
+
+func newAttributeSource(tree *object.Tree) *attributeSource {
+	s := &attributeSource{
+		tree:   tree,
+		dirs:   make(map[string][]gitattributes.MatchAttribute),
+		macros: make(map[string][]gitattributes.Attribute),
+	}
+	for _, line := range builtinMacros {
+		if m, err := gitattributes.ParseAttributesLine(line, nil, true); err == nil {
+			s.macros[m.Name] = m.Attributes
+		}
+	}
+	return s
+}
+
+// exclusionReason reports why a file's lines should be dropped based on
+// its linguist-generated, linguist-vendored and diff attributes.
+func (s *attributeSource) exclusionReason(filePath string) exclusionReason {
+	switch {
+	case isTrue(s.lookup(filePath, "linguist-generated")):
+		return excludedGenerated
+	case isTrue(s.lookup(filePath, "linguist-vendored")):
+		return excludedVendored
+	case isUnset(s.lookup(filePath, "diff")):
+		return excludedNoDiff
+	default:
+		return notExcluded
+	}
+}
+
+// lookup returns the attribute that applies to filePath, or nil. As in git,
+// deeper .gitattributes files override shallower ones and later lines
+// override earlier ones.
+func (s *attributeSource) lookup(filePath, name string) gitattributes.Attribute {
+	parts := strings.Split(filePath, "/")
+
+	stack := make([][]gitattributes.MatchAttribute, 0, len(parts))
+	for depth := 0; depth < len(parts); depth++ {
+		stack = append(stack, s.rules(parts[:depth]))
+	}
+
+	for i := len(stack) - 1; i >= 0; i-- {
+		rules := stack[i]
+		for j := len(rules) - 1; j >= 0; j-- {
+			if rules[j].Pattern == nil || !rules[j].Pattern.Match(parts) {
+				continue
+			}
+			if attr := s.find(rules[j].Attributes, name); attr != nil {
+				return attr
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *attributeSource) find(attrs []gitattributes.Attribute, name string) gitattributes.Attribute {
+	for i := len(attrs) - 1; i >= 0; i-- {
+		if attrs[i].Name() == name {
+			return attrs[i]
+		}
+		if !attrs[i].IsSet() {
+			continue
+		}
+		for _, expanded := range s.macros[attrs[i].Name()] {
+			if expanded.Name() == name {
+				return expanded
+			}
+		}
+	}
+	return nil
+}
+
+// rules returns the parsed .gitattributes of the directory at domain.
+// Malformed lines are skipped, as git does.
+func (s *attributeSource) rules(domain []string) []gitattributes.MatchAttribute {
+	dir := path.Join(domain...)
+	if rules, ok := s.dirs[dir]; ok {
+		return rules
+	}
+
+	var rules []gitattributes.MatchAttribute
+	if file, err := s.tree.File(path.Join(dir, attributesFile)); err == nil {
+		if content, err := file.Contents(); err == nil {
+			rules = s.parse(content, domain)
+		}
+	}
+
+	s.dirs[dir] = rules
+	return rules
+}
+
+func (s *attributeSource) parse(content string, domain []string) []gitattributes.MatchAttribute {
+	// Macros may only be defined at the top level.
+	allowMacro := len(domain) == 0
+
+	rules := make([]gitattributes.MatchAttribute, 0)
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		m, err := gitattributes.ParseAttributesLine(scanner.Text(), domain, allowMacro)
+		if err != nil || m.Name == "" {
+			continue
+		}
+		if m.Pattern == nil {
+			s.macros[m.Name] = m.Attributes
+			continue
+		}
+		rules = append(rules, m)
+	}
+	return rules
+}
+
+// isTrue accepts both "attr" and "attr=true", the forms linguist honors.
+func isTrue(attr gitattributes.Attribute) bool {
+	if attr == nil {
+		return false
+	}
+	return attr.IsSet() || (attr.IsValueSet() && strings.EqualFold(attr.Value(), "true"))
+}
+
+func isUnset(attr gitattributes.Attribute) bool {
+	return attr != nil && attr.IsUnset()
+}
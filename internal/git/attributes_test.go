@@ -0,0 +1,118 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGitRepository_GetCommitPairs_Attributes(t *testing.T) {
+	dir := t.TempDir()
+	runGit(t, dir, "2024-01-01T10:00:00Z", "init", "-b", "main")
+	runGit(t, dir, "2024-01-01T10:00:00Z", "config", "user.email", "dev@example.com")
+	runGit(t, dir, "2024-01-01T10:00:00Z", "config", "user.name", "Dev")
+
+	write := func(file, content string) {
+		path := filepath.Join(dir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", file, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write %s: %v", file, err)
+		}
+	}
+	commit := func(date, msg string) {
+		runGit(t, dir, date, "add", "-A")
+		runGit(t, dir, date, "commit", "-m", msg)
+	}
+
+	write("main.go", "package main\n")
+	write("api.pb.go", "package api\n")
+	commit("2024-01-01T10:00:00Z", "initial")
+
+	// The attributes arrive together with the files they describe.
+	write(".gitattributes", "*.pb.go linguist-generated\nvendor/** linguist-vendored\n*.bin binary\n*.log linguist-generated\n")
+	write("sub/.gitattributes", "*.pb.go -linguist-generated\n")
+	write("main.go", "package main\n\nfunc main() {}\n")
+	write("api.pb.go", "package api\n\nvar A = 1\n")
+	write("sub/handwritten.pb.go", "package sub\nvar B = 2\n")
+	write("vendor/lib/x.go", "package lib\n\nvar X = 1\nvar Y = 2\n")
+	write("data.bin", "payload\n")
+	write("notes.log", "note\n")
+	commit("2024-01-01T11:00:00Z", "add attributes")
+
+	// Once the root attributes are gone, api.pb.go counts again; the
+	// deleted vendored file is judged by the revision it is deleted from.
+	if err := os.Remove(filepath.Join(dir, ".gitattributes")); err != nil {
+		t.Fatalf("Failed to remove .gitattributes: %v", err)
+	}
+	if err := os.RemoveAll(filepath.Join(dir, "vendor")); err != nil {
+		t.Fatalf("Failed to remove vendor: %v", err)
+	}
+	write("api.pb.go", "package api\n\nvar A = 1\nvar C = 3\n")
+	commit("2024-01-01T12:00:00Z", "drop attributes")
+
+	repo, err := OpenRepository(dir, &RepositoryOptions{ExcludeFiles: []string{"*.log"}})
+	if err != nil {
+		t.Fatalf("Failed to open repository: %v", err)
+	}
+	defer repo.Close()
+
+	commits, err := repo.GetCommits(nil)
+	if err != nil {
+		t.Fatalf("GetCommits() error = %v", err)
+	}
+	pairs, err := repo.(*gitRepository).GetCommitPairs(commits)
+	if err != nil {
+		t.Fatalf("GetCommitPairs() error = %v", err)
+	}
+
+	byMessage := make(map[string]*CommitPair)
+	for _, p := range pairs {
+		byMessage[strings.TrimSpace(p.Current.Message)] = p
+	}
+
+	added := byMessage["add attributes"]
+	if added == nil {
+		t.Fatal("missing pair for attribute commit")
+	}
+	// Blank lines are not counted.
+	wantDropped := DroppedLines{ExcludeFiles: 1, Generated: 1, Vendored: 3, NoDiff: 1}
+	if added.Stats.Dropped != wantDropped {
+		t.Errorf("Dropped = %+v, want %+v", added.Stats.Dropped, wantDropped)
+	}
+	if added.Stats.Additions+added.Stats.Dropped.Total() != added.Stats.TotalAdditions {
+		t.Errorf("Additions %d + dropped %d != TotalAdditions %d",
+			added.Stats.Additions, added.Stats.Dropped.Total(), added.Stats.TotalAdditions)
+	}
+	for _, excluded := range []string{"vendor/lib/x.go", "data.bin", "notes.log", "b/api.pb.go"} {
+		if strings.Contains(added.DiffContent, excluded) {
+			t.Errorf("DiffContent should not include %s", excluded)
+		}
+	}
+	if !strings.Contains(added.DiffContent, "sub/handwritten.pb.go") {
+		t.Error("DiffContent should include a file whose generated marker is unset in a subdirectory")
+	}
+
+	dropped := byMessage["drop attributes"]
+	if dropped == nil {
+		t.Fatal("missing pair for attribute removal commit")
+	}
+	wantDropped = DroppedLines{Vendored: 3}
+	if dropped.Stats.Dropped != wantDropped {
+		t.Errorf("Dropped = %+v, want %+v", dropped.Stats.Dropped, wantDropped)
+	}
+	if dropped.Stats.Additions != 1 {
+		t.Errorf("Additions = %d, want 1 (api.pb.go no longer generated)", dropped.Stats.Additions)
+	}
+	// Excluded files are left out of the per-file breakdown too.
+	wantFiles := []FileStats{
+		{Path: ".gitattributes", Deletions: 4},
+		{Path: "api.pb.go", Additions: 1},
+	}
+	if !reflect.DeepEqual(dropped.Stats.Files, wantFiles) {
+		t.Errorf("Files = %+v, want %+v", dropped.Stats.Files, wantFiles)
+	}
+}
@@ -0,0 +1,140 @@
+package git
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// FileBlame counts a file's lines by the commit that last changed them.
+type FileBlame struct {
+	Path    string
+	Lines   int
+	Commits map[string]int
+}
+
+// SkippedFile is a file Blame could not attribute, with the reason.
+type SkippedFile struct {
+	Path string
+	Err  error
+}
+
+// Blame runs git blame over every file at the head of branch, or HEAD when
+// branch is empty. Binary files and files excluded from the diff stats are
+// skipped, as are empty files. Files are returned in tree order. A file
+// that fails to blame is returned among the skipped files rather than
+// failing the others.
+//
+// Blame walks each file's history, so files are blamed on the same bounded
+// worker pool as diffs, each worker beyond the first with its own handle on
+// the repository.
+func (r *gitRepository) Blame(branch string) ([]FileBlame, []SkippedFile, error) {
+	head, err := r.resolveHead(branch, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	commit, err := r.repo.CommitObject(head)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get head commit: %w", err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get head tree: %w", err)
+	}
+
+	attrs := newAttributeSource(tree)
+	var paths []string
+	err = tree.Files().ForEach(func(f *object.File) error {
+		if r.exclusionReason(f.Name, attrs) != notExcluded {
+			return nil
+		}
+		if binary, err := f.IsBinary(); err != nil || binary {
+			return nil
+		}
+		paths = append(paths, f.Name)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	if len(paths) == 0 {
+		return nil, nil, nil
+	}
+	results := make([]FileBlame, len(paths))
+	errs := make([]error, len(paths))
+
+	workers := r.concurrency
+	if workers > len(paths) {
+		workers = len(paths)
+	}
+	openErrs := make([]error, workers)
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		repo := r.repo
+		var openErr error
+		if w > 0 {
+			repo, openErr = git.PlainOpen(r.path)
+			openErrs[w] = openErr
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if openErr != nil {
+					continue
+				}
+				results[i], errs[i] = blameFile(repo, head, paths[i])
+			}
+		}()
+	}
+
+	for i := range paths {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range openErrs {
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open repository: %w", err)
+		}
+	}
+
+	blames := make([]FileBlame, 0, len(results))
+	var skipped []SkippedFile
+	for i, result := range results {
+		if errs[i] != nil {
+			skipped = append(skipped, SkippedFile{Path: paths[i], Err: errs[i]})
+			continue
+		}
+		if result.Lines > 0 {
+			blames = append(blames, result)
+		}
+	}
+	return blames, skipped, nil
+}
+
+func blameFile(repo *git.Repository, head plumbing.Hash, path string) (FileBlame, error) {
+	commit, err := repo.CommitObject(head)
+	if err != nil {
+		return FileBlame{}, fmt.Errorf("failed to get head commit: %w", err)
+	}
+	result, err := git.Blame(commit, path)
+	if err != nil {
+		return FileBlame{}, fmt.Errorf("failed to blame %s: %w", path, err)
+	}
+
+	blame := FileBlame{Path: path, Lines: len(result.Lines), Commits: make(map[string]int)}
+	for _, line := range result.Lines {
+		blame.Commits[line.Hash.String()]++
+	}
+	return blame, nil
+}
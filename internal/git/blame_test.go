@@ -0,0 +1,128 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGitRepository_Blame(t *testing.T) {
+	dir := t.TempDir()
+	runGit(t, dir, "2024-01-01T10:00:00Z", "init", "-b", "main")
+	runGit(t, dir, "2024-01-01T10:00:00Z", "config", "user.email", "dev@example.com")
+	runGit(t, dir, "2024-01-01T10:00:00Z", "config", "user.name", "Dev")
+
+	write := func(file, content string) {
+		path := filepath.Join(dir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", file, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write %s: %v", file, err)
+		}
+	}
+	commit := func(date, msg string) string {
+		runGit(t, dir, date, "add", "-A")
+		runGit(t, dir, date, "commit", "-m", msg)
+		out, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD").Output()
+		if err != nil {
+			t.Fatalf("git rev-parse failed: %v", err)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	write("main.go", "package main\n\nfunc main() {}\n")
+	write("notes.log", "note\n")
+	first := commit("2024-01-01T10:00:00Z", "initial")
+
+	write("main.go", "package main\n\nfunc main() {\n\trun()\n}\n")
+	write("api.pb.go", "package main\n\nvar A = 1\n")
+	write(".gitattributes", "*.pb.go linguist-generated\n")
+	write("empty.txt", "")
+	write("logo.bin", "\x00\x01\x02")
+	second := commit("2024-01-01T11:00:00Z", "run")
+
+	repo, err := OpenRepository(dir, &RepositoryOptions{ExcludeFiles: []string{"*.log"}, Concurrency: 2})
+	if err != nil {
+		t.Fatalf("Failed to open repository: %v", err)
+	}
+	defer repo.Close()
+
+	blames, skippedFiles, err := repo.(BlameProvider).Blame("")
+	if err != nil {
+		t.Fatalf("Blame() error = %v", err)
+	}
+	if len(skippedFiles) != 0 {
+		t.Errorf("Blame() skipped %+v, want none", skippedFiles)
+	}
+
+	byPath := make(map[string]FileBlame)
+	for _, b := range blames {
+		byPath[b.Path] = b
+	}
+	for _, skipped := range []string{"notes.log", "api.pb.go", "empty.txt", "logo.bin"} {
+		if _, ok := byPath[skipped]; ok {
+			t.Errorf("Blame() should skip %s", skipped)
+		}
+	}
+
+	main, ok := byPath["main.go"]
+	if !ok {
+		t.Fatal("Blame() missing main.go")
+	}
+	// "package main" and the blank line survive from the first commit.
+	if main.Lines != 5 || main.Commits[first] != 2 || main.Commits[second] != 3 {
+		t.Errorf("main.go = %d lines, %d from first, %d from second; want 5, 2, 3",
+			main.Lines, main.Commits[first], main.Commits[second])
+	}
+}
+
+func TestGitRepository_Blame_SkipsFailingFiles(t *testing.T) {
+	dir := t.TempDir()
+	runGit(t, dir, "2024-01-01T10:00:00Z", "init", "-b", "main")
+	runGit(t, dir, "2024-01-01T10:00:00Z", "config", "user.email", "dev@example.com")
+	runGit(t, dir, "2024-01-01T10:00:00Z", "config", "user.name", "Dev")
+
+	write := func(file, content string) {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write %s: %v", file, err)
+		}
+	}
+	write("broken.go", "package broken\n")
+	write("main.go", "package main\n")
+	runGit(t, dir, "2024-01-01T10:00:00Z", "add", "-A")
+	runGit(t, dir, "2024-01-01T10:00:00Z", "commit", "-m", "initial")
+
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD:broken.go").Output()
+	if err != nil {
+		t.Fatalf("git rev-parse failed: %v", err)
+	}
+	write("broken.go", "package broken\n\nfunc broken() {}\n")
+	runGit(t, dir, "2024-01-01T11:00:00Z", "add", "-A")
+	runGit(t, dir, "2024-01-01T11:00:00Z", "commit", "-m", "extend")
+
+	// Blaming broken.go needs its first version, which goes missing.
+	blob := strings.TrimSpace(string(out))
+	if err := os.Remove(filepath.Join(dir, ".git", "objects", blob[:2], blob[2:])); err != nil {
+		t.Fatalf("Failed to remove blob: %v", err)
+	}
+
+	repo, err := OpenRepository(dir, nil)
+	if err != nil {
+		t.Fatalf("Failed to open repository: %v", err)
+	}
+	defer repo.Close()
+
+	blames, skipped, err := repo.(BlameProvider).Blame("")
+	if err != nil {
+		t.Fatalf("Blame() error = %v", err)
+	}
+	if len(blames) != 1 || blames[0].Path != "main.go" {
+		t.Errorf("Blame() = %+v, want only main.go", blames)
+	}
+	if len(skipped) != 1 || skipped[0].Path != "broken.go" || skipped[0].Err == nil {
+		t.Errorf("skipped = %+v, want broken.go with its error", skipped)
+	}
+}
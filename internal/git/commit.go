@@ -1,6 +1,13 @@
 package git
 
-import "time"
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/gosource"
+)
 
 type Commit struct {
 	Hash      string
@@ -9,6 +16,23 @@ type Commit struct {
 	Timestamp time.Time
 	Message   string
 	Parents   []string
+
+	// Committer identity and time. They differ from the author's when a
+	// commit was applied, rebased or created by a tool on someone's behalf.
+	Committer      string
+	CommitterEmail string
+	CommitTime     time.Time
+
+	// Raw author and committer identities as recorded in the commit,
+	// before the .mailmap and configured aliases resolve them. They tell
+	// which tool made a commit even when a mailmap folds it into a person.
+	RawAuthor         string
+	RawEmail          string
+	RawCommitter      string
+	RawCommitterEmail string
+
+	// Trailers are parsed from the end of Message.
+	Trailers []Trailer
 }
 
 type CommitPair struct {
@@ -26,9 +50,145 @@ type DiffStats struct {
 	TotalAdditions    int64
 	TotalDeletions    int64
 	FilesChangedTotal int
+
+	// Dropped counts the added and deleted lines left out of Additions and
+	// Deletions, by the reason their file was excluded.
+	Dropped DroppedLines
+
+	// Go measures the changed lines of non-excluded .go files, parsed from
+	// their post-image. It is nil when the diff adds no Go code.
+	Go *gosource.Signals `json:",omitempty"`
+
+	// Files breaks Additions and Deletions down by non-excluded file, in
+	// diff order.
+	Files []FileStats `json:",omitempty"`
+}
+
+// FileStats counts the lines a commit adds to and deletes from one file.
+// Path is the post-image path, or the pre-image path of a deleted file.
+type FileStats struct {
+	Path      string
+	Additions int64
+	Deletions int64
+}
+
+// DroppedLines splits excluded lines by reason. A file matching several
+// reasons is counted once, under the first in field order.
+type DroppedLines struct {
+	ExcludeFiles int64
+	Generated    int64
+	Vendored     int64
+	NoDiff       int64
+}
+
+func (d DroppedLines) Total() int64 {
+	return d.ExcludeFiles + d.Generated + d.Vendored + d.NoDiff
+}
+
+func (d *DroppedLines) Add(other DroppedLines) {
+	d.ExcludeFiles += other.ExcludeFiles
+	d.Generated += other.Generated
+	d.Vendored += other.Vendored
+	d.NoDiff += other.NoDiff
 }
 
 type CommitOptions struct {
 	Branch   string
 	MaxDepth int
+
+	// Range limits the walk to a "base..head" revision range: commits
+	// reachable from head but not from base. An empty head means Branch
+	// (or HEAD).
+	Range string
+	// Since and Until bound the author timestamp. Zero values are open.
+	Since time.Time
+	Until time.Time
+	// Author keeps commits whose author name or email contains it
+	// (case-insensitive).
+	Author string
+	// Paths keeps commits touching at least one matching path. Entries are
+	// directory prefixes or filepath.Match globs.
+	Paths []string
+
+	// BaselineDepth caps how many commits of surrounding history are read
+	// to build the repository baseline when filters are set (0 = all).
+	BaselineDepth int
+}
+
+// IsFiltered reports whether the options select a subset of the history
+// rather than the whole branch. A range without a base ("..head") only
+// moves the starting point and does not count.
+func (o *CommitOptions) IsFiltered() bool {
+	return (o.Range != "" && !strings.HasPrefix(o.Range, "..")) ||
+		!o.Since.IsZero() ||
+		!o.Until.IsZero() ||
+		o.Author != "" ||
+		len(o.Paths) > 0
+}
+
+// BaselineOptions returns options that walk the unfiltered history up to
+// the same head, used to build the baseline the filtered commits are
+// compared against.
+func (o *CommitOptions) BaselineOptions() (*CommitOptions, error) {
+	_, head, err := o.RangeBounds()
+	if err != nil {
+		return nil, err
+	}
+
+	baseline := &CommitOptions{
+		Branch:   o.Branch,
+		MaxDepth: o.BaselineDepth,
+	}
+	if head != "" {
+		baseline.Range = ".." + head
+	}
+	return baseline, nil
+}
+
+// RangeBounds splits Range into its base and head revisions.
+func (o *CommitOptions) RangeBounds() (base, head string, err error) {
+	if o.Range == "" {
+		return "", "", nil
+	}
+
+	if strings.Contains(o.Range, "...") {
+		return "", "", fmt.Errorf("symmetric difference ranges are not supported: %s", o.Range)
+	}
+
+	base, head, found := strings.Cut(o.Range, "..")
+	if !found {
+		return "", "", fmt.Errorf("invalid revision range '%s' (expected base..head)", o.Range)
+	}
+
+	return base, head, nil
+}
+
+func (o *CommitOptions) matchesCommit(c *Commit) bool {
+	if !o.Since.IsZero() && c.Timestamp.Before(o.Since) {
+		return false
+	}
+	if !o.Until.IsZero() && c.Timestamp.After(o.Until) {
+		return false
+	}
+	if o.Author != "" {
+		author := strings.ToLower(o.Author)
+		if !strings.Contains(strings.ToLower(c.Author), author) &&
+			!strings.Contains(strings.ToLower(c.Email), author) {
+			return false
+		}
+	}
+	return true
+}
+
+func matchesPath(filters []string, path string) bool {
+	for _, filter := range filters {
+		filter = strings.TrimSuffix(filter, "/")
+		if path == filter || strings.HasPrefix(path, filter+"/") {
+			return true
+		}
+		if matched, err := filepath.Match(filter, path); err == nil && matched {
+			return true
+		}
+	}
+	return false
 }
@@ -175,3 +175,55 @@ func TestCommitOptionsStructure(t *testing.T) {
 		}
 	})
 }
+
+func TestCommitOptionsFilters(t *testing.T) {
+	t.Run("is filtered", func(t *testing.T) {
+		if (&CommitOptions{Branch: "main", MaxDepth: 10}).IsFiltered() {
+			t.Error("branch and depth alone should not count as filters")
+		}
+		if (&CommitOptions{Range: "..head"}).IsFiltered() {
+			t.Error("range without base should not count as a filter")
+		}
+		filtered := []*CommitOptions{
+			{Range: "a..b"},
+			{Since: time.Now()},
+			{Until: time.Now()},
+			{Author: "bob"},
+			{Paths: []string{"src"}},
+		}
+		for _, opts := range filtered {
+			if !opts.IsFiltered() {
+				t.Errorf("IsFiltered() = false for %+v", opts)
+			}
+		}
+	})
+
+	t.Run("baseline options keep range head", func(t *testing.T) {
+		opts := &CommitOptions{Branch: "main", Range: "base..head", Author: "bob", BaselineDepth: 50}
+		baseline, err := opts.BaselineOptions()
+		if err != nil {
+			t.Fatalf("BaselineOptions() unexpected error = %v", err)
+		}
+		if baseline.Range != "..head" || baseline.Author != "" || baseline.MaxDepth != 50 || baseline.Branch != "main" {
+			t.Errorf("BaselineOptions() = %+v", baseline)
+		}
+	})
+
+	t.Run("path matching", func(t *testing.T) {
+		tests := []struct {
+			path string
+			want bool
+		}{
+			{"internal/git/repository.go", true},
+			{"internal/gitx/file.go", false},
+			{"cmd/main.go", true},
+			{"README.md", false},
+		}
+		filters := []string{"internal/git/", "cmd/*.go"}
+		for _, tt := range tests {
+			if got := matchesPath(filters, tt.path); got != tt.want {
+				t.Errorf("matchesPath(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		}
+	})
+}
@@ -0,0 +1,291 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/TryCadence/Cadence/internal/gosource"
+)
+
+type diffJob struct {
+	fromHash string
+	toHash   string
+}
+
+type diffResult struct {
+	stats   *DiffStats
+	content string
+	err     error
+}
+
+// computeDiffs diffs every job on a bounded worker pool. Results are
+// returned in job order so reports stay stable regardless of scheduling.
+// Jobs found in the cache skip the pool entirely, unless the cache kept
+// only their stats.
+//
+// go-git's object storage is not safe for concurrent use, so every worker
+// beyond the first opens its own handle on the repository.
+func (r *gitRepository) computeDiffs(jobs []diffJob) []diffResult {
+	results := make([]diffResult, len(jobs))
+
+	pending := make([]int, 0, len(jobs))
+	for i, job := range jobs {
+		if r.cache != nil {
+			if stats, content, complete, ok := r.cache.GetDiff(job.fromHash, job.toHash); ok && complete {
+				results[i] = diffResult{stats: stats, content: content}
+				continue
+			}
+		}
+		pending = append(pending, i)
+	}
+
+	if len(pending) == 0 {
+		return results
+	}
+
+	workers := r.concurrency
+	if workers > len(pending) {
+		workers = len(pending)
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		repo := r.repo
+		var openErr error
+		if w > 0 {
+			repo, openErr = git.PlainOpen(r.path)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if openErr != nil {
+					results[i].err = fmt.Errorf("failed to open repository: %w", openErr)
+					continue
+				}
+				stats, content, err := r.diff(repo, jobs[i].fromHash, jobs[i].toHash)
+				results[i] = diffResult{stats: stats, content: content, err: err}
+				if err == nil && r.cache != nil {
+					// The cache is best effort; a failed write only costs a
+					// recomputation next run.
+					_ = r.cache.PutDiff(jobs[i].fromHash, jobs[i].toHash, stats, content)
+				}
+			}
+		}()
+	}
+
+	for _, i := range pending {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// diff walks the tree diff between two commits once, producing both the
+// line statistics and the patch text of non-excluded files. Files are
+// excluded by exclude_files or by linguist-generated, linguist-vendored or
+// -diff in the .gitattributes of the revision.
+func (r *gitRepository) diff(repo *git.Repository, fromHash, toHash string) (*DiffStats, string, error) {
+	fromCommit, err := repo.CommitObject(plumbing.NewHash(fromHash))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get from commit: %w", err)
+	}
+
+	toCommit, err := repo.CommitObject(plumbing.NewHash(toHash))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get to commit: %w", err)
+	}
+
+	fromTree, err := fromCommit.Tree()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get from tree: %w", err)
+	}
+
+	toTree, err := toCommit.Tree()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get to tree: %w", err)
+	}
+
+	changes, err := fromTree.Diff(toTree)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get diff: %w", err)
+	}
+
+	// Attributes are read from the side of the diff that holds the file.
+	fromAttrs := newAttributeSource(fromTree)
+	toAttrs := newAttributeSource(toTree)
+
+	stats := &DiffStats{}
+	filesChanged := make(map[string]bool)
+	filesChangedTotal := make(map[string]bool)
+	var diffContent strings.Builder
+
+	for _, change := range changes {
+		patch, err := change.Patch()
+		if err != nil {
+			continue
+		}
+
+		// A change holds a single file, so its first patch decides whether
+		// the patch text is kept.
+		keepContent := false
+
+		for i, filePatch := range patch.FilePatches() {
+			from, to := filePatch.Files()
+
+			var filePath string
+			reason := notExcluded
+			if to != nil {
+				filePath = to.Path()
+				reason = r.exclusionReason(filePath, toAttrs)
+			} else if from != nil {
+				filePath = from.Path()
+				reason = r.exclusionReason(filePath, fromAttrs)
+			}
+
+			if from != nil {
+				filesChangedTotal[from.Path()] = true
+			}
+			if to != nil {
+				filesChangedTotal[to.Path()] = true
+			}
+
+			if reason == notExcluded {
+				if from != nil {
+					filesChanged[from.Path()] = true
+				}
+				if to != nil {
+					filesChanged[to.Path()] = true
+				}
+				if i == 0 {
+					keepContent = true
+				}
+			}
+
+			var file FileStats
+			for _, chunk := range filePatch.Chunks() {
+				lines := strings.Split(chunk.Content(), "\n")
+				for _, line := range lines {
+					if line == "" {
+						continue
+					}
+					switch chunk.Type() {
+					case diff.Add:
+						stats.TotalAdditions++
+						if reason == notExcluded {
+							stats.Additions++
+							file.Additions++
+						} else {
+							stats.Dropped.count(reason, 1)
+						}
+					case diff.Delete:
+						stats.TotalDeletions++
+						if reason == notExcluded {
+							stats.Deletions++
+							file.Deletions++
+						} else {
+							stats.Dropped.count(reason, 1)
+						}
+					}
+				}
+			}
+
+			if reason == notExcluded && filePath != "" && file.Additions+file.Deletions > 0 {
+				file.Path = filePath
+				stats.Files = append(stats.Files, file)
+			}
+
+			if r.analyzeGo && reason == notExcluded && to != nil && strings.HasSuffix(filePath, ".go") && !filePatch.IsBinary() {
+				analyzeGo(stats, toTree, filePath, filePatch.Chunks())
+			}
+		}
+
+		if keepContent {
+			diffContent.WriteString(patch.String())
+		}
+	}
+
+	stats.FilesChanged = len(filesChanged)
+	stats.FilesChangedTotal = len(filesChangedTotal)
+
+	return stats, diffContent.String(), nil
+}
+
+// analyzeGo parses the post-image of a changed Go file and adds the
+// signals of its added lines to stats. Files that cannot be read or parsed
+// are counted as parse errors.
+func analyzeGo(stats *DiffStats, tree *object.Tree, path string, chunks []diff.Chunk) {
+	changed := addedLines(chunks)
+	if len(changed) == 0 {
+		return
+	}
+	if stats.Go == nil {
+		stats.Go = &gosource.Signals{}
+	}
+
+	file, err := tree.File(path)
+	if err != nil {
+		stats.Go.ParseErrors++
+		return
+	}
+	content, err := file.Contents()
+	if err != nil {
+		stats.Go.ParseErrors++
+		return
+	}
+	signals, err := gosource.Analyze(path, []byte(content), changed)
+	if err != nil {
+		stats.Go.ParseErrors++
+		return
+	}
+	stats.Go.Add(signals)
+}
+
+// addedLines maps a file patch's added chunks to line ranges of the
+// post-image. Deleted lines do not exist there and do not advance it.
+func addedLines(chunks []diff.Chunk) []gosource.LineRange {
+	var ranges []gosource.LineRange
+	line := 1
+	for _, chunk := range chunks {
+		content := chunk.Content()
+		n := strings.Count(content, "\n")
+		if content != "" && !strings.HasSuffix(content, "\n") {
+			n++
+		}
+		switch chunk.Type() {
+		case diff.Equal:
+			line += n
+		case diff.Add:
+			if n > 0 {
+				ranges = append(ranges, gosource.LineRange{Start: line, End: line + n - 1})
+			}
+			line += n
+		}
+	}
+	return ranges
+}
+
+// exclusionReason reports why a file is left out of the stats, checking
+// exclude_files before the file's attributes at the revision.
+func (r *gitRepository) exclusionReason(filePath string, attrs *attributeSource) exclusionReason {
+	if r.shouldExcludeFile(filePath) {
+		return excludedByPattern
+	}
+	return attrs.exclusionReason(filePath)
+}
+
+func (r *gitRepository) GetCommitDiff(fromHash, toHash string) (string, error) {
+	_, content, err := r.diff(r.repo, fromHash, toHash)
+	return content, err
+}
@@ -0,0 +1,68 @@
+package git
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+)
+
+// ExcludeMatcher matches repository paths against exclude_files patterns
+// using .gitignore semantics:
+//
+//   - "*.min.js" without a slash matches the name at any depth
+//   - "node_modules/" with a trailing slash matches a directory at any depth
+//   - "/build" or "docs/*.md" containing a slash is anchored to the root
+//   - "**" spans any number of directories ("**/testdata/**", "a/**/b")
+//   - "!keep.me" re-includes paths excluded by an earlier pattern
+//
+// Later patterns take precedence over earlier ones. Blank lines and
+// "#" comments are ignored so patterns can be pasted from a .gitignore.
+type ExcludeMatcher struct {
+	matcher gitignore.Matcher
+	empty   bool
+}
+
+func NewExcludeMatcher(patterns []string) (*ExcludeMatcher, error) {
+	parsed := make([]gitignore.Pattern, 0, len(patterns))
+	for _, p := range patterns {
+		trimmed := strings.TrimSpace(p)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if err := validatePattern(trimmed); err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, gitignore.ParsePattern(trimmed, nil))
+	}
+
+	return &ExcludeMatcher{
+		matcher: gitignore.NewMatcher(parsed),
+		empty:   len(parsed) == 0,
+	}, nil
+}
+
+// Match reports whether the file at path (slash separated, relative to the
+// repository root) is excluded.
+func (m *ExcludeMatcher) Match(path string) bool {
+	if m == nil || m.empty || path == "" {
+		return false
+	}
+	return m.matcher.Match(strings.Split(filepath.ToSlash(path), "/"), false)
+}
+
+// validatePattern rejects patterns filepath.Match cannot compile, which the
+// gitignore matcher would otherwise treat as never matching.
+func validatePattern(pattern string) error {
+	body := strings.TrimPrefix(pattern, "!")
+	for _, segment := range strings.Split(body, "/") {
+		if segment == "" || segment == "**" {
+			continue
+		}
+		if _, err := filepath.Match(segment, ""); err != nil {
+			return fmt.Errorf("invalid exclude pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package git
+
+import "testing"
+
+func TestExcludeMatcher(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		path     string
+		want     bool
+	}{
+		{name: "no patterns", patterns: nil, path: "main.go", want: false},
+		{name: "basename glob at root", patterns: []string{"*.log"}, path: "debug.log", want: true},
+		{name: "basename glob nested", patterns: []string{"*.log"}, path: "logs/2024/debug.log", want: true},
+		{name: "basename glob no match", patterns: []string{"*.log"}, path: "main.go", want: false},
+		{name: "exact name nested", patterns: []string{"package-lock.json"}, path: "web/package-lock.json", want: true},
+		{name: "directory pattern at root", patterns: []string{"node_modules/"}, path: "node_modules/react/index.js", want: true},
+		{name: "directory pattern nested", patterns: []string{"node_modules/"}, path: "packages/app/node_modules/react/index.js", want: true},
+		{name: "directory pattern does not match file", patterns: []string{"node_modules/"}, path: "src/node_modules", want: false},
+		{name: "double star suffix at root", patterns: []string{"node_modules/**"}, path: "node_modules/a/b/c.js", want: true},
+		{name: "double star suffix is anchored", patterns: []string{"node_modules/**"}, path: "web/node_modules/a.js", want: false},
+		{name: "double star prefix", patterns: []string{"**/testdata/**"}, path: "internal/git/testdata/repo/file.txt", want: true},
+		{name: "double star prefix at root", patterns: []string{"**/testdata/**"}, path: "testdata/file.txt", want: true},
+		{name: "double star in the middle", patterns: []string{"src/**/*.pb.go"}, path: "src/api/v1/user.pb.go", want: true},
+		{name: "double star in the middle zero dirs", patterns: []string{"src/**/*.pb.go"}, path: "src/user.pb.go", want: true},
+		{name: "double star in the middle wrong root", patterns: []string{"src/**/*.pb.go"}, path: "lib/api/user.pb.go", want: false},
+		{name: "leading slash anchors", patterns: []string{"/build"}, path: "build/out.js", want: true},
+		{name: "leading slash does not match nested", patterns: []string{"/build"}, path: "tools/build/out.js", want: false},
+		{name: "inner slash anchors", patterns: []string{"docs/*.md"}, path: "docs/intro.md", want: true},
+		{name: "inner slash single level", patterns: []string{"docs/*.md"}, path: "docs/api/intro.md", want: false},
+		{name: "inner slash not nested", patterns: []string{"docs/*.md"}, path: "site/docs/intro.md", want: false},
+		{name: "negation re-includes", patterns: []string{"*.log", "!keep.log"}, path: "keep.log", want: false},
+		{name: "negation leaves others", patterns: []string{"*.log", "!keep.log"}, path: "drop.log", want: true},
+		{name: "later pattern wins", patterns: []string{"!keep.log", "*.log"}, path: "keep.log", want: true},
+		{name: "negation inside directory", patterns: []string{"vendor/", "!vendor/modules.txt"}, path: "vendor/modules.txt", want: false},
+		{name: "comments and blanks ignored", patterns: []string{"# generated", "", "*.gen.go"}, path: "a.gen.go", want: true},
+		{name: "character class", patterns: []string{"*.[oa]"}, path: "lib/x.a", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewExcludeMatcher(tt.patterns)
+			if err != nil {
+				t.Fatalf("NewExcludeMatcher(%q) unexpected error = %v", tt.patterns, err)
+			}
+			if got := m.Match(tt.path); got != tt.want {
+				t.Errorf("Match(%q) with %q = %v, want %v", tt.path, tt.patterns, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewExcludeMatcher_InvalidPattern(t *testing.T) {
+	if _, err := NewExcludeMatcher([]string{"*.log", "src/[a-"}); err == nil {
+		t.Error("NewExcludeMatcher() expected error for malformed pattern")
+	}
+
+	if _, err := OpenRepository(createTestRepo(t), &RepositoryOptions{ExcludeFiles: []string{"[z-a"}}); err == nil {
+		t.Error("OpenRepository() expected error for malformed exclude pattern")
+	}
+}
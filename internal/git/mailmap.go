@@ -0,0 +1,197 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+)
+
+const mailmapFile = ".mailmap"
+
+// AuthorAlias folds alternate names and emails of one person into a
+// canonical identity. Aliases containing "@" match emails, others match
+// names; both comparisons ignore case.
+type AuthorAlias struct {
+	Name    string
+	Email   string
+	Aliases []string
+}
+
+// IdentityResolver maps raw commit identities to canonical ones using a
+// .mailmap followed by configured aliases.
+type IdentityResolver struct {
+	// mailmap entries keyed by commit email, then by commit name ("" for
+	// entries that match any name).
+	mailmap map[string]map[string]mailmapEntry
+
+	aliasByEmail map[string]AuthorAlias
+	aliasByName  map[string]AuthorAlias
+}
+
+type mailmapEntry struct {
+	name  string
+	email string
+}
+
+// NewIdentityResolver parses mailmap (the contents of a .mailmap file, may
+// be empty) and indexes aliases.
+func NewIdentityResolver(mailmap string, aliases []AuthorAlias) (*IdentityResolver, error) {
+	r := &IdentityResolver{
+		mailmap:      make(map[string]map[string]mailmapEntry),
+		aliasByEmail: make(map[string]AuthorAlias),
+		aliasByName:  make(map[string]AuthorAlias),
+	}
+
+	if err := r.parseMailmap(strings.NewReader(mailmap)); err != nil {
+		return nil, err
+	}
+
+	for _, alias := range aliases {
+		if alias.Email == "" {
+			return nil, fmt.Errorf("author alias for %q is missing an email", alias.Name)
+		}
+		r.aliasByEmail[strings.ToLower(alias.Email)] = alias
+		for _, a := range alias.Aliases {
+			key := strings.ToLower(strings.TrimSpace(a))
+			if key == "" {
+				continue
+			}
+			if strings.Contains(key, "@") {
+				r.aliasByEmail[key] = alias
+			} else {
+				r.aliasByName[key] = alias
+			}
+		}
+	}
+
+	return r, nil
+}
+
+// Resolve returns the canonical name and email for a commit identity.
+// Unknown identities are returned unchanged.
+func (r *IdentityResolver) Resolve(name, email string) (string, string) {
+	if r == nil {
+		return name, email
+	}
+
+	if byName, ok := r.mailmap[strings.ToLower(email)]; ok {
+		entry, ok := byName[strings.ToLower(name)]
+		if !ok {
+			entry, ok = byName[""]
+		}
+		if ok {
+			if entry.name != "" {
+				name = entry.name
+			}
+			if entry.email != "" {
+				email = entry.email
+			}
+		}
+	}
+
+	alias, ok := r.aliasByEmail[strings.ToLower(email)]
+	if !ok {
+		alias, ok = r.aliasByName[strings.ToLower(name)]
+	}
+	if ok {
+		if alias.Name != "" {
+			name = alias.Name
+		}
+		email = alias.Email
+	}
+
+	return name, email
+}
+
+// parseMailmap reads the forms documented in gitmailmap(5):
+//
+//	Proper Name <commit@email>
+//	<proper@email> <commit@email>
+//	Proper Name <proper@email> <commit@email>
+//	Proper Name <proper@email> Commit Name <commit@email>
+func (r *IdentityResolver) parseMailmap(reader io.Reader) error {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		properName, properEmail, rest, ok := parseMailmapIdentity(line)
+		if !ok {
+			// git silently ignores lines it cannot parse.
+			continue
+		}
+
+		commitName, commitEmail, _, ok := parseMailmapIdentity(rest)
+		if !ok {
+			// Single identity: the email is the one found in commits.
+			commitName, commitEmail, properEmail = "", properEmail, ""
+		}
+
+		key := strings.ToLower(commitEmail)
+		if r.mailmap[key] == nil {
+			r.mailmap[key] = make(map[string]mailmapEntry)
+		}
+		r.mailmap[key][strings.ToLower(commitName)] = mailmapEntry{name: properName, email: properEmail}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read mailmap: %w", err)
+	}
+	return nil
+}
+
+// parseMailmapIdentity splits "Name <email>" off the front of s.
+func parseMailmapIdentity(s string) (name, email, rest string, ok bool) {
+	open := strings.Index(s, "<")
+	if open < 0 {
+		return "", "", "", false
+	}
+	closing := strings.Index(s[open:], ">")
+	if closing < 0 {
+		return "", "", "", false
+	}
+	closing += open
+
+	return strings.TrimSpace(s[:open]), strings.TrimSpace(s[open+1 : closing]), s[closing+1:], true
+}
+
+// readMailmap returns the repository's .mailmap, preferring the working
+// tree and falling back to HEAD for bare repositories. A missing file is
+// not an error.
+func readMailmap(repo *git.Repository, path string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(path, mailmapFile)) // #nosec G304 -- path is the repository being analyzed
+	if err == nil {
+		return string(data), nil
+	}
+	if !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read %s: %w", mailmapFile, err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return "", nil
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return "", nil
+	}
+	file, err := commit.File(mailmapFile)
+	if err != nil {
+		return "", nil
+	}
+	content, err := file.Contents()
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", mailmapFile, err)
+	}
+	return content, nil
+}
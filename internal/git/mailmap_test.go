@@ -0,0 +1,121 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestIdentityResolver_Resolve(t *testing.T) {
+	mailmap := `# Comments and blank lines are ignored
+
+Jane Doe <jane@work.com>
+<jane@work.com> <jane@users.noreply.github.com>
+Jane Doe <jane@work.com> <JDOE@Old.com>
+Bob Smith <bob@work.com> bobby <bob@laptop.local>
+not an entry
+`
+	aliases := []AuthorAlias{
+		{Name: "Bob Smith", Email: "bob@work.com", Aliases: []string{"robert@personal.org", "Robert S"}},
+		{Email: "ci@work.com", Aliases: []string{"build-bot@work.com"}},
+	}
+
+	resolver, err := NewIdentityResolver(mailmap, aliases)
+	if err != nil {
+		t.Fatalf("NewIdentityResolver() unexpected error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		inName    string
+		inEmail   string
+		wantName  string
+		wantEmail string
+	}{
+		{name: "proper name only", inName: "jane", inEmail: "jane@work.com", wantName: "Jane Doe", wantEmail: "jane@work.com"},
+		{name: "proper email only", inName: "Jane", inEmail: "jane@users.noreply.github.com", wantName: "Jane", wantEmail: "jane@work.com"},
+		{name: "name and email case-insensitive", inName: "J. Doe", inEmail: "jdoe@old.com", wantName: "Jane Doe", wantEmail: "jane@work.com"},
+		{name: "commit name must match", inName: "bobby", inEmail: "bob@laptop.local", wantName: "Bob Smith", wantEmail: "bob@work.com"},
+		{name: "commit name mismatch", inName: "someone", inEmail: "bob@laptop.local", wantName: "someone", wantEmail: "bob@laptop.local"},
+		{name: "alias by email", inName: "Rob", inEmail: "Robert@Personal.org", wantName: "Bob Smith", wantEmail: "bob@work.com"},
+		{name: "alias by name", inName: "robert s", inEmail: "rs@elsewhere.net", wantName: "Bob Smith", wantEmail: "bob@work.com"},
+		{name: "alias without name keeps name", inName: "Build Bot", inEmail: "build-bot@work.com", wantName: "Build Bot", wantEmail: "ci@work.com"},
+		{name: "unknown identity", inName: "Eve", inEmail: "eve@example.com", wantName: "Eve", wantEmail: "eve@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, email := resolver.Resolve(tt.inName, tt.inEmail)
+			if name != tt.wantName || email != tt.wantEmail {
+				t.Errorf("Resolve(%q, %q) = %q, %q, want %q, %q", tt.inName, tt.inEmail, name, email, tt.wantName, tt.wantEmail)
+			}
+		})
+	}
+
+	t.Run("alias requires email", func(t *testing.T) {
+		if _, err := NewIdentityResolver("", []AuthorAlias{{Name: "Nobody"}}); err == nil {
+			t.Error("NewIdentityResolver() expected error for alias without email")
+		}
+	})
+}
+
+func TestGitRepository_GetCommits_Mailmap(t *testing.T) {
+	dir := t.TempDir()
+	runGit(t, dir, "2024-01-01T10:00:00Z", "init", "-b", "main")
+
+	commit := func(date, name, email, content string) {
+		if err := os.WriteFile(filepath.Join(dir, "file.txt"), []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write file.txt: %v", err)
+		}
+		runGit(t, dir, date, "add", "-A")
+		runGit(t, dir, date, "-c", "user.name="+name, "-c", "user.email="+email, "commit", "-m", content)
+	}
+
+	commit("2024-01-01T10:00:00Z", "Jane Doe", "jane@work.com", "one\n")
+	commit("2024-01-01T11:00:00Z", "jane", "12345+jane@users.noreply.github.com", "one\ntwo\n")
+	commit("2024-01-01T12:00:00Z", "Jane at home", "jane@home.net", "one\ntwo\nthree\n")
+
+	mailmap := "Jane Doe <jane@work.com> <12345+jane@users.noreply.github.com>\n"
+	if err := os.WriteFile(filepath.Join(dir, ".mailmap"), []byte(mailmap), 0o600); err != nil {
+		t.Fatalf("Failed to write .mailmap: %v", err)
+	}
+
+	repo, err := OpenRepository(dir, &RepositoryOptions{
+		Aliases: []AuthorAlias{{Name: "Jane Doe", Email: "jane@work.com", Aliases: []string{"jane@home.net"}}},
+	})
+	if err != nil {
+		t.Fatalf("OpenRepository() unexpected error = %v", err)
+	}
+	defer repo.Close()
+
+	commits, err := repo.GetCommits(nil)
+	if err != nil {
+		t.Fatalf("GetCommits() error = %v", err)
+	}
+	raw := make(map[string]bool)
+	for _, c := range commits {
+		if c.Author != "Jane Doe" || c.Email != "jane@work.com" {
+			t.Errorf("commit %s author = %q <%s>, want Jane Doe <jane@work.com>", c.Hash[:7], c.Author, c.Email)
+		}
+		raw[c.RawEmail] = true
+	}
+	for _, email := range []string{"jane@work.com", "12345+jane@users.noreply.github.com", "jane@home.net"} {
+		if !raw[email] {
+			t.Errorf("no commit keeps the raw email %s", email)
+		}
+	}
+	if diversity := CalculateAuthorDiversity(commits); diversity != 0 {
+		t.Errorf("CalculateAuthorDiversity() = %f, want 0 for a single resolved author", diversity)
+	}
+
+	pairs, err := repo.(*gitRepository).GetCommitPairs(commits)
+	if err != nil {
+		t.Fatalf("GetCommitPairs() error = %v", err)
+	}
+	for _, p := range pairs {
+		if p.TimeDelta != time.Hour {
+			t.Errorf("pair %s TimeDelta = %v, want 1h from the same resolved author", p.Current.Hash[:7], p.TimeDelta)
+		}
+	}
+}
@@ -4,17 +4,34 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"path/filepath"
-	"strings"
+	"runtime"
+	"sort"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
-	"github.com/go-git/go-git/v5/plumbing/format/diff"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
 )
 
 type RepositoryOptions struct {
+	// ExcludeFiles are .gitignore-style patterns of files left out of the
+	// diff stats and content. See ExcludeMatcher.
 	ExcludeFiles []string
+	// Aliases fold alternate author identities together after the
+	// repository's .mailmap has been applied.
+	Aliases []AuthorAlias
+	// Concurrency bounds how many commit pairs are diffed in parallel.
+	// Zero uses one worker per CPU.
+	Concurrency int
+	// Cache, when set, serves previously computed diffs and stores new ones.
+	Cache DiffCache
+	// MergeParent pairs merge commits with their MergeParent-th parent
+	// (1 = first parent). Zero skips merge commits entirely.
+	MergeParent int
+	// AnalyzeGo parses the changed .go files of every pair into
+	// DiffStats.Go. Set it when go_source_analysis runs; parsing costs a
+	// read of each file's post-image.
+	AnalyzeGo bool
 }
 
 type Repository interface {
@@ -30,10 +47,30 @@ type DiffProvider interface {
 	GetCommitDiff(fromHash, toHash string) (string, error)
 }
 
+// BlameProvider attributes the lines of the files at the head of a branch
+// to the commits that last changed them.
+type BlameProvider interface {
+	Blame(branch string) ([]FileBlame, []SkippedFile, error)
+}
+
+// DiffCache persists diff results between runs. Commits are immutable, so
+// a pair's stats and content never change for a given exclusion setup.
+// GetDiff reports complete as false when the cache kept only the stats of
+// a pair, whose content then has to be computed again.
+type DiffCache interface {
+	GetDiff(fromHash, toHash string) (stats *DiffStats, content string, complete, ok bool)
+	PutDiff(fromHash, toHash string, stats *DiffStats, content string) error
+}
+
 type gitRepository struct {
-	repo         *git.Repository
-	path         string
-	excludeFiles []string
+	repo        *git.Repository
+	path        string
+	exclude     *ExcludeMatcher
+	identities  *IdentityResolver
+	mergeParent int
+	concurrency int
+	cache       DiffCache
+	analyzeGo   bool
 }
 
 func OpenRepository(path string, opts *RepositoryOptions) (Repository, error) {
@@ -50,10 +87,43 @@ func OpenRepository(path string, opts *RepositoryOptions) (Repository, error) {
 		return nil, fmt.Errorf("failed to open repository at '%s': %w", path, err)
 	}
 
+	if opts.MergeParent < 0 {
+		return nil, fmt.Errorf("merge parent cannot be negative")
+	}
+
+	if opts.Concurrency < 0 {
+		return nil, fmt.Errorf("concurrency cannot be negative")
+	}
+
+	exclude, err := NewExcludeMatcher(opts.ExcludeFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	mailmap, err := readMailmap(r, path)
+	if err != nil {
+		return nil, err
+	}
+
+	identities, err := NewIdentityResolver(mailmap, opts.Aliases)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency == 0 {
+		concurrency = runtime.NumCPU()
+	}
+
 	return &gitRepository{
-		repo:         r,
-		path:         path,
-		excludeFiles: opts.ExcludeFiles,
+		repo:        r,
+		path:        path,
+		exclude:     exclude,
+		identities:  identities,
+		mergeParent: opts.MergeParent,
+		concurrency: concurrency,
+		cache:       opts.Cache,
+		analyzeGo:   opts.AnalyzeGo,
 	}, nil
 }
 
@@ -62,29 +132,22 @@ func (r *gitRepository) GetCommits(opts *CommitOptions) ([]*Commit, error) {
 		opts = &CommitOptions{}
 	}
 
-	var ref *plumbing.Reference
-	var err error
+	base, head, err := opts.RangeBounds()
+	if err != nil {
+		return nil, err
+	}
 
-	if opts.Branch != "" {
-		ref, err = r.repo.Reference(plumbing.ReferenceName("refs/heads/"+opts.Branch), true)
-		if err != nil {
-			// If the specified branch doesn't exist, fall back to HEAD
-			fmt.Fprintf(os.Stderr, "Warning: branch '%s' not found, using default branch\n", opts.Branch)
-			ref, err = r.repo.Head()
-			if err != nil {
-				return nil, fmt.Errorf("failed to get HEAD: %w", err)
-			}
-		}
-	} else {
-		ref, err = r.repo.Head()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get HEAD: %w", err)
-		}
+	from, err := r.resolveHead(opts.Branch, head)
+	if err != nil {
+		return nil, err
 	}
 
-	commitIter, err := r.repo.Log(&git.LogOptions{
-		From: ref.Hash(),
-	})
+	excluded, err := r.ancestors(base)
+	if err != nil {
+		return nil, err
+	}
+
+	commitIter, err := r.repo.Log(&git.LogOptions{From: from})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create commit iterator: %w", err)
 	}
@@ -98,19 +161,26 @@ func (r *gitRepository) GetCommits(opts *CommitOptions) ([]*Commit, error) {
 			return io.EOF
 		}
 
-		parents := make([]string, len(c.ParentHashes))
-		for i, p := range c.ParentHashes {
-			parents[i] = p.String()
+		if excluded[c.Hash] {
+			return nil
 		}
 
-		commits = append(commits, &Commit{
-			Hash:      c.Hash.String(),
-			Author:    c.Author.Name,
-			Email:     c.Author.Email,
-			Timestamp: c.Author.When,
-			Message:   c.Message,
-			Parents:   parents,
-		})
+		commit := r.newCommit(c)
+		if !opts.matchesCommit(commit) {
+			return nil
+		}
+
+		if len(opts.Paths) > 0 {
+			touched, err := touchesPaths(c, opts.Paths)
+			if err != nil {
+				return err
+			}
+			if !touched {
+				return nil
+			}
+		}
+
+		commits = append(commits, commit)
 
 		count++
 		return nil
@@ -123,221 +193,263 @@ func (r *gitRepository) GetCommits(opts *CommitOptions) ([]*Commit, error) {
 	return commits, nil
 }
 
-func (r *gitRepository) GetCommitPairs(commits []*Commit) ([]*CommitPair, error) {
-	if len(commits) < 2 {
-		return []*CommitPair{}, nil
+// touchesPaths reports whether a commit changes a path matching filters.
+// Like "git log -- <path>", a merge only counts when the matching paths
+// differ from every parent. go-git's LogOptions.PathFilter diffs successive
+// commits of the log instead, which is wrong on branchy history.
+func touchesPaths(c *object.Commit, filters []string) (bool, error) {
+	tree, err := c.Tree()
+	if err != nil {
+		return false, fmt.Errorf("failed to get tree for %s: %w", c.Hash, err)
 	}
 
-	pairs := make([]*CommitPair, 0)
-
-	for i := 0; i < len(commits)-1; i++ {
-		current := commits[i]
-		previous := commits[i+1]
-
-		if len(current.Parents) > 1 {
-			continue
-		}
-
-		timeDelta := current.Timestamp.Sub(previous.Timestamp)
-		if timeDelta <= 0 {
-			continue
-		}
+	if c.NumParents() == 0 {
+		return treeChangesMatch(nil, tree, filters)
+	}
 
-		stats, err := r.getDiffStats(previous.Hash, current.Hash)
+	touched := false
+	err = c.Parents().ForEach(func(parent *object.Commit) error {
+		parentTree, err := parent.Tree()
 		if err != nil {
-			continue
+			return fmt.Errorf("failed to get tree for %s: %w", parent.Hash, err)
 		}
 
-		// Get the actual diff content for AI analysis
-		diffContent, err := r.GetCommitDiff(previous.Hash, current.Hash)
+		touched, err = treeChangesMatch(parentTree, tree, filters)
 		if err != nil {
-			// If we can't get diff content, continue without it
-			diffContent = ""
+			return err
 		}
-
-		pairs = append(pairs, &CommitPair{
-			Previous:    previous,
-			Current:     current,
-			TimeDelta:   timeDelta,
-			Stats:       stats,
-			DiffContent: diffContent,
-		})
+		if !touched {
+			return storer.ErrStop
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
 	}
 
-	return pairs, nil
+	return touched, nil
 }
 
-func (r *gitRepository) shouldExcludeFile(filePath string) bool {
-	if len(r.excludeFiles) == 0 {
-		return false
+func treeChangesMatch(from, to *object.Tree, filters []string) (bool, error) {
+	changes, err := object.DiffTree(from, to)
+	if err != nil {
+		return false, fmt.Errorf("failed to diff trees: %w", err)
 	}
 
-	for _, pattern := range r.excludeFiles {
-		matched, err := filepath.Match(pattern, filepath.Base(filePath))
-		if err == nil && matched {
-			return true
+	for _, change := range changes {
+		if matchesPath(filters, change.From.Name) || matchesPath(filters, change.To.Name) {
+			return true, nil
 		}
+	}
+
+	return false, nil
+}
 
-		matched, err = filepath.Match(pattern, filePath)
-		if err == nil && matched {
-			return true
+// resolveHead picks the commit the log walk starts from: the head of a
+// revision range if given, else the branch, else HEAD.
+func (r *gitRepository) resolveHead(branch, revision string) (plumbing.Hash, error) {
+	if revision != "" {
+		hash, err := r.repo.ResolveRevision(plumbing.Revision(revision))
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("failed to resolve revision '%s': %w", revision, err)
 		}
+		return *hash, nil
 	}
 
-	return false
-}
+	if branch != "" {
+		ref, err := r.repo.Reference(plumbing.ReferenceName("refs/heads/"+branch), true)
+		if err == nil {
+			return ref.Hash(), nil
+		}
+		// If the specified branch doesn't exist, fall back to HEAD
+		fmt.Fprintf(os.Stderr, "Warning: branch '%s' not found, using default branch\n", branch)
+	}
 
-func (r *gitRepository) getDiffStats(fromHash, toHash string) (*DiffStats, error) {
-	fromCommit, err := r.repo.CommitObject(plumbing.NewHash(fromHash))
+	ref, err := r.repo.Head()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get from commit: %w", err)
+		return plumbing.ZeroHash, fmt.Errorf("failed to get HEAD: %w", err)
 	}
+	return ref.Hash(), nil
+}
 
-	toCommit, err := r.repo.CommitObject(plumbing.NewHash(toHash))
-	if err != nil {
-		return nil, fmt.Errorf("failed to get to commit: %w", err)
+// ancestors returns every commit reachable from revision, including itself.
+// An empty revision yields an empty set.
+func (r *gitRepository) ancestors(revision string) (map[plumbing.Hash]bool, error) {
+	seen := make(map[plumbing.Hash]bool)
+	if revision == "" {
+		return seen, nil
 	}
 
-	fromTree, err := fromCommit.Tree()
+	hash, err := r.repo.ResolveRevision(plumbing.Revision(revision))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get from tree: %w", err)
+		return nil, fmt.Errorf("failed to resolve revision '%s': %w", revision, err)
 	}
 
-	toTree, err := toCommit.Tree()
+	iter, err := r.repo.Log(&git.LogOptions{From: *hash})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get to tree: %w", err)
+		return nil, fmt.Errorf("failed to create commit iterator: %w", err)
 	}
+	defer iter.Close()
 
-	changes, err := fromTree.Diff(toTree)
+	err = iter.ForEach(func(c *object.Commit) error {
+		seen[c.Hash] = true
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get diff: %w", err)
+		return nil, fmt.Errorf("error iterating commits: %w", err)
 	}
 
-	stats := &DiffStats{}
-	filesChanged := make(map[string]bool)
-	filesChangedTotal := make(map[string]bool)
-
-	for _, change := range changes {
-		patch, err := change.Patch()
-		if err != nil {
-			continue
-		}
+	return seen, nil
+}
 
-		for _, filePatch := range patch.FilePatches() {
-			from, to := filePatch.Files()
+// newCommit converts a go-git commit, resolving the author and committer
+// through the .mailmap and configured aliases.
+func (r *gitRepository) newCommit(c *object.Commit) *Commit {
+	name, email := r.identities.Resolve(c.Author.Name, c.Author.Email)
+	committer, committerEmail := r.identities.Resolve(c.Committer.Name, c.Committer.Email)
 
-			var filePath string
-			if to != nil {
-				filePath = to.Path()
-			} else if from != nil {
-				filePath = from.Path()
-			}
+	parents := make([]string, len(c.ParentHashes))
+	for i, p := range c.ParentHashes {
+		parents[i] = p.String()
+	}
 
-			if from != nil {
-				filesChangedTotal[from.Path()] = true
-			}
-			if to != nil {
-				filesChangedTotal[to.Path()] = true
-			}
+	return &Commit{
+		Hash:      c.Hash.String(),
+		Author:    name,
+		Email:     email,
+		Timestamp: c.Author.When,
+		Message:   c.Message,
+		Parents:   parents,
 
-			isExcluded := r.shouldExcludeFile(filePath)
+		Committer:      committer,
+		CommitterEmail: committerEmail,
+		CommitTime:     c.Committer.When,
 
-			if !isExcluded {
-				if from != nil {
-					filesChanged[from.Path()] = true
-				}
-				if to != nil {
-					filesChanged[to.Path()] = true
-				}
-			}
+		RawAuthor:         c.Author.Name,
+		RawEmail:          c.Author.Email,
+		RawCommitter:      c.Committer.Name,
+		RawCommitterEmail: c.Committer.Email,
 
-			chunks := filePatch.Chunks()
-			for _, chunk := range chunks {
-				lines := strings.Split(chunk.Content(), "\n")
-				for _, line := range lines {
-					if line == "" {
-						continue
-					}
-					switch chunk.Type() {
-					case diff.Add:
-						stats.TotalAdditions++
-						if !isExcluded {
-							stats.Additions++
-						}
-					case diff.Delete:
-						stats.TotalDeletions++
-						if !isExcluded {
-							stats.Deletions++
-						}
-					}
-				}
-			}
-		}
+		Trailers: ParseTrailers(c.Message),
 	}
-
-	stats.FilesChanged = len(filesChanged)
-	stats.FilesChangedTotal = len(filesChangedTotal)
-
-	return stats, nil
 }
 
-func (r *gitRepository) Close() error {
-	return nil
-}
-
-func (r *gitRepository) GetCommitDiff(fromHash, toHash string) (string, error) {
-	fromCommit, err := r.repo.CommitObject(plumbing.NewHash(fromHash))
-	if err != nil {
-		return "", fmt.Errorf("failed to get from commit: %w", err)
+// GetCommitPairs pairs every commit with its parent in the commit graph.
+// Parents outside the given commit set are loaded from the repository, so
+// the oldest commit of a truncated history still gets a pair. TimeDelta is
+// measured from the same author's previous commit when one is present in
+// the set, falling back to the parent's timestamp otherwise.
+func (r *gitRepository) GetCommitPairs(commits []*Commit) ([]*CommitPair, error) {
+	if len(commits) == 0 {
+		return []*CommitPair{}, nil
 	}
 
-	toCommit, err := r.repo.CommitObject(plumbing.NewHash(toHash))
-	if err != nil {
-		return "", fmt.Errorf("failed to get to commit: %w", err)
+	byHash := make(map[string]*Commit, len(commits))
+	for _, c := range commits {
+		byHash[c.Hash] = c
 	}
+	authorPrevious := previousByAuthor(commits)
 
-	fromTree, err := fromCommit.Tree()
-	if err != nil {
-		return "", fmt.Errorf("failed to get from tree: %w", err)
-	}
+	pairs := make([]*CommitPair, 0, len(commits))
+	jobs := make([]diffJob, 0, len(commits))
 
-	toTree, err := toCommit.Tree()
-	if err != nil {
-		return "", fmt.Errorf("failed to get to tree: %w", err)
-	}
+	for _, current := range commits {
+		parentHash, ok := r.pairParent(current)
+		if !ok {
+			continue
+		}
 
-	changes, err := fromTree.Diff(toTree)
-	if err != nil {
-		return "", fmt.Errorf("failed to get diff: %w", err)
-	}
+		previous, ok := byHash[parentHash]
+		if !ok {
+			var err error
+			previous, err = r.lookupCommit(parentHash)
+			if err != nil {
+				continue
+			}
+		}
 
-	var diffContent strings.Builder
-	for _, change := range changes {
-		patch, err := change.Patch()
-		if err != nil {
-			continue
+		since := previous.Timestamp
+		if prior, ok := authorPrevious[current.Hash]; ok {
+			since = prior.Timestamp
 		}
 
-		filePatches := patch.FilePatches()
-		if len(filePatches) == 0 {
+		timeDelta := current.Timestamp.Sub(since)
+		if timeDelta <= 0 {
 			continue
 		}
 
-		// Check first file patch to determine if we should exclude
-		from, to := filePatches[0].Files()
-		var filePath string
-		if to != nil {
-			filePath = to.Path()
-		} else if from != nil {
-			filePath = from.Path()
-		}
+		pairs = append(pairs, &CommitPair{
+			Previous:  previous,
+			Current:   current,
+			TimeDelta: timeDelta,
+		})
+		jobs = append(jobs, diffJob{fromHash: previous.Hash, toHash: current.Hash})
+	}
 
-		if r.shouldExcludeFile(filePath) {
+	results := r.computeDiffs(jobs)
+
+	diffed := pairs[:0]
+	for i, pair := range pairs {
+		if results[i].err != nil {
 			continue
 		}
+		pair.Stats = results[i].stats
+		pair.DiffContent = results[i].content
+		diffed = append(diffed, pair)
+	}
+
+	return diffed, nil
+}
 
-		diffContent.WriteString(patch.String())
+// pairParent returns the hash of the parent a commit is diffed against.
+// Root commits have no pair, and merge commits only get one when
+// mergeParent selects an existing parent.
+func (r *gitRepository) pairParent(c *Commit) (string, bool) {
+	switch {
+	case len(c.Parents) == 0:
+		return "", false
+	case len(c.Parents) == 1:
+		return c.Parents[0], true
+	case r.mergeParent > 0 && r.mergeParent <= len(c.Parents):
+		return c.Parents[r.mergeParent-1], true
+	default:
+		return "", false
 	}
+}
+
+func (r *gitRepository) lookupCommit(hash string) (*Commit, error) {
+	c, err := r.repo.CommitObject(plumbing.NewHash(hash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s: %w", hash, err)
+	}
+	return r.newCommit(c), nil
+}
 
-	return diffContent.String(), nil
+// previousByAuthor maps each commit hash to the most recent earlier commit
+// by the same author email.
+func previousByAuthor(commits []*Commit) map[string]*Commit {
+	ordered := make([]*Commit, len(commits))
+	copy(ordered, commits)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Timestamp.Before(ordered[j].Timestamp)
+	})
+
+	latest := make(map[string]*Commit)
+	previous := make(map[string]*Commit, len(commits))
+	for _, c := range ordered {
+		if prior, ok := latest[c.Email]; ok {
+			previous[c.Hash] = prior
+		}
+		latest[c.Email] = c
+	}
+
+	return previous
+}
+
+func (r *gitRepository) shouldExcludeFile(filePath string) bool {
+	return r.exclude.Match(filePath)
+}
+
+func (r *gitRepository) Close() error {
+	return nil
 }
@@ -4,6 +4,10 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -399,3 +403,500 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+// runGit runs a git command in dir with fixed author and committer dates.
+func runGit(t *testing.T, dir, date string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, out)
+	}
+}
+
+// createBranchyRepo creates a repository where two authors commit on
+// separate branches that are then merged:
+//
+//	main:    A(alice) ---- C(alice) ---- M
+//	feature:      \-- B(bob) -- D(bob) -/
+func createBranchyRepo(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	runGit(t, dir, "2024-01-01T10:00:00Z", "init", "-b", "main")
+
+	commit := func(date, name, email, file, content, msg string) {
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write %s: %v", file, err)
+		}
+		runGit(t, dir, date, "add", file)
+		runGit(t, dir, date, "-c", "user.name="+name, "-c", "user.email="+email, "commit", "-m", msg)
+	}
+
+	commit("2024-01-01T10:00:00Z", "Alice", "alice@example.com", "a.txt", "a\n", "A")
+	runGit(t, dir, "2024-01-01T10:00:00Z", "checkout", "-b", "feature")
+	commit("2024-01-01T11:00:00Z", "Bob", "bob@example.com", "b.txt", "b\n", "B")
+	runGit(t, dir, "2024-01-01T11:00:00Z", "checkout", "main")
+	commit("2024-01-01T12:00:00Z", "Alice", "alice@example.com", "c.txt", "c\nc\n", "C")
+	runGit(t, dir, "2024-01-01T12:00:00Z", "checkout", "feature")
+	commit("2024-01-01T13:00:00Z", "Bob", "bob@example.com", "d.txt", "d\nd\nd\n", "D")
+	runGit(t, dir, "2024-01-01T13:00:00Z", "checkout", "main")
+	runGit(t, dir, "2024-01-01T14:00:00Z", "-c", "user.name=Alice", "-c", "user.email=alice@example.com",
+		"merge", "--no-ff", "-m", "M", "feature")
+
+	return dir
+}
+
+func TestGitRepository_GetCommitPairs_FollowsParents(t *testing.T) {
+	repoPath := createBranchyRepo(t)
+
+	gitRepo, err := OpenRepository(repoPath, nil)
+	if err != nil {
+		t.Fatalf("Failed to open repository: %v", err)
+	}
+	defer gitRepo.Close()
+	repo := gitRepo.(*gitRepository)
+
+	commits, err := repo.GetCommits(nil)
+	if err != nil {
+		t.Fatalf("GetCommits() error = %v", err)
+	}
+
+	pairs, err := repo.GetCommitPairs(commits)
+	if err != nil {
+		t.Fatalf("GetCommitPairs() unexpected error = %v", err)
+	}
+
+	byMessage := make(map[string]*CommitPair)
+	for _, pair := range pairs {
+		if pair.Previous.Hash != pair.Current.Parents[0] {
+			t.Errorf("pair %q: Previous = %s, want parent %s", pair.Current.Message, pair.Previous.Hash, pair.Current.Parents[0])
+		}
+		byMessage[strings.TrimSpace(pair.Current.Message)] = pair
+	}
+
+	if len(pairs) != 3 {
+		t.Fatalf("len(pairs) = %d, want 3 (B, C, D; root and merge skipped)", len(pairs))
+	}
+
+	tests := []struct {
+		message   string
+		parent    string
+		timeDelta time.Duration
+		additions int64
+	}{
+		// Bob's first commit has no earlier commit by Bob, so the parent's time is used.
+		{message: "B", parent: "A", timeDelta: time.Hour, additions: 1},
+		// Alice's C follows her own A two hours earlier, not Bob's B.
+		{message: "C", parent: "A", timeDelta: 2 * time.Hour, additions: 2},
+		{message: "D", parent: "B", timeDelta: 2 * time.Hour, additions: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			pair, ok := byMessage[tt.message]
+			if !ok {
+				t.Fatalf("no pair for commit %s", tt.message)
+			}
+			if got := strings.TrimSpace(pair.Previous.Message); got != tt.parent {
+				t.Errorf("Previous = %s, want %s", got, tt.parent)
+			}
+			if pair.TimeDelta != tt.timeDelta {
+				t.Errorf("TimeDelta = %v, want %v", pair.TimeDelta, tt.timeDelta)
+			}
+			if pair.Stats.Additions != tt.additions {
+				t.Errorf("Additions = %d, want %d", pair.Stats.Additions, tt.additions)
+			}
+		})
+	}
+
+	t.Run("merge parent option pairs merges", func(t *testing.T) {
+		mergeRepo, err := OpenRepository(repoPath, &RepositoryOptions{MergeParent: 1})
+		if err != nil {
+			t.Fatalf("Failed to open repository: %v", err)
+		}
+		defer mergeRepo.Close()
+
+		pairs, err := mergeRepo.(*gitRepository).GetCommitPairs(commits)
+		if err != nil {
+			t.Fatalf("GetCommitPairs() unexpected error = %v", err)
+		}
+		if len(pairs) != 4 {
+			t.Fatalf("len(pairs) = %d, want 4", len(pairs))
+		}
+
+		merge := pairs[0]
+		if strings.TrimSpace(merge.Current.Message) != "M" {
+			t.Fatalf("first pair = %q, want merge commit", merge.Current.Message)
+		}
+		if strings.TrimSpace(merge.Previous.Message) != "C" {
+			t.Errorf("merge Previous = %q, want first parent C", merge.Previous.Message)
+		}
+		// The merge brings in Bob's b.txt and d.txt relative to the first parent.
+		if merge.Stats.Additions != 4 {
+			t.Errorf("merge Additions = %d, want 4", merge.Stats.Additions)
+		}
+	})
+
+	t.Run("parent outside commit set is loaded", func(t *testing.T) {
+		pairs, err := repo.GetCommitPairs(commits[:1])
+		if err != nil {
+			t.Fatalf("GetCommitPairs() unexpected error = %v", err)
+		}
+		// commits[0] is the merge, which is skipped by default.
+		if len(pairs) != 0 {
+			t.Errorf("len(pairs) = %d, want 0", len(pairs))
+		}
+
+		var d *Commit
+		for _, c := range commits {
+			if strings.TrimSpace(c.Message) == "D" {
+				d = c
+			}
+		}
+		pairs, err = repo.GetCommitPairs([]*Commit{d})
+		if err != nil {
+			t.Fatalf("GetCommitPairs() unexpected error = %v", err)
+		}
+		if len(pairs) != 1 {
+			t.Fatalf("len(pairs) = %d, want 1", len(pairs))
+		}
+		if strings.TrimSpace(pairs[0].Previous.Message) != "B" {
+			t.Errorf("Previous = %q, want B", pairs[0].Previous.Message)
+		}
+	})
+}
+
+func TestGitRepository_GetCommits_Filters(t *testing.T) {
+	repoPath := createBranchyRepo(t)
+
+	repo, err := OpenRepository(repoPath, nil)
+	if err != nil {
+		t.Fatalf("Failed to open repository: %v", err)
+	}
+	defer repo.Close()
+
+	messages := func(commits []*Commit) []string {
+		out := make([]string, 0, len(commits))
+		for _, c := range commits {
+			out = append(out, strings.TrimSpace(c.Message))
+		}
+		sort.Strings(out)
+		return out
+	}
+
+	tests := []struct {
+		name string
+		opts *CommitOptions
+		want []string
+	}{
+		{
+			name: "revision range",
+			opts: &CommitOptions{Range: "main~1..main"},
+			want: []string{"B", "D", "M"},
+		},
+		{
+			name: "range with empty head uses branch",
+			opts: &CommitOptions{Range: "feature..", Branch: "main"},
+			want: []string{"C", "M"},
+		},
+		{
+			name: "since and until",
+			opts: &CommitOptions{
+				Since: time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+				Until: time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC),
+			},
+			want: []string{"B", "C", "D"},
+		},
+		{
+			name: "author by email",
+			opts: &CommitOptions{Author: "BOB@"},
+			want: []string{"B", "D"},
+		},
+		{
+			name: "author by name",
+			opts: &CommitOptions{Author: "alice"},
+			want: []string{"A", "C", "M"},
+		},
+		{
+			// M matches d.txt against C but is identical to D, so it is
+			// pruned like "git log -- <path>" does.
+			name: "path glob",
+			opts: &CommitOptions{Paths: []string{"d.txt", "a.*"}},
+			want: []string{"A", "D"},
+		},
+		{
+			name: "filters combine",
+			opts: &CommitOptions{Range: "main~1..main", Author: "bob"},
+			want: []string{"B", "D"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commits, err := repo.GetCommits(tt.opts)
+			if err != nil {
+				t.Fatalf("GetCommits() unexpected error = %v", err)
+			}
+			got := messages(commits)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("GetCommits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("invalid ranges return errors", func(t *testing.T) {
+		for _, rng := range []string{"main", "main...feature", "nope..main"} {
+			if _, err := repo.GetCommits(&CommitOptions{Range: rng}); err == nil {
+				t.Errorf("GetCommits(Range: %q) expected error but got none", rng)
+			}
+		}
+	})
+}
+
+func TestGitRepository_GetCommitPairs_Concurrency(t *testing.T) {
+	repoPath := createBranchyRepo(t)
+
+	pairsWith := func(concurrency int) []*CommitPair {
+		t.Helper()
+		repo, err := OpenRepository(repoPath, &RepositoryOptions{Concurrency: concurrency, MergeParent: 1})
+		if err != nil {
+			t.Fatalf("Failed to open repository: %v", err)
+		}
+		defer repo.Close()
+
+		commits, err := repo.GetCommits(nil)
+		if err != nil {
+			t.Fatalf("GetCommits() error = %v", err)
+		}
+		pairs, err := repo.(*gitRepository).GetCommitPairs(commits)
+		if err != nil {
+			t.Fatalf("GetCommitPairs() unexpected error = %v", err)
+		}
+		return pairs
+	}
+
+	serial := pairsWith(1)
+	for _, concurrency := range []int{2, 8} {
+		parallel := pairsWith(concurrency)
+		if len(parallel) != len(serial) {
+			t.Fatalf("concurrency %d: len(pairs) = %d, want %d", concurrency, len(parallel), len(serial))
+		}
+		for i := range serial {
+			if parallel[i].Current.Hash != serial[i].Current.Hash {
+				t.Errorf("concurrency %d: pair %d = %s, want %s", concurrency, i, parallel[i].Current.Hash, serial[i].Current.Hash)
+			}
+			if !reflect.DeepEqual(parallel[i].Stats, serial[i].Stats) {
+				t.Errorf("concurrency %d: pair %d stats = %+v, want %+v", concurrency, i, *parallel[i].Stats, *serial[i].Stats)
+			}
+			if parallel[i].DiffContent != serial[i].DiffContent {
+				t.Errorf("concurrency %d: pair %d diff content differs", concurrency, i)
+			}
+		}
+	}
+
+	t.Run("negative concurrency is rejected", func(t *testing.T) {
+		if _, err := OpenRepository(repoPath, &RepositoryOptions{Concurrency: -1}); err == nil {
+			t.Error("OpenRepository() expected error for negative concurrency")
+		}
+	})
+}
+
+func TestGitRepository_GetCommitDiff(t *testing.T) {
+	repoPath := createTestRepo(t)
+
+	repo, err := OpenRepository(repoPath, &RepositoryOptions{ExcludeFiles: []string{"file1.txt"}})
+	if err != nil {
+		t.Fatalf("Failed to open repository: %v", err)
+	}
+	defer repo.Close()
+
+	commits, err := repo.GetCommits(nil)
+	if err != nil {
+		t.Fatalf("GetCommits() error = %v", err)
+	}
+
+	diffProvider := repo.(DiffProvider)
+
+	// commits[1] adds file2.txt, commits[0] only touches the excluded file1.txt.
+	content, err := diffProvider.GetCommitDiff(commits[2].Hash, commits[1].Hash)
+	if err != nil {
+		t.Fatalf("GetCommitDiff() unexpected error = %v", err)
+	}
+	if !strings.Contains(content, "+new line 1") {
+		t.Errorf("GetCommitDiff() = %q, want added lines of file2.txt", content)
+	}
+
+	content, err = diffProvider.GetCommitDiff(commits[1].Hash, commits[0].Hash)
+	if err != nil {
+		t.Fatalf("GetCommitDiff() unexpected error = %v", err)
+	}
+	if content != "" {
+		t.Errorf("GetCommitDiff() = %q, want excluded file omitted", content)
+	}
+}
+
+type countingCache struct {
+	mu      sync.Mutex
+	entries map[string]*DiffStats
+	content map[string]string
+	hits    int
+	puts    int
+	// statsOnly keeps no content, as a cache does for oversized diffs.
+	statsOnly bool
+}
+
+func (c *countingCache) GetDiff(fromHash, toHash string) (*DiffStats, string, bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats, ok := c.entries[fromHash+toHash]
+	if ok {
+		c.hits++
+	}
+	return stats, c.content[fromHash+toHash], !c.statsOnly, ok
+}
+
+func (c *countingCache) PutDiff(fromHash, toHash string, stats *DiffStats, content string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[fromHash+toHash] = stats
+	c.content[fromHash+toHash] = content
+	c.puts++
+	return nil
+}
+
+func TestGitRepository_GetCommitPairs_Cache(t *testing.T) {
+	repoPath := createBranchyRepo(t)
+	diffCache := &countingCache{entries: make(map[string]*DiffStats), content: make(map[string]string)}
+
+	repo, err := OpenRepository(repoPath, &RepositoryOptions{Cache: diffCache})
+	if err != nil {
+		t.Fatalf("Failed to open repository: %v", err)
+	}
+	defer repo.Close()
+
+	commits, err := repo.GetCommits(nil)
+	if err != nil {
+		t.Fatalf("GetCommits() error = %v", err)
+	}
+
+	first, err := repo.(*gitRepository).GetCommitPairs(commits)
+	if err != nil {
+		t.Fatalf("GetCommitPairs() unexpected error = %v", err)
+	}
+	if diffCache.puts != len(first) || diffCache.hits != 0 {
+		t.Fatalf("first run: puts = %d, hits = %d, want %d puts and no hits", diffCache.puts, diffCache.hits, len(first))
+	}
+
+	second, err := repo.(*gitRepository).GetCommitPairs(commits)
+	if err != nil {
+		t.Fatalf("GetCommitPairs() unexpected error = %v", err)
+	}
+	if diffCache.hits != len(first) || diffCache.puts != len(first) {
+		t.Errorf("second run: puts = %d, hits = %d, want only hits", diffCache.puts, diffCache.hits)
+	}
+	for i := range first {
+		if !reflect.DeepEqual(first[i].Stats, second[i].Stats) || first[i].DiffContent != second[i].DiffContent {
+			t.Errorf("pair %d differs between cached and fresh runs", i)
+		}
+	}
+
+	// Entries without their content are diffed again.
+	diffCache.statsOnly = true
+	third, err := repo.(*gitRepository).GetCommitPairs(commits)
+	if err != nil {
+		t.Fatalf("GetCommitPairs() unexpected error = %v", err)
+	}
+	if diffCache.puts != 2*len(first) {
+		t.Errorf("stats-only run: puts = %d, want %d", diffCache.puts, 2*len(first))
+	}
+	for i := range first {
+		if first[i].DiffContent != third[i].DiffContent {
+			t.Errorf("pair %d lost its content when the cache kept only stats", i)
+		}
+	}
+}
+
+func TestGitRepository_GetCommitPairs_GoSignals(t *testing.T) {
+	dir := t.TempDir()
+	runGit(t, dir, "2024-01-01T10:00:00Z", "init", "-b", "main")
+
+	write := func(file, content string) {
+		t.Helper()
+		path := filepath.Join(dir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write %s: %v", file, err)
+		}
+	}
+	commit := func(date, msg string) {
+		t.Helper()
+		runGit(t, dir, date, "add", "-A")
+		runGit(t, dir, date, "-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "-m", msg)
+	}
+
+	base := "package app\n\nfunc Load(path string) error {\n\tif err := check(path); err != nil {\n\t\treturn err\n\t}\n\treturn nil\n}\n\nfunc check(p string) error { return nil }\n"
+	write("app.go", base)
+	write("notes.txt", "notes\n")
+	commit("2024-01-01T10:00:00Z", "Initial")
+
+	// Adds a function with a wrapped error; the bare return in Load is
+	// unchanged and must not be counted. The vendored copy is excluded and
+	// the broken file only counts as a parse error.
+	write("app.go", "package app\n\nimport \"fmt\"\n\n"+base[len("package app\n\n"):]+
+		"\n// Save stores path.\nfunc Save(path string) error {\n\tif err := check(path); err != nil {\n\t\treturn fmt.Errorf(\"failed to save: %w\", err)\n\t}\n\treturn nil\n}\n")
+	write("vendor/lib/lib.go", base)
+	write("broken.go", "package app\n\nfunc {\n")
+	write("notes.txt", "notes\nmore\n")
+	commit("2024-01-01T11:00:00Z", "Add Save")
+
+	gitRepo, err := OpenRepository(dir, &RepositoryOptions{ExcludeFiles: []string{"vendor/"}, AnalyzeGo: true})
+	if err != nil {
+		t.Fatalf("Failed to open repository: %v", err)
+	}
+	defer gitRepo.Close()
+	repo := gitRepo.(*gitRepository)
+
+	commits, err := repo.GetCommits(nil)
+	if err != nil {
+		t.Fatalf("GetCommits() error = %v", err)
+	}
+	pairs, err := repo.GetCommitPairs(commits)
+	if err != nil {
+		t.Fatalf("GetCommitPairs() error = %v", err)
+	}
+	if len(pairs) != 1 {
+		t.Fatalf("GetCommitPairs() returned %d pairs, want 1", len(pairs))
+	}
+
+	signals := pairs[0].Stats.Go
+	if signals == nil {
+		t.Fatal("Stats.Go = nil, want signals for app.go")
+	}
+	if signals.Files != 1 || signals.ParseErrors != 1 {
+		t.Errorf("Files, ParseErrors = %d, %d, want 1, 1", signals.Files, signals.ParseErrors)
+	}
+	if signals.BareErrorReturns != 0 || signals.WrappedErrorReturns != 1 {
+		t.Errorf("BareErrorReturns, WrappedErrorReturns = %d, %d, want 0, 1",
+			signals.BareErrorReturns, signals.WrappedErrorReturns)
+	}
+	if signals.CommentLines != 1 {
+		t.Errorf("CommentLines = %d, want 1", signals.CommentLines)
+	}
+	if len(signals.FuncLengths) != 1 || signals.FuncLengths[0] != 6 {
+		t.Errorf("FuncLengths = %v, want [6]", signals.FuncLengths)
+	}
+
+	repo.analyzeGo = false
+	unparsed, err := repo.GetCommitPairs(commits)
+	if err != nil {
+		t.Fatalf("GetCommitPairs() error = %v", err)
+	}
+	if unparsed[0].Stats.Go != nil {
+		t.Errorf("Stats.Go = %+v without AnalyzeGo, want nil", unparsed[0].Stats.Go)
+	}
+}
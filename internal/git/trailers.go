@@ -0,0 +1,74 @@
+package git
+
+import (
+	"strings"
+)
+
+// Trailer is a "Key: value" line from the trailer block that ends a commit
+// message, such as "Co-authored-by" or "Signed-off-by".
+type Trailer struct {
+	Key   string
+	Value string
+}
+
+// ParseTrailers extracts the trailer block of a commit message: its last
+// paragraph, provided it is not the subject and every line in it is a
+// trailer or the indented continuation of one. Keys keep their original
+// case; compare them with strings.EqualFold.
+func ParseTrailers(message string) []Trailer {
+	paragraphs := splitParagraphs(message)
+	if len(paragraphs) < 2 {
+		return nil
+	}
+
+	block := paragraphs[len(paragraphs)-1]
+	trailers := make([]Trailer, 0, len(block))
+	for _, line := range block {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(trailers) > 0 {
+			last := &trailers[len(trailers)-1]
+			last.Value += " " + strings.TrimSpace(line)
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || !isTrailerKey(key) {
+			return nil
+		}
+		trailers = append(trailers, Trailer{Key: key, Value: strings.TrimSpace(value)})
+	}
+
+	return trailers
+}
+
+func splitParagraphs(message string) [][]string {
+	paragraphs := make([][]string, 0)
+	current := make([]string, 0)
+	for _, line := range strings.Split(strings.ReplaceAll(message, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) == "" {
+			if len(current) > 0 {
+				paragraphs = append(paragraphs, current)
+				current = make([]string, 0)
+			}
+			continue
+		}
+		current = append(current, strings.TrimRight(line, " \t"))
+	}
+	if len(current) > 0 {
+		paragraphs = append(paragraphs, current)
+	}
+	return paragraphs
+}
+
+// isTrailerKey accepts the tokens git interpret-trailers does: letters,
+// digits and hyphens.
+func isTrailerKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, r := range key {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+			return false
+		}
+	}
+	return true
+}
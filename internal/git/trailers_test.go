@@ -0,0 +1,98 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTrailers(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    []Trailer
+	}{
+		{
+			name:    "subject only",
+			message: "Fix: handle empty input",
+			want:    nil,
+		},
+		{
+			name:    "trailer block",
+			message: "Add parser\n\nLonger description.\n\nSigned-off-by: Jane <jane@example.com>\nCo-authored-by: Claude <noreply@anthropic.com>\n",
+			want: []Trailer{
+				{Key: "Signed-off-by", Value: "Jane <jane@example.com>"},
+				{Key: "Co-authored-by", Value: "Claude <noreply@anthropic.com>"},
+			},
+		},
+		{
+			name:    "continuation line",
+			message: "Subject\n\nReviewed-by: Someone\n  Else <else@example.com>\n",
+			want:    []Trailer{{Key: "Reviewed-by", Value: "Someone Else <else@example.com>"}},
+		},
+		{
+			name:    "last paragraph is prose",
+			message: "Subject\n\nSigned-off-by: Jane <jane@example.com>\n\nThis explains: things.\n",
+			want:    nil,
+		},
+		{
+			name:    "mixed block is not trailers",
+			message: "Subject\n\nCo-authored-by: Bob <bob@example.com>\nsee the ticket for details\n",
+			want:    nil,
+		},
+		{
+			name:    "keys with spaces are not trailers",
+			message: "Subject\n\nNote to self: revisit\n",
+			want:    nil,
+		},
+		{
+			name:    "crlf line endings",
+			message: "Subject\r\n\r\nGenerated-by: tool\r\n",
+			want:    []Trailer{{Key: "Generated-by", Value: "tool"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseTrailers(tt.message)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTrailers() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitRepository_GetCommits_CommitterAndTrailers(t *testing.T) {
+	dir := t.TempDir()
+	runGit(t, dir, "2024-01-01T10:00:00Z", "init", "-b", "main")
+	runGit(t, dir, "2024-01-01T10:00:00Z", "config", "user.name", "Release Bot")
+	runGit(t, dir, "2024-01-01T10:00:00Z", "config", "user.email", "bot@example.com")
+	runGit(t, dir, "2024-01-01T10:00:00Z", "commit", "--allow-empty",
+		"--author", "Jane Doe <jane@example.com>",
+		"-m", "Add feature\n\nCo-authored-by: Claude <noreply@anthropic.com>")
+
+	repo, err := OpenRepository(dir, nil)
+	if err != nil {
+		t.Fatalf("OpenRepository() unexpected error = %v", err)
+	}
+	defer repo.Close()
+
+	commits, err := repo.GetCommits(nil)
+	if err != nil {
+		t.Fatalf("GetCommits() error = %v", err)
+	}
+	if len(commits) != 1 {
+		t.Fatalf("len(commits) = %d, want 1", len(commits))
+	}
+
+	c := commits[0]
+	if c.Author != "Jane Doe" || c.Committer != "Release Bot" || c.CommitterEmail != "bot@example.com" {
+		t.Errorf("identities = %q / %q <%s>, want Jane Doe / Release Bot <bot@example.com>", c.Author, c.Committer, c.CommitterEmail)
+	}
+	if c.CommitTime.IsZero() {
+		t.Error("CommitTime should be set")
+	}
+	want := []Trailer{{Key: "Co-authored-by", Value: "Claude <noreply@anthropic.com>"}}
+	if !reflect.DeepEqual(c.Trailers, want) {
+		t.Errorf("Trailers = %#v, want %#v", c.Trailers, want)
+	}
+}
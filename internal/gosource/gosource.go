@@ -0,0 +1,300 @@
+// Package gosource measures Go code a commit touched by parsing the
+// post-image of each changed file. Only lines the commit added or modified
+// contribute, so unchanged code in the same file does not dilute the
+// signals.
+package gosource
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"math"
+	"strings"
+)
+
+// LineRange is an inclusive span of 1-based lines in a file's post-image.
+type LineRange struct {
+	Start int
+	End   int
+}
+
+// Naming summarizes the identifiers declared on changed lines: functions,
+// types, variables, constants, parameters, struct fields and := targets.
+// Blank identifiers are skipped.
+type Naming struct {
+	Identifiers int
+	TotalLength int
+	// Short counts names of one or two characters, such as i, ok and err
+	// shortened to e.
+	Short int
+	// Underscored counts snake_case and SCREAMING_CASE names, which gofmt
+	// leaves alone but Go style avoids.
+	Underscored int
+}
+
+// MeanLength is the average declared identifier length.
+func (n Naming) MeanLength() float64 {
+	if n.Identifiers == 0 {
+		return 0
+	}
+	return float64(n.TotalLength) / float64(n.Identifiers)
+}
+
+// Signals are measurements of the changed lines of one or more Go files.
+type Signals struct {
+	// Files counts the files parsed; ParseErrors those that failed to
+	// parse and contribute nothing else.
+	Files       int
+	ParseErrors int
+
+	// CodeLines counts changed lines holding code; CommentLines changed
+	// lines holding only comments.
+	CodeLines    int
+	CommentLines int
+
+	Naming Naming
+
+	// BareErrorReturns counts `if err != nil { return err }` on changed
+	// lines; WrappedErrorReturns those returning a call that takes err,
+	// such as fmt.Errorf("...: %w", err).
+	BareErrorReturns    int
+	WrappedErrorReturns int
+
+	// FuncLengths holds the line count of each function declaration whose
+	// lines were mostly changed, that is, functions the commit wrote
+	// rather than edited.
+	FuncLengths []int
+}
+
+// CommentRatio is comment-only lines per line of code.
+func (s *Signals) CommentRatio() float64 {
+	if s.CodeLines == 0 {
+		return 0
+	}
+	return float64(s.CommentLines) / float64(s.CodeLines)
+}
+
+// ErrorReturns counts the error checks classified as bare or wrapped.
+func (s *Signals) ErrorReturns() int {
+	return s.BareErrorReturns + s.WrappedErrorReturns
+}
+
+// WrappedShare is the fraction of error checks that wrap the error.
+func (s *Signals) WrappedShare() float64 {
+	if s.ErrorReturns() == 0 {
+		return 0
+	}
+	return float64(s.WrappedErrorReturns) / float64(s.ErrorReturns())
+}
+
+// FuncLengthVariation is the coefficient of variation of FuncLengths:
+// near 0 when every function is about the same length.
+func (s *Signals) FuncLengthVariation() float64 {
+	if len(s.FuncLengths) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, l := range s.FuncLengths {
+		sum += float64(l)
+	}
+	mean := sum / float64(len(s.FuncLengths))
+	if mean == 0 {
+		return 0
+	}
+	var variance float64
+	for _, l := range s.FuncLengths {
+		d := float64(l) - mean
+		variance += d * d
+	}
+	return math.Sqrt(variance/float64(len(s.FuncLengths))) / mean
+}
+
+// Add accumulates other into s.
+func (s *Signals) Add(other *Signals) {
+	if other == nil {
+		return
+	}
+	s.Files += other.Files
+	s.ParseErrors += other.ParseErrors
+	s.CodeLines += other.CodeLines
+	s.CommentLines += other.CommentLines
+	s.Naming.Identifiers += other.Naming.Identifiers
+	s.Naming.TotalLength += other.Naming.TotalLength
+	s.Naming.Short += other.Naming.Short
+	s.Naming.Underscored += other.Naming.Underscored
+	s.BareErrorReturns += other.BareErrorReturns
+	s.WrappedErrorReturns += other.WrappedErrorReturns
+	s.FuncLengths = append(s.FuncLengths, other.FuncLengths...)
+}
+
+// Analyze parses src, the full post-image of filename, and measures the
+// lines in changed. A file that does not parse returns an error and no
+// signals.
+func Analyze(filename string, src []byte, changed []LineRange) (*Signals, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+
+	lines := make(map[int]bool)
+	for _, r := range changed {
+		for l := r.Start; l <= r.End; l++ {
+			lines[l] = true
+		}
+	}
+
+	s := &Signals{Files: 1}
+	s.countLines(src, lines)
+
+	line := func(pos token.Pos) int { return fset.Position(pos).Line }
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			s.declare(n.Name, lines, line)
+			if n.Body != nil {
+				s.function(line(n.Pos()), line(n.End()), lines)
+			}
+		case *ast.Field:
+			for _, name := range n.Names {
+				s.declare(name, lines, line)
+			}
+		case *ast.ValueSpec:
+			for _, name := range n.Names {
+				s.declare(name, lines, line)
+			}
+		case *ast.TypeSpec:
+			s.declare(n.Name, lines, line)
+		case *ast.AssignStmt:
+			if n.Tok == token.DEFINE {
+				for _, lhs := range n.Lhs {
+					if ident, ok := lhs.(*ast.Ident); ok {
+						s.declare(ident, lines, line)
+					}
+				}
+			}
+		case *ast.RangeStmt:
+			if n.Tok == token.DEFINE {
+				for _, e := range []ast.Expr{n.Key, n.Value} {
+					if ident, ok := e.(*ast.Ident); ok {
+						s.declare(ident, lines, line)
+					}
+				}
+			}
+		case *ast.IfStmt:
+			s.errorCheck(n, lines, line)
+		}
+		return true
+	})
+
+	return s, nil
+}
+
+// countLines marks changed lines as code or comment by scanning tokens, so
+// a line with code and a trailing comment counts as code.
+func (s *Signals) countLines(src []byte, changed map[int]bool) {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(src))
+
+	var sc scanner.Scanner
+	sc.Init(file, src, nil, scanner.ScanComments)
+
+	code := make(map[int]bool)
+	comment := make(map[int]bool)
+	for {
+		pos, tok, lit := sc.Scan()
+		if tok == token.EOF {
+			break
+		}
+		start := file.Line(pos)
+		switch {
+		case tok == token.COMMENT:
+			for l := start; l <= start+strings.Count(lit, "\n"); l++ {
+				comment[l] = true
+			}
+		case tok == token.SEMICOLON && lit == "\n":
+			// Automatically inserted; not code of its own.
+		default:
+			// Raw strings can span lines.
+			for l := start; l <= start+strings.Count(lit, "\n"); l++ {
+				code[l] = true
+			}
+		}
+	}
+
+	for l := range changed {
+		switch {
+		case code[l]:
+			s.CodeLines++
+		case comment[l]:
+			s.CommentLines++
+		}
+	}
+}
+
+func (s *Signals) declare(ident *ast.Ident, changed map[int]bool, line func(token.Pos) int) {
+	if ident == nil || ident.Name == "_" || !changed[line(ident.Pos())] {
+		return
+	}
+	name := ident.Name
+	s.Naming.Identifiers++
+	s.Naming.TotalLength += len(name)
+	if len(name) <= 2 {
+		s.Naming.Short++
+	}
+	if strings.Contains(strings.Trim(name, "_"), "_") {
+		s.Naming.Underscored++
+	}
+}
+
+func (s *Signals) function(start, end int, changed map[int]bool) {
+	touched := 0
+	for l := start; l <= end; l++ {
+		if changed[l] {
+			touched++
+		}
+	}
+	length := end - start + 1
+	if touched*2 >= length {
+		s.FuncLengths = append(s.FuncLengths, length)
+	}
+}
+
+// errorCheck classifies `if x != nil { ...; return ..., r }` where r is x
+// itself (bare) or a call that takes x (wrapped).
+func (s *Signals) errorCheck(n *ast.IfStmt, changed map[int]bool, line func(token.Pos) int) {
+	cond, ok := n.Cond.(*ast.BinaryExpr)
+	if !ok || cond.Op != token.NEQ {
+		return
+	}
+	checked, ok := cond.X.(*ast.Ident)
+	if !ok {
+		return
+	}
+	if nilIdent, ok := cond.Y.(*ast.Ident); !ok || nilIdent.Name != "nil" {
+		return
+	}
+
+	for _, stmt := range n.Body.List {
+		ret, ok := stmt.(*ast.ReturnStmt)
+		if !ok || len(ret.Results) == 0 || !changed[line(ret.Pos())] {
+			continue
+		}
+		switch last := ret.Results[len(ret.Results)-1].(type) {
+		case *ast.Ident:
+			if last.Name == checked.Name {
+				s.BareErrorReturns++
+			}
+		case *ast.CallExpr:
+			for _, arg := range last.Args {
+				if ident, ok := arg.(*ast.Ident); ok && ident.Name == checked.Name {
+					s.WrappedErrorReturns++
+					break
+				}
+			}
+		}
+	}
+}
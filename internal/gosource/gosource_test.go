@@ -0,0 +1,130 @@
+package gosource
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+const sample = `package sample
+
+import "fmt"
+
+// Load reads the config.
+func Load(path string) error {
+	data, err := read(path)
+	if err != nil {
+		return err
+	}
+	return parse(data)
+}
+
+func Save(path string) error {
+	// Write to a temporary file first.
+	if err := write(path); err != nil {
+		return fmt.Errorf("failed to save %s: %w", path, err)
+	}
+	return nil
+}
+
+func read(p string) ([]byte, error) { return nil, nil }
+func parse(b []byte) error          { return nil }
+func write(p string) error          { return nil }
+
+var max_retry_count = 3
+`
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name    string
+		changed []LineRange
+		want    Signals
+	}{
+		{
+			name:    "nothing changed",
+			changed: nil,
+			want:    Signals{Files: 1},
+		},
+		{
+			name:    "whole file",
+			changed: []LineRange{{Start: 1, End: 26}},
+			want: Signals{
+				Files:        1,
+				CodeLines:    19,
+				CommentLines: 2,
+				Naming: Naming{
+					// Load path data err Save path err read p parse b write p max_retry_count
+					Identifiers: 14,
+					TotalLength: 4 + 4 + 4 + 3 + 4 + 4 + 3 + 4 + 1 + 5 + 1 + 5 + 1 + 15,
+					Short:       3,
+					Underscored: 1,
+				},
+				BareErrorReturns:    1,
+				WrappedErrorReturns: 1,
+				FuncLengths:         []int{7, 7, 1, 1, 1},
+			},
+		},
+		{
+			name:    "only the Save hunk",
+			changed: []LineRange{{Start: 14, End: 20}},
+			want: Signals{
+				Files:               1,
+				CodeLines:           6,
+				CommentLines:        1,
+				Naming:              Naming{Identifiers: 3, TotalLength: 4 + 4 + 3},
+				WrappedErrorReturns: 1,
+				FuncLengths:         []int{7},
+			},
+		},
+		{
+			name:    "one line of Load does not make it new",
+			changed: []LineRange{{Start: 9, End: 9}},
+			want:    Signals{Files: 1, CodeLines: 1, BareErrorReturns: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Analyze("sample.go", []byte(sample), tt.changed)
+			if err != nil {
+				t.Fatalf("Analyze() error = %v", err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("Analyze() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+
+	if _, err := Analyze("broken.go", []byte("package broken\nfunc {"), []LineRange{{Start: 1, End: 2}}); err == nil {
+		t.Error("Analyze() of invalid Go should fail")
+	}
+}
+
+func TestSignals_Ratios(t *testing.T) {
+	s := &Signals{
+		CodeLines:           40,
+		CommentLines:        10,
+		BareErrorReturns:    1,
+		WrappedErrorReturns: 3,
+		FuncLengths:         []int{10, 10, 10, 10},
+		Naming:              Naming{Identifiers: 4, TotalLength: 30},
+	}
+	s.Add(&Signals{Files: 1, FuncLengths: []int{20}})
+
+	if got := s.CommentRatio(); got != 0.25 {
+		t.Errorf("CommentRatio() = %v, want 0.25", got)
+	}
+	if got := s.WrappedShare(); got != 0.75 {
+		t.Errorf("WrappedShare() = %v, want 0.75", got)
+	}
+	if got := s.Naming.MeanLength(); got != 7.5 {
+		t.Errorf("MeanLength() = %v, want 7.5", got)
+	}
+	// Lengths 10, 10, 10, 10, 20: mean 12, standard deviation 4.
+	if got := s.FuncLengthVariation(); math.Abs(got-4.0/12) > 1e-9 {
+		t.Errorf("FuncLengthVariation() = %v, want %v", got, 4.0/12)
+	}
+	if (&Signals{}).FuncLengthVariation() != 0 {
+		t.Error("FuncLengthVariation() of no functions should be 0")
+	}
+}
@@ -0,0 +1,223 @@
+// Package langmodel is a small offline n-gram language model of a
+// repository's own code and commit messages. Commits that the model finds
+// far more predictable than the history it was trained on are candidates
+// for generated text: language models favor the most likely next token.
+package langmodel
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+)
+
+// FormatVersion is bumped whenever the file layout or the tokenization
+// changes, so models trained by an older release are rejected rather than
+// scored wrongly.
+const FormatVersion = 1
+
+const (
+	// DefaultOrder is the n-gram length: each token is predicted from the
+	// two before it.
+	DefaultOrder = 3
+	// minTokenCount is how often a token must occur to enter the
+	// vocabulary; rarer ones are modeled as unknown.
+	minTokenCount = 2
+	// minNGramCount drops longer n-grams seen fewer times, which keeps
+	// the file small at little cost in accuracy.
+	minNGramCount = 2
+	// heldOutEvery holds out every nth commit to measure the baseline.
+	heldOutEvery = 5
+	// Held-out commits with fewer tokens do not enter the baseline.
+	minBaselineCodeTokens = 20
+	minBaselineTextTokens = 4
+)
+
+// Model holds a code model trained on added lines and a text model trained
+// on commit messages, from commits authored before Cutoff.
+type Model struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	Cutoff    time.Time `json:"cutoff"`
+	Commits   int       `json:"commits"`
+	Code      *NGram    `json:"code"`
+	Text      *NGram    `json:"text"`
+}
+
+// Document is the training material of one commit.
+type Document struct {
+	// Code holds the lines the commit added.
+	Code    []string
+	Message string
+}
+
+// ScoreCode measures added lines of code against the code model.
+func (m *Model) ScoreCode(lines []string) Score {
+	return m.Code.Score(CodeSequences(lines))
+}
+
+// ScoreText measures a commit message against the text model.
+func (m *Model) ScoreText(message string) Score {
+	return m.Text.Score(TextSequences(message))
+}
+
+// Train builds a model from docs, authored before cutoff. Every
+// heldOutEvery-th commit is first held out from a trial model to measure
+// how predictable unseen commits of this repository typically are; the
+// final model is then trained on all of them.
+func Train(docs []Document, cutoff time.Time) *Model {
+	code := make([][][]string, len(docs))
+	text := make([][][]string, len(docs))
+	for i, doc := range docs {
+		code[i] = CodeSequences(doc.Code)
+		text[i] = TextSequences(doc.Message)
+	}
+
+	m := &Model{
+		Version:   FormatVersion,
+		CreatedAt: time.Now().UTC(),
+		Cutoff:    cutoff.UTC(),
+		Commits:   len(docs),
+		Code:      trainNGram(code, DefaultOrder),
+		Text:      trainNGram(text, DefaultOrder),
+	}
+	m.Code.Baseline = measureBaseline(code, DefaultOrder, minBaselineCodeTokens)
+	m.Text.Baseline = measureBaseline(text, DefaultOrder, minBaselineTextTokens)
+	return m
+}
+
+// trainNGram counts the n-grams of every sequence of every document.
+func trainNGram(docs [][][]string, order int) *NGram {
+	frequency := make(map[string]int)
+	for _, doc := range docs {
+		for _, seq := range doc {
+			for _, t := range seq {
+				frequency[t]++
+			}
+			frequency[tokenEnd]++
+		}
+	}
+
+	n := &NGram{Order: order, Counts: map[string]int{tokenUnknown: 0}}
+	vocab := func(t string) string {
+		if t == tokenStart || frequency[t] >= minTokenCount {
+			return t
+		}
+		return tokenUnknown
+	}
+
+	higher := make(map[string]int)
+	for _, doc := range docs {
+		for _, seq := range doc {
+			padded := pad(seq, order)
+			for i := range padded {
+				padded[i] = vocab(padded[i])
+			}
+			for i := order - 1; i < len(padded); i++ {
+				n.Counts[padded[i]]++
+				n.Tokens++
+				for k := 2; k <= order; k++ {
+					higher[strings.Join(padded[i-k+1:i+1], " ")]++
+				}
+			}
+		}
+	}
+	for key, count := range higher {
+		if count >= minNGramCount {
+			n.Counts[key] = count
+		}
+	}
+
+	n.index()
+	return n
+}
+
+// measureBaseline scores the held-out documents against a model of the
+// rest. Too little history leaves the baseline empty.
+func measureBaseline(docs [][][]string, order, minTokens int) Baseline {
+	if len(docs) < 2*heldOutEvery {
+		return Baseline{}
+	}
+
+	var train, heldOut [][][]string
+	for i, doc := range docs {
+		if i%heldOutEvery == heldOutEvery-1 {
+			heldOut = append(heldOut, doc)
+		} else {
+			train = append(train, doc)
+		}
+	}
+	model := trainNGram(train, order)
+
+	var logPerplexities, burstiness []float64
+	for _, doc := range heldOut {
+		score := model.Score(doc)
+		if score.Tokens < minTokens {
+			continue
+		}
+		logPerplexities = append(logPerplexities, math.Log(score.Perplexity))
+		if score.Sequences > 1 {
+			burstiness = append(burstiness, score.Burstiness)
+		}
+	}
+
+	b := Baseline{Commits: len(logPerplexities)}
+	b.MeanLogPerplexity, b.StdLogPerplexity = meanStd(logPerplexities)
+	b.MeanBurstiness, b.StdBurstiness = meanStd(burstiness)
+	return b
+}
+
+// Save writes the model as gzip-compressed JSON.
+func (m *Model) Save(path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create model file: %w", err)
+	}
+	zw := gzip.NewWriter(f)
+	if err := json.NewEncoder(zw).Encode(m); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write model: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write model: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write model: %w", err)
+	}
+	return nil
+}
+
+// Load reads a model written by Save. Models of another FormatVersion are
+// rejected.
+func Load(path string) (*Model, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open model file: %w", err)
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read model %s: %w", path, err)
+	}
+	defer zr.Close()
+
+	var m Model
+	if err := json.NewDecoder(zr).Decode(&m); err != nil {
+		return nil, fmt.Errorf("failed to read model %s: %w", path, err)
+	}
+	if m.Version != FormatVersion {
+		return nil, fmt.Errorf("model %s has format version %d, expected %d; retrain it with cadence model train", path, m.Version, FormatVersion)
+	}
+	if m.Code == nil || m.Text == nil || m.Code.Order < 1 || m.Text.Order < 1 {
+		return nil, fmt.Errorf("model %s is incomplete", path)
+	}
+
+	m.Code.index()
+	m.Text.index()
+	return &m, nil
+}
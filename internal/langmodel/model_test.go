@@ -0,0 +1,160 @@
+package langmodel
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// history returns n commits of varied but recurring Go code and messages.
+func history(n int) []Document {
+	verbs := []string{"Fix", "Add", "Remove", "Handle", "Rename"}
+	nouns := []string{"parser", "cache", "config", "reporter", "webhook", "flag"}
+	docs := make([]Document, 0, n)
+	for i := 0; i < n; i++ {
+		noun := nouns[i%len(nouns)]
+		docs = append(docs, Document{
+			Code: []string{
+				fmt.Sprintf("func (s *%sState) load%d(path string) error {", noun, i%3),
+				"\tdata, err := os.ReadFile(path)",
+				"\tif err != nil {",
+				fmt.Sprintf("\t\treturn fmt.Errorf(\"failed to read %s: %%w\", err)", noun),
+				"\t}",
+				fmt.Sprintf("\ts.%s = data", noun),
+				"\treturn nil",
+				"}",
+			},
+			Message: fmt.Sprintf("%s %s handling", verbs[i%len(verbs)], noun),
+		})
+	}
+	return docs
+}
+
+func TestNGramProbabilitiesSumToOne(t *testing.T) {
+	model := Train(history(20), time.Now())
+
+	var vocab []string
+	for key := range model.Code.Counts {
+		if !strings.Contains(key, " ") {
+			vocab = append(vocab, key)
+		}
+	}
+
+	contexts := [][]string{
+		nil,
+		{tokenStart, tokenStart},
+		{"err", "!"},
+		{"never", "seen"},
+	}
+	for _, context := range contexts {
+		var sum float64
+		for _, token := range vocab {
+			sum += model.Code.prob(context, token)
+		}
+		if math.Abs(sum-1) > 1e-9 {
+			t.Errorf("probabilities after %q sum to %v, want 1", context, sum)
+		}
+	}
+}
+
+func TestScorePerplexity(t *testing.T) {
+	model := Train(history(20), time.Now())
+
+	familiar := model.ScoreCode(history(1)[0].Code)
+	novel := model.ScoreCode([]string{
+		"select id, name from users where active = 1 order by name;",
+		"<div class=\"card\"><span>{{ title }}</span></div>",
+	})
+	if familiar.Tokens == 0 || novel.Tokens == 0 {
+		t.Fatalf("Tokens = %d and %d, want both scored", familiar.Tokens, novel.Tokens)
+	}
+	if familiar.Perplexity >= novel.Perplexity {
+		t.Errorf("familiar perplexity %v >= novel perplexity %v", familiar.Perplexity, novel.Perplexity)
+	}
+	if familiar.Sequences != 8 || familiar.Burstiness <= 0 {
+		t.Errorf("familiar Sequences = %d, Burstiness = %v, want 8 and > 0", familiar.Sequences, familiar.Burstiness)
+	}
+
+	if empty := model.ScoreText(""); empty.Tokens != 0 || empty.Perplexity != 0 {
+		t.Errorf("ScoreText(\"\") = %+v, want zero", empty)
+	}
+}
+
+func TestTrainBaseline(t *testing.T) {
+	tests := []struct {
+		name        string
+		commits     int
+		wantCommits int
+	}{
+		{name: "too little history", commits: 9, wantCommits: 0},
+		{name: "every fifth commit held out", commits: 20, wantCommits: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := Train(history(tt.commits), time.Now())
+			b := model.Code.Baseline
+			if b.Commits != tt.wantCommits {
+				t.Errorf("Code.Baseline.Commits = %d, want %d", b.Commits, tt.wantCommits)
+			}
+			if tt.wantCommits > 0 && b.MeanLogPerplexity <= 0 {
+				t.Errorf("Code.Baseline.MeanLogPerplexity = %v, want > 0", b.MeanLogPerplexity)
+			}
+		})
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	cutoff := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	model := Train(history(20), cutoff)
+	path := filepath.Join(t.TempDir(), "cadence.model")
+	if err := model.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !loaded.Cutoff.Equal(cutoff) || loaded.Commits != 20 {
+		t.Errorf("loaded Cutoff = %v, Commits = %d, want %v and 20", loaded.Cutoff, loaded.Commits, cutoff)
+	}
+	if loaded.Code.Baseline != model.Code.Baseline {
+		t.Errorf("loaded baseline = %+v, want %+v", loaded.Code.Baseline, model.Code.Baseline)
+	}
+
+	lines := []string{"\tif err != nil {", "\t\treturn err", "\t}"}
+	if got, want := loaded.ScoreCode(lines), model.ScoreCode(lines); got != want {
+		t.Errorf("loaded ScoreCode() = %+v, want %+v", got, want)
+	}
+}
+
+func TestLoadRejectsOtherVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.model")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := gzip.NewWriter(f)
+	old := Model{Version: FormatVersion + 1, Code: &NGram{Order: 3}, Text: &NGram{Order: 3}}
+	if err := json.NewEncoder(zw).Encode(old); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Load(path)
+	if err == nil || !strings.Contains(err.Error(), "cadence model train") {
+		t.Errorf("Load() error = %v, want a version error asking to retrain", err)
+	}
+}
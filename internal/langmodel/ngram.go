@@ -0,0 +1,165 @@
+package langmodel
+
+import (
+	"math"
+	"strings"
+)
+
+// discount is subtracted from every observed n-gram count and handed to
+// the lower order, as in absolute discounting.
+const discount = 0.75
+
+// NGram is an interpolated absolute-discounting n-gram model over one kind
+// of token. Counts holds every kept n-gram of 1 to Order tokens, keyed by
+// its tokens joined with spaces; tokens never contain spaces.
+type NGram struct {
+	Order  int            `json:"order"`
+	Tokens int            `json:"tokens"`
+	Counts map[string]int `json:"counts"`
+	// Baseline describes how surprising held-out commits of the training
+	// history were, so a commit can be compared with its own repository.
+	Baseline Baseline `json:"baseline"`
+
+	contexts map[string]contextStats
+}
+
+// Baseline summarizes held-out commits: the mean and standard deviation
+// of their log perplexity and of their burstiness.
+type Baseline struct {
+	Commits           int     `json:"commits"`
+	MeanLogPerplexity float64 `json:"mean_log_perplexity"`
+	StdLogPerplexity  float64 `json:"std_log_perplexity"`
+	MeanBurstiness    float64 `json:"mean_burstiness"`
+	StdBurstiness     float64 `json:"std_burstiness"`
+}
+
+// contextStats is how often a context was followed by any token, and by
+// how many distinct tokens.
+type contextStats struct {
+	total    int
+	distinct int
+}
+
+// Score is how well a model predicts a set of sequences. Perplexity is the
+// exponential of the mean surprisal per token; Burstiness is the
+// coefficient of variation of the per-sequence mean surprisal, low when
+// every line is about equally predictable. Burstiness is 0 with fewer than
+// two sequences.
+type Score struct {
+	Tokens     int
+	Sequences  int
+	Perplexity float64
+	Burstiness float64
+}
+
+// index derives the context statistics from Counts. It runs after training
+// and after loading.
+func (n *NGram) index() {
+	n.contexts = make(map[string]contextStats)
+	for key, count := range n.Counts {
+		i := strings.LastIndexByte(key, ' ')
+		context := ""
+		if i >= 0 {
+			context = key[:i]
+		}
+		stats := n.contexts[context]
+		stats.total += count
+		stats.distinct++
+		n.contexts[context] = stats
+	}
+}
+
+// known maps tokens outside the vocabulary to the unknown token. The start
+// token is only ever context and never in the vocabulary.
+func (n *NGram) known(token string) string {
+	if _, ok := n.Counts[token]; ok || token == tokenStart {
+		return token
+	}
+	return tokenUnknown
+}
+
+// prob is P(token | context), interpolating each order with the next lower
+// one down to an add-one unigram.
+func (n *NGram) prob(context []string, token string) float64 {
+	if len(context) == 0 {
+		vocab := n.contexts[""].distinct
+		return (float64(n.Counts[token]) + 1) / (float64(n.Tokens) + float64(vocab))
+	}
+
+	lower := n.prob(context[1:], token)
+	key := strings.Join(context, " ")
+	stats, ok := n.contexts[key]
+	if !ok || stats.total == 0 {
+		return lower
+	}
+	count := float64(n.Counts[key+" "+token])
+	total := float64(stats.total)
+	return math.Max(count-discount, 0)/total + discount*float64(stats.distinct)/total*lower
+}
+
+// Score measures sequences against the model. It is safe for concurrent
+// use on models returned by Train and Load.
+func (n *NGram) Score(seqs [][]string) Score {
+	var score Score
+	var total float64
+	means := make([]float64, 0, len(seqs))
+	for _, seq := range seqs {
+		padded := pad(seq, n.Order)
+		var sum float64
+		count := 0
+		for i := n.Order - 1; i < len(padded); i++ {
+			context := make([]string, 0, n.Order-1)
+			for _, t := range padded[i-n.Order+1 : i] {
+				context = append(context, n.known(t))
+			}
+			sum -= math.Log(n.prob(context, n.known(padded[i])))
+			count++
+		}
+		if count == 0 {
+			continue
+		}
+		total += sum
+		score.Tokens += count
+		score.Sequences++
+		means = append(means, sum/float64(count))
+	}
+
+	if score.Tokens == 0 {
+		return score
+	}
+	score.Perplexity = math.Exp(total / float64(score.Tokens))
+	if len(means) > 1 {
+		mean, std := meanStd(means)
+		if mean > 0 {
+			score.Burstiness = std / mean
+		}
+	}
+	return score
+}
+
+// pad surrounds a sequence with start tokens for the first context and an
+// end token, which the model predicts like any other.
+func pad(seq []string, order int) []string {
+	padded := make([]string, 0, len(seq)+order)
+	for i := 0; i < order-1; i++ {
+		padded = append(padded, tokenStart)
+	}
+	padded = append(padded, seq...)
+	return append(padded, tokenEnd)
+}
+
+func meanStd(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
@@ -0,0 +1,122 @@
+package langmodel
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Placeholder tokens. Numbers and string literals carry little about style
+// and would otherwise flood the vocabulary.
+const (
+	tokenStart   = "<s>"
+	tokenEnd     = "</s>"
+	tokenUnknown = "<unk>"
+	tokenNumber  = "<num>"
+	tokenString  = "<str>"
+)
+
+// CodeTokens splits a line of source code into identifiers, punctuation
+// and placeholders for number and string literals. Identifiers keep their
+// case so naming conventions stay visible to the model.
+func CodeTokens(line string) []string {
+	var tokens []string
+	runes := []rune(line)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '_' || unicode.IsLetter(r):
+			j := i + 1
+			for j < len(runes) && (runes[j] == '_' || unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j])) {
+				j++
+			}
+			tokens = append(tokens, string(runes[i:j]))
+			i = j
+		case unicode.IsDigit(r):
+			j := i + 1
+			for j < len(runes) && (runes[j] == '.' || runes[j] == '_' || unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j])) {
+				j++
+			}
+			tokens = append(tokens, tokenNumber)
+			i = j
+		case r == '"' || r == '\'' || r == '`':
+			j := i + 1
+			for j < len(runes) && runes[j] != r {
+				if runes[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			tokens = append(tokens, tokenString)
+			i = j + 1
+		default:
+			tokens = append(tokens, string(r))
+			i++
+		}
+	}
+	return tokens
+}
+
+// TextTokens splits English text into lowercased words and sentence
+// punctuation. Each line of text is one sequence.
+func TextTokens(line string) []string {
+	var tokens []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			tokens = append(tokens, word.String())
+			word.Reset()
+		}
+	}
+	for _, r := range strings.ToLower(line) {
+		switch {
+		case unicode.IsLetter(r) || r == '\'':
+			word.WriteRune(r)
+		case unicode.IsDigit(r):
+			flush()
+			if len(tokens) == 0 || tokens[len(tokens)-1] != tokenNumber {
+				tokens = append(tokens, tokenNumber)
+			}
+		case strings.ContainsRune(".,;:!?()", r):
+			flush()
+			tokens = append(tokens, string(r))
+		default:
+			flush()
+		}
+	}
+	flush()
+	return tokens
+}
+
+// AddedLines returns the lines a unified diff adds, without the + prefix.
+func AddedLines(diffContent string) []string {
+	var lines []string
+	for _, line := range strings.Split(diffContent, "\n") {
+		if strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++") {
+			lines = append(lines, line[1:])
+		}
+	}
+	return lines
+}
+
+// CodeSequences tokenizes lines of code into one sequence per non-empty
+// line.
+func CodeSequences(lines []string) [][]string {
+	return sequences(lines, CodeTokens)
+}
+
+// TextSequences tokenizes text into one sequence per non-empty line.
+func TextSequences(text string) [][]string {
+	return sequences(strings.Split(text, "\n"), TextTokens)
+}
+
+func sequences(lines []string, tokenize func(string) []string) [][]string {
+	var seqs [][]string
+	for _, line := range lines {
+		if tokens := tokenize(line); len(tokens) > 0 {
+			seqs = append(seqs, tokens)
+		}
+	}
+	return seqs
+}
@@ -0,0 +1,78 @@
+package langmodel
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCodeTokens(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want []string
+	}{
+		{
+			name: "identifiers and punctuation",
+			line: "\tif err != nil {",
+			want: []string{"if", "err", "!", "=", "nil", "{"},
+		},
+		{
+			name: "literals become placeholders",
+			line: `x := fmt.Sprintf("a \"b\" %d", 0x1F, 2.5)`,
+			want: []string{"x", ":", "=", "fmt", ".", "Sprintf", "(", "<str>", ",", "<num>", ",", "<num>", ")"},
+		},
+		{
+			name: "case is kept",
+			line: "parseHTTPHeader_v2",
+			want: []string{"parseHTTPHeader_v2"},
+		},
+		{
+			name: "blank line",
+			line: "   ",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CodeTokens(tt.line); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CodeTokens(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextTokens(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want []string
+	}{
+		{
+			name: "words are lowercased",
+			line: "Fix the Parser, don't panic!",
+			want: []string{"fix", "the", "parser", ",", "don't", "panic", "!"},
+		},
+		{
+			name: "numbers collapse",
+			line: "Bump to 1.2.3 (see #42)",
+			want: []string{"bump", "to", "<num>", ".", "<num>", ".", "<num>", "(", "see", "<num>", ")"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TextTokens(tt.line); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TextTokens(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddedLines(t *testing.T) {
+	diff := "diff --git a/a.go b/a.go\n--- a/a.go\n+++ b/a.go\n@@ -1,2 +1,3 @@\n package a\n-var x = 1\n+var x = 2\n+var y = 3\n"
+	want := []string{"var x = 2", "var y = 3"}
+	if got := AddedLines(diff); !reflect.DeepEqual(got, want) {
+		t.Errorf("AddedLines() = %q, want %q", got, want)
+	}
+}
@@ -20,6 +20,7 @@ type RepositoryStats struct {
 	TotalLOCDeleted      int64
 	UnfilteredLOCAdded   int64
 	UnfilteredLOCDeleted int64
+	DroppedLines         git.DroppedLines
 	AverageVelocity      float64
 	MedianVelocity       float64
 	VelocityPercentile   *Percentiles
@@ -94,6 +95,7 @@ func CalculateStats(commits []*git.Commit, pairs []*git.CommitPair) *RepositoryS
 
 		stats.UnfilteredLOCAdded += pair.Stats.TotalAdditions
 		stats.UnfilteredLOCDeleted += pair.Stats.TotalDeletions
+		stats.DroppedLines.Add(pair.Stats.Dropped)
 
 		hasFilteredChanges := pair.Stats.Additions > 0 || pair.Stats.Deletions > 0
 
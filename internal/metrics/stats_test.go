@@ -79,6 +79,7 @@ func TestCalculateStats(t *testing.T) {
 					TotalDeletions:    60,
 					FilesChanged:      5,
 					FilesChangedTotal: 6,
+					Dropped:           git.DroppedLines{ExcludeFiles: 20, NoDiff: 10},
 				},
 			},
 			{
@@ -92,6 +93,7 @@ func TestCalculateStats(t *testing.T) {
 					TotalDeletions:    120,
 					FilesChanged:      8,
 					FilesChangedTotal: 10,
+					Dropped:           git.DroppedLines{Generated: 50, NoDiff: 20},
 				},
 			},
 		}
@@ -119,6 +121,10 @@ func TestCalculateStats(t *testing.T) {
 		if stats.UnfilteredLOCDeleted != 180 {
 			t.Errorf("UnfilteredLOCDeleted = %d, want 180", stats.UnfilteredLOCDeleted)
 		}
+		wantDropped := git.DroppedLines{ExcludeFiles: 20, Generated: 50, NoDiff: 30}
+		if stats.DroppedLines != wantDropped {
+			t.Errorf("DroppedLines = %+v, want %+v", stats.DroppedLines, wantDropped)
+		}
 
 		expectedTimeSpan := 20 * time.Minute
 		if stats.TimeSpan != expectedTimeSpan {
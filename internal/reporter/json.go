@@ -3,6 +3,9 @@ package reporter
 import (
 	"encoding/json"
 	"time"
+
+	"github.com/TryCadence/Cadence/internal/detector"
+	"github.com/TryCadence/Cadence/internal/git"
 )
 
 type JSONReporter struct{}
@@ -11,7 +14,12 @@ type JSONReport struct {
 	Statistics        JSONStats              `json:"statistics"`
 	Thresholds        JSONThresholds         `json:"thresholds"`
 	SuspiciousCount   int                    `json:"suspicious_count"`
+	KnownFindings     int                    `json:"known_findings"`
 	SuspiciousCommits []JSONSuspiciousCommit `json:"suspicious_commits"`
+	AuthorFindings    []JSONFinding          `json:"author_findings"`
+	WindowFindings    []JSONFinding          `json:"window_findings"`
+	Suppressed        []JSONSuppressed       `json:"suppressed_findings"`
+	Expired           []JSONSuppression      `json:"expired_suppressions"`
 }
 
 type JSONStats struct {
@@ -23,11 +31,21 @@ type JSONStats struct {
 	TotalLOCDeleted      int64            `json:"total_loc_deleted_filtered"`
 	UnfilteredLOCAdded   int64            `json:"total_loc_added_unfiltered"`
 	UnfilteredLOCDeleted int64            `json:"total_loc_deleted_unfiltered"`
+	DroppedLines         JSONDroppedLines `json:"dropped_lines"`
 	AverageVelocity      float64          `json:"average_velocity_loc_per_min"`
 	MedianVelocity       float64          `json:"median_velocity_loc_per_min"`
 	VelocityPercentiles  *JSONPercentiles `json:"velocity_percentiles,omitempty"`
 }
 
+// JSONDroppedLines counts the lines excluded from the filtered totals by
+// reason.
+type JSONDroppedLines struct {
+	ExcludeFiles      int64 `json:"exclude_files"`
+	LinguistGenerated int64 `json:"linguist_generated"`
+	LinguistVendored  int64 `json:"linguist_vendored"`
+	NoDiff            int64 `json:"no_diff"`
+}
+
 type JSONPercentiles struct {
 	P50 float64 `json:"p50"`
 	P75 float64 `json:"p75"`
@@ -45,23 +63,195 @@ type JSONThresholds struct {
 }
 
 type JSONSuspiciousCommit struct {
-	Hash                string   `json:"hash"`
-	Author              string   `json:"author"`
-	Email               string   `json:"email"`
-	Timestamp           string   `json:"timestamp"`
-	Message             string   `json:"message"`
-	Additions           int64    `json:"additions_filtered"`
-	Deletions           int64    `json:"deletions_filtered"`
-	TotalAdditions      int64    `json:"additions_total"`
-	TotalDeletions      int64    `json:"deletions_total"`
-	FilesChanged        int      `json:"files_changed_filtered"`
-	FilesChangedTotal   int      `json:"files_changed_total"`
-	TimeDelta           float64  `json:"time_delta_seconds"`
-	AdditionVelocityMin float64  `json:"addition_velocity_per_min"`
-	DeletionVelocityMin float64  `json:"deletion_velocity_per_min"`
-	ConfidenceScore     float64  `json:"confidence_score"`
-	Reasons             []string `json:"reasons"`
-	AIAnalysis          string   `json:"ai_analysis,omitempty"`
+	Hash                string        `json:"hash"`
+	Author              string        `json:"author"`
+	Email               string        `json:"email"`
+	Timestamp           string        `json:"timestamp"`
+	Message             string        `json:"message"`
+	Additions           int64         `json:"additions_filtered"`
+	Deletions           int64         `json:"deletions_filtered"`
+	TotalAdditions      int64         `json:"additions_total"`
+	TotalDeletions      int64         `json:"deletions_total"`
+	FilesChanged        int           `json:"files_changed_filtered"`
+	FilesChangedTotal   int           `json:"files_changed_total"`
+	TimeDelta           float64       `json:"time_delta_seconds"`
+	AdditionVelocityMin float64       `json:"addition_velocity_per_min"`
+	DeletionVelocityMin float64       `json:"deletion_velocity_per_min"`
+	ConfidenceScore     float64       `json:"confidence_score"`
+	Severity            string        `json:"severity,omitempty"`
+	Reasons             []string      `json:"reasons"`
+	Findings            []JSONFinding `json:"findings"`
+	Files               []JSONFile    `json:"files,omitempty"`
+	AIAnalysis          string        `json:"ai_analysis,omitempty"`
+}
+
+// JSONFile is a file of a suspicious commit and its part in the commit's
+// confidence score. Files are listed highest contribution first.
+type JSONFile struct {
+	Path         string  `json:"path"`
+	Additions    int64   `json:"additions"`
+	Deletions    int64   `json:"deletions"`
+	Contribution float64 `json:"contribution"`
+}
+
+func newJSONFiles(files []detector.FileContribution, stats *git.DiffStats) []JSONFile {
+	if len(files) == 0 {
+		return nil
+	}
+	lines := make(map[string]git.FileStats)
+	if stats != nil {
+		for _, f := range stats.Files {
+			lines[f.Path] = f
+		}
+	}
+	result := make([]JSONFile, len(files))
+	for i, f := range files {
+		result[i] = JSONFile{
+			Path:         f.Path,
+			Additions:    lines[f.Path].Additions,
+			Deletions:    lines[f.Path].Deletions,
+			Contribution: f.Score,
+		}
+	}
+	return result
+}
+
+// JSONFinding is one strategy's structured evidence against a commit, an
+// author or a window of time.
+type JSONFinding struct {
+	Strategy   string         `json:"strategy"`
+	Scope      string         `json:"scope,omitempty"`
+	Author     string         `json:"author,omitempty"`
+	Start      string         `json:"start,omitempty"`
+	End        string         `json:"end,omitempty"`
+	Commits    []string       `json:"commits,omitempty"`
+	Category   string         `json:"category"`
+	Severity   string         `json:"severity"`
+	Confidence float64        `json:"confidence"`
+	Observed   float64        `json:"observed_value,omitempty"`
+	Threshold  float64        `json:"threshold,omitempty"`
+	Baseline   string         `json:"baseline,omitempty"`
+	File       string         `json:"file,omitempty"`
+	StartLine  int            `json:"start_line,omitempty"`
+	EndLine    int            `json:"end_line,omitempty"`
+	Locations  []JSONLocation `json:"locations,omitempty"`
+	Message    string         `json:"message"`
+}
+
+// JSONSuppression is a suppression of reviewed findings and where it was
+// declared.
+type JSONSuppression struct {
+	Commit   string `json:"commit,omitempty"`
+	Author   string `json:"author,omitempty"`
+	Path     string `json:"path,omitempty"`
+	Strategy string `json:"strategy,omitempty"`
+	Reason   string `json:"reason"`
+	Expires  string `json:"expires,omitempty"`
+	Source   string `json:"source"`
+}
+
+// JSONSuppressed is a finding a suppression cleared. Commit is empty for
+// author and window findings.
+type JSONSuppressed struct {
+	Commit      string          `json:"commit,omitempty"`
+	Finding     JSONFinding     `json:"finding"`
+	Suppression JSONSuppression `json:"suppression"`
+}
+
+func newJSONSuppression(s detector.Suppression) JSONSuppression {
+	return JSONSuppression{
+		Commit:   s.Commit,
+		Author:   s.Author,
+		Path:     s.Path,
+		Strategy: s.Strategy,
+		Reason:   s.Reason,
+		Expires:  s.Expires,
+		Source:   s.Source,
+	}
+}
+
+func newJSONSuppressed(suppressed []detector.SuppressedFinding) []JSONSuppressed {
+	result := make([]JSONSuppressed, len(suppressed))
+	for i, sf := range suppressed {
+		result[i] = JSONSuppressed{
+			Finding:     newJSONScopedFindings([]detector.Finding{sf.Finding})[0],
+			Suppression: newJSONSuppression(sf.Suppression),
+		}
+		if sf.Pair != nil {
+			result[i].Commit = sf.Pair.Current.Hash
+		}
+	}
+	return result
+}
+
+func newJSONExpired(expired []detector.Suppression) []JSONSuppression {
+	result := make([]JSONSuppression, len(expired))
+	for i, s := range expired {
+		result[i] = newJSONSuppression(s)
+	}
+	return result
+}
+
+// JSONLocation is one place a finding's evidence comes from. Locations
+// sharing a group belong together, such as the copies of a cloned fragment.
+type JSONLocation struct {
+	Group     int    `json:"group,omitempty"`
+	File      string `json:"file"`
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line"`
+}
+
+func newJSONLocations(locations []detector.Location) []JSONLocation {
+	if len(locations) == 0 {
+		return nil
+	}
+	result := make([]JSONLocation, len(locations))
+	for i, l := range locations {
+		result[i] = JSONLocation{Group: l.Group, File: l.File, StartLine: l.StartLine, EndLine: l.EndLine}
+	}
+	return result
+}
+
+func newJSONFindings(findings []detector.Finding) []JSONFinding {
+	result := make([]JSONFinding, len(findings))
+	for i, f := range findings {
+		result[i] = JSONFinding{
+			Strategy:   f.Strategy,
+			Author:     f.Author,
+			Start:      formatTime(f.Start),
+			End:        formatTime(f.End),
+			Commits:    f.Commits,
+			Category:   f.Category,
+			Severity:   string(f.Severity),
+			Confidence: f.Confidence,
+			Observed:   f.Observed,
+			Threshold:  f.Threshold,
+			Baseline:   f.Baseline,
+			File:       f.File,
+			StartLine:  f.StartLine,
+			EndLine:    f.EndLine,
+			Locations:  newJSONLocations(f.Locations),
+			Message:    f.Message,
+		}
+	}
+	return result
+}
+
+// newJSONScopedFindings converts author or window findings, which name
+// their scope since they stand apart from any commit.
+func newJSONScopedFindings(findings []detector.Finding) []JSONFinding {
+	result := newJSONFindings(findings)
+	for i, f := range findings {
+		result[i].Scope = string(f.Scope)
+	}
+	return result
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
 }
 
 func (r *JSONReporter) Generate(data *ReportData) (string, error) {
@@ -75,8 +265,14 @@ func (r *JSONReporter) Generate(data *ReportData) (string, error) {
 			TotalLOCDeleted:      data.Stats.TotalLOCDeleted,
 			UnfilteredLOCAdded:   data.Stats.UnfilteredLOCAdded,
 			UnfilteredLOCDeleted: data.Stats.UnfilteredLOCDeleted,
-			AverageVelocity:      data.Stats.AverageVelocity,
-			MedianVelocity:       data.Stats.MedianVelocity,
+			DroppedLines: JSONDroppedLines{
+				ExcludeFiles:      data.Stats.DroppedLines.ExcludeFiles,
+				LinguistGenerated: data.Stats.DroppedLines.Generated,
+				LinguistVendored:  data.Stats.DroppedLines.Vendored,
+				NoDiff:            data.Stats.DroppedLines.NoDiff,
+			},
+			AverageVelocity: data.Stats.AverageVelocity,
+			MedianVelocity:  data.Stats.MedianVelocity,
 		},
 		Thresholds: JSONThresholds{
 			SuspiciousAdditions: data.Thresholds.SuspiciousAdditions,
@@ -86,7 +282,12 @@ func (r *JSONReporter) Generate(data *ReportData) (string, error) {
 			MinTimeDeltaSeconds: data.Thresholds.MinTimeDeltaSeconds,
 		},
 		SuspiciousCount:   len(data.Suspicious),
+		KnownFindings:     data.Known,
 		SuspiciousCommits: make([]JSONSuspiciousCommit, len(data.Suspicious)),
+		AuthorFindings:    newJSONScopedFindings(data.AuthorFindings),
+		WindowFindings:    newJSONScopedFindings(data.WindowFindings),
+		Suppressed:        newJSONSuppressed(data.Suppressed),
+		Expired:           newJSONExpired(data.Expired),
 	}
 
 	if data.Stats.VelocityPercentile != nil {
@@ -114,7 +315,10 @@ func (r *JSONReporter) Generate(data *ReportData) (string, error) {
 			FilesChangedTotal: s.Pair.Stats.FilesChangedTotal,
 			TimeDelta:         s.Pair.TimeDelta.Seconds(),
 			ConfidenceScore:   s.Score,
+			Severity:          string(s.Severity),
 			Reasons:           s.Reasons,
+			Findings:          newJSONFindings(s.Findings),
+			Files:             newJSONFiles(s.Files, s.Pair.Stats),
 			AIAnalysis:        s.AIAnalysis,
 		}
 		if s.AdditionVelocity != nil {
@@ -2,6 +2,7 @@ package reporter
 
 import (
 	"encoding/json"
+	"reflect"
 	"testing"
 	"time"
 
@@ -25,6 +26,7 @@ func TestJSONReporter_Generate(t *testing.T) {
 				TotalLOCDeleted:      200,
 				UnfilteredLOCAdded:   600,
 				UnfilteredLOCDeleted: 250,
+				DroppedLines:         git.DroppedLines{ExcludeFiles: 60, Generated: 30, Vendored: 10, NoDiff: 50},
 				AverageVelocity:      25.5,
 				MedianVelocity:       20.0,
 				VelocityPercentile: &metrics.Percentiles{
@@ -80,6 +82,10 @@ func TestJSONReporter_Generate(t *testing.T) {
 		if result.Statistics.UnfilteredLOCDeleted != 250 {
 			t.Errorf("UnfilteredLOCDeleted = %d, want 250", result.Statistics.UnfilteredLOCDeleted)
 		}
+		wantDropped := JSONDroppedLines{ExcludeFiles: 60, LinguistGenerated: 30, LinguistVendored: 10, NoDiff: 50}
+		if result.Statistics.DroppedLines != wantDropped {
+			t.Errorf("DroppedLines = %+v, want %+v", result.Statistics.DroppedLines, wantDropped)
+		}
 		if result.Statistics.AverageVelocity != 25.5 {
 			t.Errorf("AverageVelocity = %f, want 25.5", result.Statistics.AverageVelocity)
 		}
@@ -153,6 +159,10 @@ func TestJSONReporter_Generate(t *testing.T) {
 							TotalDeletions:    60,
 							FilesChanged:      10,
 							FilesChangedTotal: 12,
+							Files: []git.FileStats{
+								{Path: "a.go", Additions: 13},
+								{Path: "b.go", Additions: 40, Deletions: 5},
+							},
 						},
 					},
 					AdditionVelocity: &metrics.VelocityMetrics{
@@ -165,6 +175,24 @@ func TestJSONReporter_Generate(t *testing.T) {
 						"Suspicious commit size",
 						"Addition velocity too high",
 					},
+					Findings: []detector.Finding{
+						{Strategy: "size_analysis", Category: "size", Severity: detector.SeverityLow, Confidence: 0.9, Observed: 500, Threshold: 100, Message: "Suspicious commit size"},
+						{
+							Strategy: "near_clone_analysis", Category: "code", Severity: detector.SeverityLow, Confidence: 0.6,
+							File: "a.go", StartLine: 1, EndLine: 13,
+							Locations: []detector.Location{
+								{Group: 1, File: "a.go", StartLine: 1, EndLine: 13},
+								{Group: 1, File: "b.go", StartLine: 20, EndLine: 32},
+							},
+							Message: "clones",
+						},
+					},
+					Score:    0.55,
+					Severity: detector.SeverityMedium,
+					Files: []detector.FileContribution{
+						{Path: "b.go", Score: 0.4},
+						{Path: "a.go", Score: 0.25},
+					},
 				},
 			},
 			Stats: &metrics.RepositoryStats{
@@ -210,6 +238,30 @@ func TestJSONReporter_Generate(t *testing.T) {
 		if sc.Message != "Add new feature" {
 			t.Errorf("Message = %s, want Add new feature", sc.Message)
 		}
+		if sc.ConfidenceScore != 0.55 || sc.Severity != "medium" {
+			t.Errorf("ConfidenceScore, Severity = %v, %q, want 0.55, medium", sc.ConfidenceScore, sc.Severity)
+		}
+		if len(sc.Findings) != 2 {
+			t.Fatalf("len(Findings) = %d, want 2", len(sc.Findings))
+		}
+		want := JSONFinding{Strategy: "size_analysis", Category: "size", Severity: "low", Confidence: 0.9, Observed: 500, Threshold: 100, Message: "Suspicious commit size"}
+		if !reflect.DeepEqual(sc.Findings[0], want) {
+			t.Errorf("Findings[0] = %+v, want %+v", sc.Findings[0], want)
+		}
+		wantLocations := []JSONLocation{
+			{Group: 1, File: "a.go", StartLine: 1, EndLine: 13},
+			{Group: 1, File: "b.go", StartLine: 20, EndLine: 32},
+		}
+		if !reflect.DeepEqual(sc.Findings[1].Locations, wantLocations) {
+			t.Errorf("Findings[1].Locations = %+v, want %+v", sc.Findings[1].Locations, wantLocations)
+		}
+		wantFiles := []JSONFile{
+			{Path: "b.go", Additions: 40, Deletions: 5, Contribution: 0.4},
+			{Path: "a.go", Additions: 13, Contribution: 0.25},
+		}
+		if !reflect.DeepEqual(sc.Files, wantFiles) {
+			t.Errorf("Files = %+v, want %+v", sc.Files, wantFiles)
+		}
 		if sc.Additions != 500 {
 			t.Errorf("Additions = %d, want 500", sc.Additions)
 		}
@@ -373,4 +425,83 @@ func TestJSONReporter_Generate(t *testing.T) {
 			t.Errorf("Timestamp = %s, want %s", result.SuspiciousCommits[0].Timestamp, expectedTimestamp)
 		}
 	})
+	t.Run("author and window findings", func(t *testing.T) {
+		start := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+		data := &ReportData{
+			Suspicious: []*detector.SuspiciousCommit{},
+			WindowFindings: []detector.Finding{{
+				Strategy: "uniform_commit_burst", Scope: detector.ScopeWindow, Start: start,
+				End: start.Add(8 * time.Minute), Commits: []string{"a", "b"}, Category: "timing",
+				Severity: detector.SeverityLow, Confidence: 0.5, Message: "2 commits within 8 minutes",
+			}},
+			Stats:      &metrics.RepositoryStats{},
+			Thresholds: &detector.Thresholds{SuspiciousAdditions: 100},
+		}
+
+		output, err := (&JSONReporter{}).Generate(data)
+		if err != nil {
+			t.Fatalf("Generate() unexpected error = %v", err)
+		}
+		var result JSONReport
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("Generated JSON is invalid: %v", err)
+		}
+
+		if result.AuthorFindings == nil || len(result.AuthorFindings) != 0 {
+			t.Errorf("AuthorFindings = %v, want empty list", result.AuthorFindings)
+		}
+		want := []JSONFinding{{
+			Strategy: "uniform_commit_burst", Scope: "window", Start: "2026-03-02T10:00:00Z",
+			End: "2026-03-02T10:08:00Z", Commits: []string{"a", "b"}, Category: "timing",
+			Severity: "low", Confidence: 0.5, Message: "2 commits within 8 minutes",
+		}}
+		if !reflect.DeepEqual(result.WindowFindings, want) {
+			t.Errorf("WindowFindings = %+v, want %+v", result.WindowFindings, want)
+		}
+	})
+	t.Run("known findings, suppressed findings and expired suppressions", func(t *testing.T) {
+		data := &ReportData{
+			Suspicious: []*detector.SuspiciousCommit{},
+			Suppressed: []detector.SuppressedFinding{{
+				Pair: &git.CommitPair{Current: &git.Commit{Hash: "abc1234def"}},
+				Finding: detector.Finding{
+					Strategy: "size_analysis", Category: "size", Severity: detector.SeverityMedium,
+					Confidence: 0.6, Message: "Large commit",
+				},
+				Suppression: detector.Suppression{Commit: "abc1234", Reason: "Vendored dependency", Source: ".cadence-ignore"},
+			}},
+			Expired:    []detector.Suppression{{Path: "docs/**", Reason: "Docs", Expires: "2026-01-01", Source: "cadence.yml"}},
+			Known:      2,
+			Stats:      &metrics.RepositoryStats{},
+			Thresholds: &detector.Thresholds{SuspiciousAdditions: 100},
+		}
+
+		output, err := (&JSONReporter{}).Generate(data)
+		if err != nil {
+			t.Fatalf("Generate() unexpected error = %v", err)
+		}
+		var result JSONReport
+		if err := json.Unmarshal([]byte(output), &result); err != nil {
+			t.Fatalf("Generated JSON is invalid: %v", err)
+		}
+
+		if result.KnownFindings != 2 {
+			t.Errorf("KnownFindings = %d, want 2", result.KnownFindings)
+		}
+		if len(result.Suppressed) != 1 {
+			t.Fatalf("len(Suppressed) = %d, want 1", len(result.Suppressed))
+		}
+		s := result.Suppressed[0]
+		if s.Commit != "abc1234def" || s.Finding.Strategy != "size_analysis" {
+			t.Errorf("Suppressed[0] = %+v", s)
+		}
+		wantSuppression := JSONSuppression{Commit: "abc1234", Reason: "Vendored dependency", Source: ".cadence-ignore"}
+		if s.Suppression != wantSuppression {
+			t.Errorf("Suppression = %+v, want %+v", s.Suppression, wantSuppression)
+		}
+		wantExpired := []JSONSuppression{{Path: "docs/**", Reason: "Docs", Expires: "2026-01-01", Source: "cadence.yml"}}
+		if !reflect.DeepEqual(result.Expired, wantExpired) {
+			t.Errorf("Expired = %+v, want %+v", result.Expired, wantExpired)
+		}
+	})
 }
@@ -9,6 +9,16 @@ import (
 
 type ReportData struct {
 	Suspicious []*detector.SuspiciousCommit
+	// AuthorFindings and WindowFindings hold repository strategy findings
+	// about an author or a window of time rather than one commit.
+	AuthorFindings []detector.Finding
+	WindowFindings []detector.Finding
+	// Suppressed holds the findings suppressions cleared, and Expired the
+	// suppressions past their expiry date.
+	Suppressed []detector.SuppressedFinding
+	Expired    []detector.Suppression
+	// Known counts the findings left out because a baseline holds them.
+	Known      int
 	Stats      *metrics.RepositoryStats
 	Thresholds *detector.Thresholds
 }
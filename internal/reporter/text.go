@@ -32,6 +32,14 @@ func (r *TextReporter) Generate(data *ReportData) (string, error) {
 	sb.WriteString(fmt.Sprintf("  Additions:           %d lines\n", data.Stats.UnfilteredLOCAdded))
 	sb.WriteString(fmt.Sprintf("  Deletions:           %d lines\n\n", data.Stats.UnfilteredLOCDeleted))
 
+	if dropped := data.Stats.DroppedLines; dropped.Total() > 0 {
+		sb.WriteString("Lines Dropped by Reason:\n")
+		sb.WriteString(fmt.Sprintf("  exclude_files:       %d lines\n", dropped.ExcludeFiles))
+		sb.WriteString(fmt.Sprintf("  linguist-generated:  %d lines\n", dropped.Generated))
+		sb.WriteString(fmt.Sprintf("  linguist-vendored:   %d lines\n", dropped.Vendored))
+		sb.WriteString(fmt.Sprintf("  -diff:               %d lines\n\n", dropped.NoDiff))
+	}
+
 	sb.WriteString("VELOCITY STATISTICS\n")
 	sb.WriteString("-------------------\n")
 	sb.WriteString(fmt.Sprintf("Average Velocity:   %.2f LOC/min\n", data.Stats.AverageVelocity))
@@ -55,6 +63,10 @@ func (r *TextReporter) Generate(data *ReportData) (string, error) {
 	sb.WriteString(fmt.Sprintf("Min Time Delta:         %d seconds (0 = disabled)\n", data.Thresholds.MinTimeDeltaSeconds))
 	sb.WriteString("\n")
 
+	if data.Known > 0 {
+		sb.WriteString(fmt.Sprintf("Known findings:         %d (in the baseline, not reported)\n\n", data.Known))
+	}
+
 	sb.WriteString("SUSPICIOUS COMMITS\n")
 	sb.WriteString("!!!!!!!!!!!!!!!!!!\n")
 
@@ -67,7 +79,11 @@ func (r *TextReporter) Generate(data *ReportData) (string, error) {
 			sb.WriteString(fmt.Sprintf("[%d] Commit: %s\n", i+1, s.Pair.Current.Hash[:7]))
 			sb.WriteString(fmt.Sprintf("    Author:          %s <%s>\n", s.Pair.Current.Author, s.Pair.Current.Email))
 			sb.WriteString(fmt.Sprintf("    Date:            %s\n", s.Pair.Current.Timestamp.Format(time.RFC3339)))
-			sb.WriteString(fmt.Sprintf("    Confidence:      %.1f%%\n", s.Score*100))
+			if s.Severity != "" {
+				sb.WriteString(fmt.Sprintf("    Confidence:      %.1f%% (%s)\n", s.Score*100, s.Severity))
+			} else {
+				sb.WriteString(fmt.Sprintf("    Confidence:      %.1f%%\n", s.Score*100))
+			}
 			sb.WriteString(fmt.Sprintf("    Additions:       %d lines (filtered) / %d lines (total)\n", s.Pair.Stats.Additions, s.Pair.Stats.TotalAdditions))
 			sb.WriteString(fmt.Sprintf("    Deletions:       %d lines (filtered) / %d lines (total)\n", s.Pair.Stats.Deletions, s.Pair.Stats.TotalDeletions))
 			sb.WriteString(fmt.Sprintf("    Files Changed:   %d (filtered) / %d (total)\n", s.Pair.Stats.FilesChanged, s.Pair.Stats.FilesChangedTotal))
@@ -83,6 +99,16 @@ func (r *TextReporter) Generate(data *ReportData) (string, error) {
 			for _, reason := range s.Reasons {
 				sb.WriteString(fmt.Sprintf("      - %s\n", reason))
 			}
+			if len(s.Files) > 0 {
+				sb.WriteString("    Top Files:\n")
+				for j, f := range s.Files {
+					if j == maxTextFiles {
+						sb.WriteString(fmt.Sprintf("      ... and %d more\n", len(s.Files)-maxTextFiles))
+						break
+					}
+					sb.WriteString(fmt.Sprintf("      - %5.1f%%  %s%s\n", f.Score*100, f.Path, formatHunks(s.Findings, f.Path)))
+				}
+			}
 			if s.AIAnalysis != "" {
 				sb.WriteString(fmt.Sprintf("    AI Analysis:     %s\n", s.AIAnalysis))
 			}
@@ -90,9 +116,109 @@ func (r *TextReporter) Generate(data *ReportData) (string, error) {
 		}
 	}
 
+	if len(data.AuthorFindings) > 0 {
+		sb.WriteString("\nAUTHOR FINDINGS\n")
+		sb.WriteString("---------------\n")
+		for i, f := range data.AuthorFindings {
+			sb.WriteString(fmt.Sprintf("[%d] %s (%s, %s)\n", i+1, f.Author, f.Strategy, f.Severity))
+			if !f.Start.IsZero() {
+				sb.WriteString(fmt.Sprintf("    Since:           %s\n", f.Start.Format(time.RFC3339)))
+			}
+			sb.WriteString(fmt.Sprintf("    %s\n\n", f.Message))
+		}
+	}
+
+	if len(data.WindowFindings) > 0 {
+		sb.WriteString("\nTIME WINDOW FINDINGS\n")
+		sb.WriteString("--------------------\n")
+		for i, f := range data.WindowFindings {
+			sb.WriteString(fmt.Sprintf("[%d] %s - %s (%s, %s)\n", i+1,
+				f.Start.Format(time.RFC3339), f.End.Format(time.RFC3339), f.Strategy, f.Severity))
+			if f.Author != "" {
+				sb.WriteString(fmt.Sprintf("    Author:          %s\n", f.Author))
+			}
+			sb.WriteString(fmt.Sprintf("    Commits:         %d\n", len(f.Commits)))
+			sb.WriteString(fmt.Sprintf("    %s\n\n", f.Message))
+		}
+	}
+
+	if len(data.Suppressed) > 0 {
+		sb.WriteString("\nSUPPRESSED FINDINGS\n")
+		sb.WriteString("-------------------\n")
+		for i, sf := range data.Suppressed {
+			subject := sf.Finding.Author
+			if sf.Pair != nil {
+				subject = sf.Pair.Current.Hash[:7]
+			}
+			sb.WriteString(fmt.Sprintf("[%d] %s (%s, %s)\n", i+1, subject, sf.Finding.Strategy, sf.Finding.Severity))
+			sb.WriteString(fmt.Sprintf("    Reason:          %s\n", sf.Suppression.Reason))
+			sb.WriteString(fmt.Sprintf("    Suppressed by:   %s in %s%s\n", sf.Suppression.Describe(), sf.Suppression.Source, formatExpiry(sf.Suppression.Expires)))
+			sb.WriteString(fmt.Sprintf("    %s\n\n", sf.Finding.Message))
+		}
+	}
+
+	if len(data.Expired) > 0 {
+		sb.WriteString("\nEXPIRED SUPPRESSIONS\n")
+		sb.WriteString("--------------------\n")
+		for _, s := range data.Expired {
+			sb.WriteString(fmt.Sprintf("WARNING: suppression of %s in %s expired on %s (%s)\n", s.Describe(), s.Source, s.Expires, s.Reason))
+		}
+	}
+
 	return sb.String(), nil
 }
 
+func formatExpiry(expires string) string {
+	if expires == "" {
+		return ""
+	}
+	return ", until " + expires
+}
+
+// maxTextFiles caps the files listed per commit, and maxTextHunks the line
+// spans listed per file.
+const (
+	maxTextFiles = 5
+	maxTextHunks = 3
+)
+
+// formatHunks lists the line spans the findings point at in a file, such as
+// " (lines 12-40, 88)", or returns "" when they name the file only as a
+// whole.
+func formatHunks(findings []detector.Finding, path string) string {
+	seen := make(map[string]bool)
+	var spans []string
+	add := func(file string, start, end int) {
+		if file != path || start == 0 {
+			return
+		}
+		span := fmt.Sprintf("%d", start)
+		if end > start {
+			span = fmt.Sprintf("%d-%d", start, end)
+		}
+		if !seen[span] {
+			seen[span] = true
+			spans = append(spans, span)
+		}
+	}
+	for _, f := range findings {
+		if len(f.Locations) == 0 {
+			add(f.File, f.StartLine, f.EndLine)
+		}
+		for _, l := range f.Locations {
+			add(l.File, l.StartLine, l.EndLine)
+		}
+	}
+
+	if len(spans) == 0 {
+		return ""
+	}
+	if len(spans) > maxTextHunks {
+		spans = append(spans[:maxTextHunks], "...")
+	}
+	return fmt.Sprintf(" (lines %s)", strings.Join(spans, ", "))
+}
+
 func formatDuration(d time.Duration) string {
 	minutes := d.Minutes()
 	return fmt.Sprintf("%.0f minutes", minutes)
@@ -22,6 +22,7 @@ func TestTextReporter_Generate(t *testing.T) {
 				TimeSpan:         60 * time.Minute,
 				TotalLOCAdded:    500,
 				TotalLOCDeleted:  200,
+				DroppedLines:     git.DroppedLines{Generated: 120, NoDiff: 8},
 				AverageVelocity:  25.5,
 				MedianVelocity:   20.0,
 				VelocityPercentile: &metrics.Percentiles{
@@ -59,6 +60,9 @@ func TestTextReporter_Generate(t *testing.T) {
 			"VELOCITY STATISTICS",
 			"Average Velocity:   25.50 LOC/min",
 			"Median Velocity:    20.00 LOC/min",
+			"Lines Dropped by Reason:",
+			"linguist-generated:  120 lines",
+			"-diff:               8 lines",
 			"CONFIGURED THRESHOLDS",
 			"SUSPICIOUS COMMITS",
 			"No suspicious commits detected",
@@ -106,6 +110,19 @@ func TestTextReporter_Generate(t *testing.T) {
 						"Suspicious commit size: 500 additions (threshold: 100 lines)",
 						"Addition velocity too high: 100.0 additions/min (threshold: 50.0 additions/min)",
 					},
+					Findings: []detector.Finding{
+						{Category: "size", Message: "Suspicious commit size: 500 additions (threshold: 100 lines)"},
+						{Category: "code", File: "gen/api.go", StartLine: 12, EndLine: 40, Locations: []detector.Location{
+							{File: "gen/api.go", StartLine: 12, EndLine: 40},
+							{File: "gen/api.go", StartLine: 88, EndLine: 88},
+						}},
+					},
+					Score:    0.72,
+					Severity: detector.SeverityHigh,
+					Files: []detector.FileContribution{
+						{Path: "gen/api.go", Score: 0.6},
+						{Path: "main.go", Score: 0.05},
+					},
 				},
 			},
 			Stats: &metrics.RepositoryStats{
@@ -139,10 +156,14 @@ func TestTextReporter_Generate(t *testing.T) {
 			"Additions:       500 lines",
 			"Deletions:       50 lines",
 			"Files Changed:   10",
+			"Confidence:      72.0% (high)",
 			"Add Velocity:    100.00 additions/min",
 			"Del Velocity:    10.00 deletions/min",
 			"Suspicious commit size",
 			"Addition velocity too high",
+			"Top Files:",
+			" 60.0%  gen/api.go (lines 12-40, 88)",
+			"  5.0%  main.go\n",
 		}
 
 		for _, expected := range expectedStrings {
@@ -177,6 +198,79 @@ func TestTextReporter_Generate(t *testing.T) {
 			t.Fatal("Generate() returned empty output")
 		}
 	})
+	t.Run("generates author and window sections", func(t *testing.T) {
+		start := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+		data := &ReportData{
+			Suspicious: []*detector.SuspiciousCommit{},
+			AuthorFindings: []detector.Finding{{
+				Strategy: "cadence_shift", Scope: detector.ScopeAuthor, Author: "dev@example.com",
+				Start: start, Severity: detector.SeverityMedium, Message: "Commit cadence changed abruptly",
+			}},
+			WindowFindings: []detector.Finding{{
+				Strategy: "uniform_commit_burst", Scope: detector.ScopeWindow, Start: start,
+				End: start.Add(8 * time.Minute), Commits: []string{"a", "b", "c"},
+				Severity: detector.SeverityLow, Message: "3 commits within 8 minutes",
+			}},
+			Stats:      &metrics.RepositoryStats{},
+			Thresholds: &detector.Thresholds{SuspiciousAdditions: 100},
+		}
+
+		output, err := (&TextReporter{}).Generate(data)
+		if err != nil {
+			t.Fatalf("Generate() unexpected error = %v", err)
+		}
+		for _, want := range []string{
+			"AUTHOR FINDINGS",
+			"[1] dev@example.com (cadence_shift, medium)",
+			"Since:           2026-03-02T10:00:00Z",
+			"TIME WINDOW FINDINGS",
+			"[1] 2026-03-02T10:00:00Z - 2026-03-02T10:08:00Z (uniform_commit_burst, low)",
+			"Commits:         3",
+		} {
+			if !contains(output, want) {
+				t.Errorf("output missing %q", want)
+			}
+		}
+	})
+	t.Run("generates known, suppressed and expired sections", func(t *testing.T) {
+		pair := &git.CommitPair{Current: &git.Commit{Hash: "abc1234def"}}
+		data := &ReportData{
+			Suspicious: []*detector.SuspiciousCommit{},
+			Suppressed: []detector.SuppressedFinding{{
+				Pair: pair,
+				Finding: detector.Finding{
+					Strategy: "size_analysis", Severity: detector.SeverityMedium, Message: "Large commit: 900 additions",
+				},
+				Suppression: detector.Suppression{
+					Commit: "abc1234", Reason: "Vendored dependency", Expires: "2027-01-31", Source: ".cadence-ignore",
+				},
+			}},
+			Expired: []detector.Suppression{{
+				Author: "bot@example.com", Reason: "Release bot", Expires: "2026-01-01", Source: "cadence.yml",
+			}},
+			Known:      3,
+			Stats:      &metrics.RepositoryStats{},
+			Thresholds: &detector.Thresholds{SuspiciousAdditions: 100},
+		}
+
+		output, err := (&TextReporter{}).Generate(data)
+		if err != nil {
+			t.Fatalf("Generate() unexpected error = %v", err)
+		}
+		for _, want := range []string{
+			"Known findings:         3 (in the baseline, not reported)",
+			"SUPPRESSED FINDINGS",
+			"[1] abc1234 (size_analysis, medium)",
+			"Reason:          Vendored dependency",
+			"Suppressed by:   commit abc1234 in .cadence-ignore, until 2027-01-31",
+			"EXPIRED SUPPRESSIONS",
+			"WARNING: suppression of author bot@example.com in cadence.yml expired on 2026-01-01 (Release bot)",
+		} {
+			if !contains(output, want) {
+				t.Errorf("output missing %q", want)
+			}
+		}
+	})
 }
 
 func TestTruncate(t *testing.T) {
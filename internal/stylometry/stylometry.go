@@ -0,0 +1,273 @@
+// Package stylometry measures the writing habits a commit shows, such as
+// indentation, identifier length, comment phrasing, commit message shape
+// and punctuation, and compares them with a profile of the same author's
+// earlier commits.
+package stylometry
+
+import (
+	"math"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/TryCadence/Cadence/internal/langmodel"
+)
+
+// Features maps feature names to their value in one commit. A feature is
+// missing when the commit holds too little material to measure it, such as
+// comment phrasing in a commit without comments.
+type Features map[string]float64
+
+// Feature names.
+const (
+	IndentTabs         = "indent_tabs"
+	IndentWidth        = "indent_width"
+	IdentifierLength   = "identifier_length"
+	CommentWords       = "comment_words"
+	CommentCapitalized = "comment_capitalized"
+	CommentPeriod      = "comment_period"
+	SubjectLength      = "subject_length"
+	SubjectCapitalized = "subject_capitalized"
+	SubjectPeriod      = "subject_period"
+	MessageWords       = "message_words"
+	PunctuationRate    = "punctuation_rate"
+)
+
+// scales are the smallest spread a profile is given per feature. An author
+// who always indents with tabs has no spread at all, and a single space
+// indented line should not count as an infinite drift.
+var scales = map[string]float64{
+	IndentTabs:         0.1,
+	IndentWidth:        1,
+	IdentifierLength:   0.5,
+	CommentWords:       1.5,
+	CommentCapitalized: 0.15,
+	CommentPeriod:      0.15,
+	SubjectLength:      8,
+	SubjectCapitalized: 0.25,
+	SubjectPeriod:      0.25,
+	MessageWords:       5,
+	PunctuationRate:    0.03,
+}
+
+// Minimum material for a feature to be measured.
+const (
+	minIndentedLines = 5
+	minIdentifiers   = 20
+	minCommentLines  = 3
+	minProseWords    = 10
+)
+
+// proseExtensions are documentation files. Their lines are prose rather
+// than code, so they would distort the code features.
+var proseExtensions = map[string]bool{
+	".md": true, ".markdown": true, ".rst": true, ".txt": true, ".adoc": true,
+}
+
+// Extract measures a commit from its unified diff and message.
+func Extract(diff, message string) Features {
+	f := make(Features)
+
+	var tabIndented, spaceIndented int
+	spaceWidths := make(map[int]int)
+	var identifiers, identifierChars int
+	var comments []string
+	prose := false
+	for _, line := range strings.Split(diff, "\n") {
+		if strings.HasPrefix(line, "+++ ") {
+			prose = proseExtensions[strings.ToLower(path.Ext(strings.TrimSpace(line[4:])))]
+			continue
+		}
+		if !strings.HasPrefix(line, "+") || prose {
+			continue
+		}
+		line = line[1:]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+
+		switch {
+		case line[0] == '\t':
+			tabIndented++
+		case line[0] == ' ':
+			spaceIndented++
+			spaceWidths[len(line)-len(strings.TrimLeft(line, " "))]++
+		}
+
+		if text, ok := commentText(trimmed); ok {
+			if text != "" {
+				comments = append(comments, text)
+			}
+			continue
+		}
+		for _, token := range langmodel.CodeTokens(trimmed) {
+			r, _ := utf8.DecodeRuneInString(token)
+			if r == '_' || unicode.IsLetter(r) {
+				identifiers++
+				identifierChars += utf8.RuneCountInString(token)
+			}
+		}
+	}
+
+	if tabIndented+spaceIndented >= minIndentedLines {
+		f[IndentTabs] = float64(tabIndented) / float64(tabIndented+spaceIndented)
+	}
+	if spaceIndented >= minIndentedLines {
+		f[IndentWidth] = float64(indentUnit(spaceWidths))
+	}
+	if identifiers >= minIdentifiers {
+		f[IdentifierLength] = float64(identifierChars) / float64(identifiers)
+	}
+	if len(comments) >= minCommentLines {
+		var words, capitalized, period int
+		for _, c := range comments {
+			words += len(strings.Fields(c))
+			if startsUpper(c) {
+				capitalized++
+			}
+			if strings.HasSuffix(c, ".") {
+				period++
+			}
+		}
+		n := float64(len(comments))
+		f[CommentWords] = float64(words) / n
+		f[CommentCapitalized] = float64(capitalized) / n
+		f[CommentPeriod] = float64(period) / n
+	}
+
+	message = strings.TrimSpace(message)
+	if message != "" {
+		subject := strings.TrimSpace(strings.SplitN(message, "\n", 2)[0])
+		f[SubjectLength] = float64(utf8.RuneCountInString(subject))
+		f[SubjectCapitalized] = boolValue(startsUpper(subject))
+		f[SubjectPeriod] = boolValue(strings.HasSuffix(subject, "."))
+		f[MessageWords] = float64(len(strings.Fields(message)))
+	}
+
+	text := message + "\n" + strings.Join(comments, "\n")
+	if words := len(strings.Fields(text)); words >= minProseWords {
+		marks := strings.Count(text, ",") + strings.Count(text, ";") + strings.Count(text, ":") +
+			strings.Count(text, "!") + strings.Count(text, "?") + strings.Count(text, "—") +
+			strings.Count(text, "–") + strings.Count(text, "…")
+		f[PunctuationRate] = float64(marks) / float64(words)
+	}
+	return f
+}
+
+// commentText returns the text of a line holding only a comment.
+func commentText(trimmed string) (string, bool) {
+	for _, prefix := range []string{"///", "//", "/**", "/*", "*/", "* ", "#", "--"} {
+		if strings.HasPrefix(trimmed, prefix) {
+			text := strings.TrimPrefix(trimmed, prefix)
+			text = strings.TrimSuffix(strings.TrimSpace(text), "*/")
+			return strings.TrimSpace(text), true
+		}
+	}
+	if trimmed == "*" {
+		return "", true
+	}
+	return "", false
+}
+
+// indentUnit is the smallest space indentation found on more than one
+// line, or the smallest of all when none repeats: the author's step.
+func indentUnit(widths map[int]int) int {
+	keys := make([]int, 0, len(widths))
+	for w := range widths {
+		keys = append(keys, w)
+	}
+	sort.Ints(keys)
+	for _, w := range keys {
+		if widths[w] > 1 {
+			return w
+		}
+	}
+	return keys[0]
+}
+
+func startsUpper(s string) bool {
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			return unicode.IsUpper(r)
+		}
+	}
+	return false
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Profile accumulates an author's features over their commits.
+type Profile struct {
+	Commits int
+	sums    map[string]stat
+}
+
+type stat struct {
+	n          int
+	sum, sumSq float64
+}
+
+// Add folds one commit's features into the profile.
+func (p *Profile) Add(f Features) {
+	if p.sums == nil {
+		p.sums = make(map[string]stat)
+	}
+	p.Commits++
+	for name, v := range f {
+		s := p.sums[name]
+		s.n++
+		s.sum += v
+		s.sumSq += v * v
+		p.sums[name] = s
+	}
+}
+
+// Drift is how far one feature of a commit lies from the profile, in
+// profile standard deviations.
+type Drift struct {
+	Feature  string
+	Observed float64
+	Typical  float64
+	Z        float64
+}
+
+// Compare measures f against the features the profile saw at least
+// minSamples times. It returns the root mean square of the per-feature
+// z-scores and the drifts, largest first.
+func (p *Profile) Compare(f Features, minSamples int) (float64, []Drift) {
+	drifts := make([]Drift, 0, len(f))
+	var sumSq float64
+	for name, v := range f {
+		s := p.sums[name]
+		if s.n < minSamples || s.n == 0 {
+			continue
+		}
+		mean := s.sum / float64(s.n)
+		std := math.Sqrt(math.Max(s.sumSq/float64(s.n)-mean*mean, 0))
+		if scale := scales[name]; std < scale {
+			std = scale
+		}
+		z := math.Abs(v-mean) / std
+		sumSq += z * z
+		drifts = append(drifts, Drift{Feature: name, Observed: v, Typical: mean, Z: z})
+	}
+	if len(drifts) == 0 {
+		return 0, drifts
+	}
+
+	sort.Slice(drifts, func(i, j int) bool {
+		if drifts[i].Z != drifts[j].Z {
+			return drifts[i].Z > drifts[j].Z
+		}
+		return drifts[i].Feature < drifts[j].Feature
+	})
+	return math.Sqrt(sumSq / float64(len(drifts))), drifts
+}
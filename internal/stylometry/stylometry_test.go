@@ -0,0 +1,165 @@
+package stylometry
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func diffOf(file string, lines ...string) string {
+	return "diff --git a/" + file + " b/" + file + "\n--- a/" + file + "\n+++ b/" + file + "\n@@ -0,0 +1 @@\n+" + strings.Join(lines, "\n+") + "\n"
+}
+
+func TestExtract(t *testing.T) {
+	goCode := diffOf("main.go",
+		"// Load reads the configuration file.",
+		"// It returns an error when the file is missing.",
+		"// Callers should check it.",
+		"func Load(configurationPath string) error {",
+		"\tcontents, readError := os.ReadFile(configurationPath)",
+		"\tif readError != nil {",
+		"\t\treturn readError",
+		"\t}",
+		"\treturn parseConfiguration(contents)",
+		"}",
+	)
+	pyCode := diffOf("main.py",
+		"# load the config",
+		"# no error handling here",
+		"# todo: retry",
+		"def load(p):",
+		"    d = open(p)",
+		"    if not d:",
+		"        return None",
+		"    return d",
+		"    # done",
+	)
+
+	tests := []struct {
+		name    string
+		diff    string
+		message string
+		want    Features
+		missing []string
+	}{
+		{
+			name:    "tabs and capitalized comments",
+			diff:    goCode,
+			message: "Add configuration loading\n\nReads the file, then parses it.",
+			want: Features{
+				IndentTabs:         1,
+				CommentWords:       6,
+				CommentCapitalized: 1,
+				CommentPeriod:      1,
+				SubjectLength:      25,
+				SubjectCapitalized: 1,
+				SubjectPeriod:      0,
+				MessageWords:       9,
+				PunctuationRate:    1.0 / 27,
+			},
+			missing: []string{IndentWidth, IdentifierLength},
+		},
+		{
+			name:    "spaces and terse comments",
+			diff:    pyCode,
+			message: "fix load.",
+			want: Features{
+				IndentTabs:         0,
+				IndentWidth:        4,
+				CommentWords:       2.5,
+				CommentCapitalized: 0,
+				CommentPeriod:      0,
+				SubjectLength:      9,
+				SubjectCapitalized: 0,
+				SubjectPeriod:      1,
+				MessageWords:       2,
+				PunctuationRate:    1.0 / 12,
+			},
+			missing: []string{IdentifierLength},
+		},
+		{
+			name:    "documentation is skipped",
+			diff:    diffOf("README.md", "# Title", "# Another", "# Third", "    indented", "    more", "    lines", "    here", "    now"),
+			message: "",
+			missing: []string{IndentTabs, CommentWords, SubjectLength, PunctuationRate},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Extract(tt.diff, tt.message)
+			for name, want := range tt.want {
+				v, ok := got[name]
+				if !ok {
+					t.Errorf("%s missing", name)
+					continue
+				}
+				if math.Abs(v-want) > 1e-9 {
+					t.Errorf("%s = %v, want %v", name, v, want)
+				}
+			}
+			for _, name := range tt.missing {
+				if v, ok := got[name]; ok {
+					t.Errorf("%s = %v, want missing", name, v)
+				}
+			}
+		})
+	}
+}
+
+func TestProfileCompare(t *testing.T) {
+	var profile Profile
+	for i := 0; i < 10; i++ {
+		profile.Add(Features{
+			IndentTabs:         1,
+			SubjectLength:      float64(40 + i%3*10),
+			SubjectCapitalized: 1,
+			IdentifierLength:   6,
+		})
+	}
+	profile.Add(Features{CommentWords: 5})
+
+	tests := []struct {
+		name         string
+		features     Features
+		wantCompared int
+		wantFirst    string
+		wantDistance float64
+	}{
+		{
+			name:         "same style",
+			features:     Features{IndentTabs: 1, SubjectLength: 49, SubjectCapitalized: 1, IdentifierLength: 6},
+			wantCompared: 4,
+			wantDistance: 0,
+		},
+		{
+			name:         "spaces instead of tabs",
+			features:     Features{IndentTabs: 0, SubjectLength: 49, SubjectCapitalized: 1, IdentifierLength: 6},
+			wantCompared: 4,
+			wantFirst:    IndentTabs,
+			// z of 10 on one feature of four: sqrt(100/4)
+			wantDistance: 5,
+		},
+		{
+			name:         "rare features are not compared",
+			features:     Features{CommentWords: 20, IdentifierLength: 6},
+			wantCompared: 1,
+			wantDistance: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			distance, drifts := profile.Compare(tt.features, 5)
+			if len(drifts) != tt.wantCompared {
+				t.Fatalf("compared %d features, want %d", len(drifts), tt.wantCompared)
+			}
+			if tt.wantFirst != "" && drifts[0].Feature != tt.wantFirst {
+				t.Errorf("largest drift = %s, want %s", drifts[0].Feature, tt.wantFirst)
+			}
+			if math.Abs(distance-tt.wantDistance) > 0.01 {
+				t.Errorf("distance = %v, want %v", distance, tt.wantDistance)
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package webhook
 
-import "time"
+import (
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/detector"
+)
 
 const (
 	// Job status constants
@@ -54,9 +58,25 @@ type Suspicion struct {
 	Message     string
 	Severity    string // "low", "medium", "high"
 	Reasons     []string
+	Findings    []detector.Finding
+	Files       []detector.FileContribution
 	Score       float64
 }
 
+// NewSuspicion converts a detector result for the commit at index.
+func NewSuspicion(index int, s *detector.SuspiciousCommit) Suspicion {
+	return Suspicion{
+		CommitHash:  s.Pair.Current.Hash,
+		CommitIndex: index,
+		Message:     s.Pair.Current.Message,
+		Severity:    string(s.Severity),
+		Reasons:     s.Reasons,
+		Findings:    s.Findings,
+		Files:       s.Files,
+		Score:       s.Score,
+	}
+}
+
 type GithubPushPayload struct {
 	Ref        string `json:"ref"`
 	Before     string `json:"before"`
@@ -1,8 +1,12 @@
 package webhook
 
 import (
+	"reflect"
 	"testing"
 	"time"
+
+	"github.com/TryCadence/Cadence/internal/detector"
+	"github.com/TryCadence/Cadence/internal/git"
 )
 
 func TestWebhookJob(t *testing.T) {
@@ -119,3 +123,36 @@ func TestJobResult(t *testing.T) {
 		}
 	})
 }
+
+func TestNewSuspicion(t *testing.T) {
+	finding := detector.Finding{
+		Strategy:   "size_analysis",
+		Category:   "size",
+		Severity:   detector.SeverityMedium,
+		Confidence: 0.75,
+		Observed:   1000,
+		Threshold:  500,
+		Message:    "Suspicious commit size: 1000 additions (threshold: 500 lines)",
+	}
+	s := &detector.SuspiciousCommit{
+		Pair: &git.CommitPair{
+			Current: &git.Commit{Hash: "abc123", Message: "Add parser"},
+			Stats:   &git.DiffStats{Additions: 1000},
+		},
+		Findings: []detector.Finding{finding},
+		Reasons:  []string{finding.Message},
+		Score:    0.5,
+		Severity: detector.SeverityMedium,
+	}
+
+	got := NewSuspicion(3, s)
+	if got.CommitHash != "abc123" || got.CommitIndex != 3 || got.Message != "Add parser" {
+		t.Errorf("NewSuspicion() commit = %q/%d/%q", got.CommitHash, got.CommitIndex, got.Message)
+	}
+	if got.Severity != "medium" || got.Score != 0.5 {
+		t.Errorf("Severity, Score = %q, %v, want medium, 0.5", got.Severity, got.Score)
+	}
+	if !reflect.DeepEqual(got.Findings, []detector.Finding{finding}) {
+		t.Errorf("Findings = %+v, want [%+v]", got.Findings, finding)
+	}
+}